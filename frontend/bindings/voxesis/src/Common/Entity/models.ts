@@ -5,6 +5,192 @@
 // @ts-ignore: Unused imports
 import {Create as $Create} from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as json$0 from "../../../../encoding/json/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as jsontext$0 from "../../../../encoding/json/jsontext/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../../../../time/models.js";
+
+/**
+ * Backup 备份目录中的一个归档文件
+ */
+export class Backup {
+    "id": number;
+    "processId": number;
+    "fileName": string;
+
+    /**
+     * 归档文件的完整路径
+     */
+    "path": string;
+
+    /**
+     * zip / tar.zst
+     */
+    "format": string;
+
+    /**
+     * 归档文件的大小
+     */
+    "sizeBytes": number;
+
+    /**
+     * 归档中的文件数
+     */
+    "files": number;
+
+    /**
+     * 归档文件的 SHA-256，十六进制
+     */
+    "sha256": string;
+
+    /**
+     * 创建归档的耗时
+     */
+    "durationMs": number;
+
+    /**
+     * manual / schedule
+     */
+    "trigger": string;
+
+    /**
+     * 开始备份的时间，Unix 毫秒
+     */
+    "createdAt": number;
+
+    /** Creates a new Backup instance. */
+    constructor($$source: Partial<Backup> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("processId" in $$source)) {
+            this["processId"] = 0;
+        }
+        if (!("fileName" in $$source)) {
+            this["fileName"] = "";
+        }
+        if (!("path" in $$source)) {
+            this["path"] = "";
+        }
+        if (!("format" in $$source)) {
+            this["format"] = "";
+        }
+        if (!("sizeBytes" in $$source)) {
+            this["sizeBytes"] = 0;
+        }
+        if (!("files" in $$source)) {
+            this["files"] = 0;
+        }
+        if (!("sha256" in $$source)) {
+            this["sha256"] = "";
+        }
+        if (!("durationMs" in $$source)) {
+            this["durationMs"] = 0;
+        }
+        if (!("trigger" in $$source)) {
+            this["trigger"] = "";
+        }
+        if (!("createdAt" in $$source)) {
+            this["createdAt"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Backup instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Backup {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new Backup($$parsedSource as Partial<Backup>);
+    }
+}
+
+/**
+ * BackupPolicy 进程的备份设置
+ * 保留规则同时生效: 最近的 KeepLast 个备份，以及最近 KeepDaily 天、KeepWeekly 周中每天、每周最新的一个备份，全部为 0 时不删除旧备份
+ */
+export class BackupPolicy {
+    /**
+     * 要备份的文件或目录，相对路径基于服务器目录，为空时自动识别世界目录
+     */
+    "paths": string[];
+
+    /**
+     * zip / tar.zst，为空使用 zip
+     */
+    "format": string;
+
+    /**
+     * 存放备份的目录，为空使用 Voxesis 数据目录下的 backups/<进程ID>
+     */
+    "directory": string;
+
+    /**
+     * 保留最近的备份数
+     */
+    "keepLast": number;
+
+    /**
+     * 按天保留的天数
+     */
+    "keepDaily": number;
+
+    /**
+     * 按周保留的周数
+     */
+    "keepWeekly": number;
+
+    /**
+     * 备份运行中的服务器时暂停保存的方式: auto / bedrock / java / off，为空使用 auto
+     */
+    "saveMode": string;
+
+    /** Creates a new BackupPolicy instance. */
+    constructor($$source: Partial<BackupPolicy> = {}) {
+        if (!("paths" in $$source)) {
+            this["paths"] = [];
+        }
+        if (!("format" in $$source)) {
+            this["format"] = "";
+        }
+        if (!("directory" in $$source)) {
+            this["directory"] = "";
+        }
+        if (!("keepLast" in $$source)) {
+            this["keepLast"] = 0;
+        }
+        if (!("keepDaily" in $$source)) {
+            this["keepDaily"] = 0;
+        }
+        if (!("keepWeekly" in $$source)) {
+            this["keepWeekly"] = 0;
+        }
+        if (!("saveMode" in $$source)) {
+            this["saveMode"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new BackupPolicy instance from a string or object.
+     */
+    static createFrom($$source: any = {}): BackupPolicy {
+        const $$createField0_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("paths" in $$parsedSource) {
+            $$parsedSource["paths"] = $$createField0_0($$parsedSource["paths"]);
+        }
+        return new BackupPolicy($$parsedSource as Partial<BackupPolicy>);
+    }
+}
+
 export class BedrockMcServerStatus {
     "motd"?: string | null;
     "protocol"?: number | null;
@@ -32,6 +218,516 @@ export class BedrockMcServerStatus {
     }
 }
 
+/**
+ * CgroupState 进程所在 cgroup 的资源限制状态
+ */
+export class CgroupState {
+    /**
+     * 资源限制是否已生效
+     */
+    "enabled": boolean;
+
+    /**
+     * 未能启用资源限制的原因
+     */
+    "warning"?: string;
+
+    /**
+     * memory.current，包括子进程
+     */
+    "memoryMb": number;
+
+    /**
+     * 因超出 cpu.max 被限流的次数
+     */
+    "throttledCount": number;
+
+    /**
+     * 被限流的总时长，微秒
+     */
+    "throttledUsec": number;
+
+    /**
+     * 内存达到 memory.max 的次数
+     */
+    "oomCount": number;
+
+    /**
+     * 被 OOM killer 杀死的进程数
+     */
+    "oomKillCount": number;
+
+    /** Creates a new CgroupState instance. */
+    constructor($$source: Partial<CgroupState> = {}) {
+        if (!("enabled" in $$source)) {
+            this["enabled"] = false;
+        }
+        if (!("memoryMb" in $$source)) {
+            this["memoryMb"] = 0;
+        }
+        if (!("throttledCount" in $$source)) {
+            this["throttledCount"] = 0;
+        }
+        if (!("throttledUsec" in $$source)) {
+            this["throttledUsec"] = 0;
+        }
+        if (!("oomCount" in $$source)) {
+            this["oomCount"] = 0;
+        }
+        if (!("oomKillCount" in $$source)) {
+            this["oomKillCount"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CgroupState instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CgroupState {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new CgroupState($$parsedSource as Partial<CgroupState>);
+    }
+}
+
+/**
+ * JobRun 任务的一次执行记录
+ */
+export class JobRun {
+    "id": number;
+    "jobId": number;
+
+    /**
+     * 计划执行的时间，Unix 毫秒
+     */
+    "scheduledAt": number;
+
+    /**
+     * 实际开始的时间，Unix 毫秒
+     */
+    "startedAt": number;
+
+    /**
+     * 执行中为空
+     */
+    "finishedAt"?: number | null;
+
+    /**
+     * running / success / failed / missed
+     */
+    "status": string;
+
+    /**
+     * 失败原因
+     */
+    "error"?: string;
+
+    /** Creates a new JobRun instance. */
+    constructor($$source: Partial<JobRun> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("jobId" in $$source)) {
+            this["jobId"] = 0;
+        }
+        if (!("scheduledAt" in $$source)) {
+            this["scheduledAt"] = 0;
+        }
+        if (!("startedAt" in $$source)) {
+            this["startedAt"] = 0;
+        }
+        if (!("status" in $$source)) {
+            this["status"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new JobRun instance from a string or object.
+     */
+    static createFrom($$source: any = {}): JobRun {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new JobRun($$parsedSource as Partial<JobRun>);
+    }
+}
+
+/**
+ * LifecycleState 进程的生命周期状态
+ */
+export enum LifecycleState {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * 已创建，从未启动
+     */
+    LifecycleCreated = "created",
+
+    /**
+     * 正在启动
+     */
+    LifecycleStarting = "starting",
+
+    /**
+     * 正在运行
+     */
+    LifecycleRunning = "running",
+
+    /**
+     * 正在执行停止流程
+     */
+    LifecycleStopping = "stopping",
+
+    /**
+     * 被手动停止或正常退出
+     */
+    LifecycleStopped = "stopped",
+
+    /**
+     * 异常退出或启动失败
+     */
+    LifecycleCrashed = "crashed",
+};
+
+/**
+ * LogRecord 从一行服务器日志中解析出的结构化记录
+ */
+export class LogRecord {
+    /**
+     * bedrock / java
+     */
+    "format": string;
+
+    /**
+     * stdout / stderr，伪终端输出为空
+     */
+    "stream"?: string;
+
+    /**
+     * 日志中的时间，Unix 毫秒
+     */
+    "time": number;
+
+    /**
+     * INFO / WARN / ERROR 等，统一为大写
+     */
+    "level": string;
+
+    /**
+     * Java 版的线程名，基岩版为空
+     */
+    "source"?: string;
+    "message": string;
+
+    /** Creates a new LogRecord instance. */
+    constructor($$source: Partial<LogRecord> = {}) {
+        if (!("format" in $$source)) {
+            this["format"] = "";
+        }
+        if (!("time" in $$source)) {
+            this["time"] = 0;
+        }
+        if (!("level" in $$source)) {
+            this["level"] = "";
+        }
+        if (!("message" in $$source)) {
+            this["message"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new LogRecord instance from a string or object.
+     */
+    static createFrom($$source: any = {}): LogRecord {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new LogRecord($$parsedSource as Partial<LogRecord>);
+    }
+}
+
+/**
+ * MetricSample 进程在一个时间段内的资源使用指标，原始采样的 Samples 为 1
+ */
+export class MetricSample {
+    /**
+     * 时间段的起点，Unix 毫秒
+     */
+    "time": number;
+
+    /**
+     * 平均 CPU 使用率
+     */
+    "cpu": number;
+
+    /**
+     * 最高 CPU 使用率
+     */
+    "cpuMax": number;
+
+    /**
+     * 平均 RSS
+     */
+    "memoryMb": number;
+
+    /**
+     * 最高 RSS
+     */
+    "memoryMaxMb": number;
+
+    /**
+     * 最多线程数
+     */
+    "threads": number;
+
+    /**
+     * 平均读取速率，字节/秒
+     */
+    "readRate": number;
+
+    /**
+     * 平均写入速率，字节/秒
+     */
+    "writeRate": number;
+
+    /**
+     * 聚合的原始采样数
+     */
+    "samples": number;
+
+    /** Creates a new MetricSample instance. */
+    constructor($$source: Partial<MetricSample> = {}) {
+        if (!("time" in $$source)) {
+            this["time"] = 0;
+        }
+        if (!("cpu" in $$source)) {
+            this["cpu"] = 0;
+        }
+        if (!("cpuMax" in $$source)) {
+            this["cpuMax"] = 0;
+        }
+        if (!("memoryMb" in $$source)) {
+            this["memoryMb"] = 0;
+        }
+        if (!("memoryMaxMb" in $$source)) {
+            this["memoryMaxMb"] = 0;
+        }
+        if (!("threads" in $$source)) {
+            this["threads"] = 0;
+        }
+        if (!("readRate" in $$source)) {
+            this["readRate"] = 0;
+        }
+        if (!("writeRate" in $$source)) {
+            this["writeRate"] = 0;
+        }
+        if (!("samples" in $$source)) {
+            this["samples"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new MetricSample instance from a string or object.
+     */
+    static createFrom($$source: any = {}): MetricSample {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new MetricSample($$parsedSource as Partial<MetricSample>);
+    }
+}
+
+/**
+ * OnlinePlayer 当前在线的玩家
+ */
+export class OnlinePlayer {
+    "name": string;
+
+    /**
+     * 仅基岩版
+     */
+    "xuid"?: string;
+
+    /**
+     * 加入时间，Unix 毫秒
+     */
+    "joinedAt": number;
+
+    /** Creates a new OnlinePlayer instance. */
+    constructor($$source: Partial<OnlinePlayer> = {}) {
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("joinedAt" in $$source)) {
+            this["joinedAt"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OnlinePlayer instance from a string or object.
+     */
+    static createFrom($$source: any = {}): OnlinePlayer {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new OnlinePlayer($$parsedSource as Partial<OnlinePlayer>);
+    }
+}
+
+/**
+ * OutputLine 带序号的一行进程输出，序号在进程管理器的生命周期内单调递增
+ */
+export class OutputLine {
+    "seq": number;
+
+    /**
+     * 输出时间，Unix 毫秒
+     */
+    "time": number;
+    "data": string;
+
+    /**
+     * 识别出服务器日志格式时的结构化记录
+     */
+    "record"?: LogRecord | null;
+
+    /** Creates a new OutputLine instance. */
+    constructor($$source: Partial<OutputLine> = {}) {
+        if (!("seq" in $$source)) {
+            this["seq"] = 0;
+        }
+        if (!("time" in $$source)) {
+            this["time"] = 0;
+        }
+        if (!("data" in $$source)) {
+            this["data"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OutputLine instance from a string or object.
+     */
+    static createFrom($$source: any = {}): OutputLine {
+        const $$createField3_0 = $$createType2;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("record" in $$parsedSource) {
+            $$parsedSource["record"] = $$createField3_0($$parsedSource["record"]);
+        }
+        return new OutputLine($$parsedSource as Partial<OutputLine>);
+    }
+}
+
+/**
+ * PlayerPlaytime 玩家在一个进程上的累计游戏时长
+ */
+export class PlayerPlaytime {
+    "name": string;
+    "xuid"?: string;
+
+    /**
+     * 会话次数
+     */
+    "sessions": number;
+
+    /**
+     * 累计时长，包括仍在进行的会话
+     */
+    "totalMs": number;
+
+    /**
+     * 最近一次在线的时间，Unix 毫秒
+     */
+    "lastSeen": number;
+
+    /** Creates a new PlayerPlaytime instance. */
+    constructor($$source: Partial<PlayerPlaytime> = {}) {
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("sessions" in $$source)) {
+            this["sessions"] = 0;
+        }
+        if (!("totalMs" in $$source)) {
+            this["totalMs"] = 0;
+        }
+        if (!("lastSeen" in $$source)) {
+            this["lastSeen"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PlayerPlaytime instance from a string or object.
+     */
+    static createFrom($$source: any = {}): PlayerPlaytime {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new PlayerPlaytime($$parsedSource as Partial<PlayerPlaytime>);
+    }
+}
+
+/**
+ * PlayerSession 玩家的一次游戏会话
+ */
+export class PlayerSession {
+    "id": number;
+    "processId": number;
+    "name": string;
+    "xuid"?: string;
+
+    /**
+     * Unix 毫秒
+     */
+    "joinedAt": number;
+
+    /**
+     * Unix 毫秒，仍在线时为空
+     */
+    "leftAt": number | null;
+
+    /**
+     * 仍在线时计算到当前时间
+     */
+    "durationMs": number;
+
+    /** Creates a new PlayerSession instance. */
+    constructor($$source: Partial<PlayerSession> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("processId" in $$source)) {
+            this["processId"] = 0;
+        }
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("joinedAt" in $$source)) {
+            this["joinedAt"] = 0;
+        }
+        if (!("leftAt" in $$source)) {
+            this["leftAt"] = null;
+        }
+        if (!("durationMs" in $$source)) {
+            this["durationMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new PlayerSession instance from a string or object.
+     */
+    static createFrom($$source: any = {}): PlayerSession {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new PlayerSession($$parsedSource as Partial<PlayerSession>);
+    }
+}
+
 export class Plugin {
     "PluginName": string;
     "PluginType": PluginType;
@@ -75,12 +771,211 @@ export enum PluginType {
     Theme = "theme",
 };
 
+/**
+ * ProcessDefinition 持久化的进程定义，重启 Voxesis 后据此恢复进程列表
+ */
+export class ProcessDefinition {
+    "id": number;
+    "processType": number;
+    "path": string;
+    "args": string[];
+
+    /**
+     * 为空时使用可执行文件所在目录
+     */
+    "workingDir": string;
+
+    /**
+     * 覆盖或追加到系统环境变量
+     */
+    "env": { [_: string]: string };
+
+    /**
+     * 重启策略、停止流程等进程配置
+     */
+    "options": json$0.RawMessage;
+
+    /** Creates a new ProcessDefinition instance. */
+    constructor($$source: Partial<ProcessDefinition> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("processType" in $$source)) {
+            this["processType"] = 0;
+        }
+        if (!("path" in $$source)) {
+            this["path"] = "";
+        }
+        if (!("args" in $$source)) {
+            this["args"] = [];
+        }
+        if (!("workingDir" in $$source)) {
+            this["workingDir"] = "";
+        }
+        if (!("env" in $$source)) {
+            this["env"] = {};
+        }
+        if (!("options" in $$source)) {
+            this["options"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProcessDefinition instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProcessDefinition {
+        const $$createField3_0 = $$createType0;
+        const $$createField5_0 = $$createType3;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("args" in $$parsedSource) {
+            $$parsedSource["args"] = $$createField3_0($$parsedSource["args"]);
+        }
+        if ("env" in $$parsedSource) {
+            $$parsedSource["env"] = $$createField5_0($$parsedSource["env"]);
+        }
+        return new ProcessDefinition($$parsedSource as Partial<ProcessDefinition>);
+    }
+}
+
+/**
+ * ProcessLifecycle 进程的生命周期记录
+ */
+export class ProcessLifecycle {
+    "state": LifecycleState;
+
+    /**
+     * 最近一次退出的退出码，未退出或未知时为空
+     */
+    "exitCode": number | null;
+
+    /**
+     * 最近一次退出时终止进程的信号，仅类 Unix 平台
+     */
+    "signal": string;
+
+    /**
+     * 最近一次启动成功的时间
+     */
+    "startedAt": time$0.Time | null;
+
+    /**
+     * 最近一次退出的时间
+     */
+    "stoppedAt": time$0.Time | null;
+
+    /**
+     * 最近一次启动失败或异常退出的错误
+     */
+    "lastError": string;
+
+    /**
+     * 是否已通过就绪检测
+     */
+    "ready": boolean;
+
+    /**
+     * 最近一次通过就绪检测的时间
+     */
+    "readyAt": time$0.Time | null;
+
+    /** Creates a new ProcessLifecycle instance. */
+    constructor($$source: Partial<ProcessLifecycle> = {}) {
+        if (!("state" in $$source)) {
+            this["state"] = ("" as LifecycleState);
+        }
+        if (!("exitCode" in $$source)) {
+            this["exitCode"] = null;
+        }
+        if (!("signal" in $$source)) {
+            this["signal"] = "";
+        }
+        if (!("startedAt" in $$source)) {
+            this["startedAt"] = null;
+        }
+        if (!("stoppedAt" in $$source)) {
+            this["stoppedAt"] = null;
+        }
+        if (!("lastError" in $$source)) {
+            this["lastError"] = "";
+        }
+        if (!("ready" in $$source)) {
+            this["ready"] = false;
+        }
+        if (!("readyAt" in $$source)) {
+            this["readyAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProcessLifecycle instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProcessLifecycle {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ProcessLifecycle($$parsedSource as Partial<ProcessLifecycle>);
+    }
+}
+
+/**
+ * ProcessState 进程的资源使用情况，资源字段为进程及其所有后代进程的汇总
+ */
 export class ProcessState {
     "pid": string;
+
+    /**
+     * 占整机 CPU 的百分比
+     */
     "cpu": number;
+
+    /**
+     * RSS，单位 MB
+     */
     "memory": number;
     "runTime": string;
 
+    /**
+     * 是否已通过就绪检测
+     */
+    "ready": boolean;
+
+    /**
+     * 100 表示占满一个 CPU 核心
+     */
+    "cpuPerCore": number;
+
+    /**
+     * 线程数
+     */
+    "threads": number;
+
+    /**
+     * 打开的文件描述符数，平台不支持时为 0
+     */
+    "openFiles": number;
+
+    /**
+     * 累计读取字节数，平台不支持时为 0
+     */
+    "readBytes": number;
+
+    /**
+     * 累计写入字节数，平台不支持时为 0
+     */
+    "writeBytes": number;
+
+    /**
+     * 进程树中的进程数
+     */
+    "processes": number;
+
+    /**
+     * 配置了资源限制时的 cgroup 状态
+     */
+    "cgroup"?: CgroupState | null;
+
     /** Creates a new ProcessState instance. */
     constructor($$source: Partial<ProcessState> = {}) {
         if (!("pid" in $$source)) {
@@ -95,6 +990,27 @@ export class ProcessState {
         if (!("runTime" in $$source)) {
             this["runTime"] = "";
         }
+        if (!("ready" in $$source)) {
+            this["ready"] = false;
+        }
+        if (!("cpuPerCore" in $$source)) {
+            this["cpuPerCore"] = 0;
+        }
+        if (!("threads" in $$source)) {
+            this["threads"] = 0;
+        }
+        if (!("openFiles" in $$source)) {
+            this["openFiles"] = 0;
+        }
+        if (!("readBytes" in $$source)) {
+            this["readBytes"] = 0;
+        }
+        if (!("writeBytes" in $$source)) {
+            this["writeBytes"] = 0;
+        }
+        if (!("processes" in $$source)) {
+            this["processes"] = 0;
+        }
 
         Object.assign(this, $$source);
     }
@@ -103,11 +1019,318 @@ export class ProcessState {
      * Creates a new ProcessState instance from a string or object.
      */
     static createFrom($$source: any = {}): ProcessState {
+        const $$createField11_0 = $$createType5;
         let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("cgroup" in $$parsedSource) {
+            $$parsedSource["cgroup"] = $$createField11_0($$parsedSource["cgroup"]);
+        }
         return new ProcessState($$parsedSource as Partial<ProcessState>);
     }
 }
 
+/**
+ * ResourceLimits 进程的资源限制，仅在 Linux 上通过 cgroup v2 对普通进程生效，0 表示不限制
+ */
+export class ResourceLimits {
+    /**
+     * memory.max，单位 MB
+     */
+    "memoryMaxMb": number;
+
+    /**
+     * cpu.max，100 表示一个完整的 CPU 核心
+     */
+    "cpuPercent": number;
+
+    /**
+     * pids.max
+     */
+    "pidsMax": number;
+
+    /** Creates a new ResourceLimits instance. */
+    constructor($$source: Partial<ResourceLimits> = {}) {
+        if (!("memoryMaxMb" in $$source)) {
+            this["memoryMaxMb"] = 0;
+        }
+        if (!("cpuPercent" in $$source)) {
+            this["cpuPercent"] = 0;
+        }
+        if (!("pidsMax" in $$source)) {
+            this["pidsMax"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ResourceLimits instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ResourceLimits {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ResourceLimits($$parsedSource as Partial<ResourceLimits>);
+    }
+}
+
+/**
+ * RestartProgress 带倒计时广播的计划重启的进度
+ */
+export class RestartProgress {
+    "reason"?: string;
+
+    /**
+     * countdown / stopping / starting / completed / aborted / failed
+     */
+    "phase": string;
+
+    /**
+     * 发起重启的时间，Unix 毫秒
+     */
+    "plannedAt": number;
+
+    /**
+     * 倒计时结束、开始停止进程的时间，Unix 毫秒
+     */
+    "restartAt": number;
+
+    /**
+     * 距离 RestartAt 的剩余时间
+     */
+    "remainingMs": number;
+    "error"?: string;
+
+    /** Creates a new RestartProgress instance. */
+    constructor($$source: Partial<RestartProgress> = {}) {
+        if (!("phase" in $$source)) {
+            this["phase"] = "";
+        }
+        if (!("plannedAt" in $$source)) {
+            this["plannedAt"] = 0;
+        }
+        if (!("restartAt" in $$source)) {
+            this["restartAt"] = 0;
+        }
+        if (!("remainingMs" in $$source)) {
+            this["remainingMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RestartProgress instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RestartProgress {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RestartProgress($$parsedSource as Partial<RestartProgress>);
+    }
+}
+
+/**
+ * Rule 匹配进程输出并执行动作的触发规则
+ */
+export class Rule {
+    "id": number;
+    "name": string;
+    "enabled": boolean;
+
+    /**
+     * 正则表达式，匹配控制台中显示的原始输出行
+     */
+    "pattern": string;
+
+    /**
+     * 只匹配这些进程的输出，为空表示所有进程
+     */
+    "processIds": number[];
+
+    /**
+     * 同一进程上两次触发的最短间隔，0 表示不限制；含命令、停止或重启动作时至少为 1 秒
+     */
+    "cooldownMs": number;
+    "actions": RuleAction[];
+
+    /** Creates a new Rule instance. */
+    constructor($$source: Partial<Rule> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            this["enabled"] = false;
+        }
+        if (!("pattern" in $$source)) {
+            this["pattern"] = "";
+        }
+        if (!("processIds" in $$source)) {
+            this["processIds"] = [];
+        }
+        if (!("cooldownMs" in $$source)) {
+            this["cooldownMs"] = 0;
+        }
+        if (!("actions" in $$source)) {
+            this["actions"] = [];
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new Rule instance from a string or object.
+     */
+    static createFrom($$source: any = {}): Rule {
+        const $$createField4_0 = $$createType6;
+        const $$createField6_0 = $$createType8;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("processIds" in $$parsedSource) {
+            $$parsedSource["processIds"] = $$createField4_0($$parsedSource["processIds"]);
+        }
+        if ("actions" in $$parsedSource) {
+            $$parsedSource["actions"] = $$createField6_0($$parsedSource["actions"]);
+        }
+        return new Rule($$parsedSource as Partial<Rule>);
+    }
+}
+
+/**
+ * RuleAction 规则触发后依次执行的动作
+ */
+export class RuleAction {
+    /**
+     * command / event / webhook / stop / restart
+     */
+    "type": string;
+
+    /**
+     * command: 发送给触发规则的进程，$1 或 ${1} 替换为捕获组
+     */
+    "command"?: string;
+
+    /**
+     * event: 事件名，随 rule-matched 事件发出
+     */
+    "event"?: string;
+
+    /**
+     * webhook: 以 POST 发送匹配结果的地址
+     */
+    "url"?: string;
+
+    /** Creates a new RuleAction instance. */
+    constructor($$source: Partial<RuleAction> = {}) {
+        if (!("type" in $$source)) {
+            this["type"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RuleAction instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RuleAction {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RuleAction($$parsedSource as Partial<RuleAction>);
+    }
+}
+
+/**
+ * ScheduledJob 按 cron 表达式定时执行的任务
+ */
+export class ScheduledJob {
+    "id": number;
+    "name": string;
+    "enabled": boolean;
+
+    /**
+     * 分 时 日 月 星期，或 @daily 等简写
+     */
+    "cron": string;
+
+    /**
+     * IANA 时区名，如 Asia/Shanghai，为空表示本机时区
+     */
+    "timezone": string;
+
+    /**
+     * command / restart / start / stop / backup
+     */
+    "type": string;
+
+    /**
+     * 任务作用的进程
+     */
+    "processId": number;
+
+    /**
+     * command: 发送给进程的命令
+     */
+    "command"?: string;
+
+    /**
+     * restart: 重启前的倒计时秒数，期间按进程的倒计时广播设置通知玩家，为 0 时立即重启
+     */
+    "delaySec"?: number;
+
+    /**
+     * skip / once，Voxesis 未运行期间错过的执行如何处理
+     */
+    "missedPolicy": string;
+
+    /**
+     * 下一次计划执行的时间，Unix 毫秒，禁用或不会再触发时为空
+     */
+    "nextRunAt"?: number | null;
+
+    /**
+     * 最近一次执行，只读
+     */
+    "lastRun"?: JobRun | null;
+
+    /** Creates a new ScheduledJob instance. */
+    constructor($$source: Partial<ScheduledJob> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("enabled" in $$source)) {
+            this["enabled"] = false;
+        }
+        if (!("cron" in $$source)) {
+            this["cron"] = "";
+        }
+        if (!("timezone" in $$source)) {
+            this["timezone"] = "";
+        }
+        if (!("type" in $$source)) {
+            this["type"] = "";
+        }
+        if (!("processId" in $$source)) {
+            this["processId"] = 0;
+        }
+        if (!("missedPolicy" in $$source)) {
+            this["missedPolicy"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ScheduledJob instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ScheduledJob {
+        const $$createField11_0 = $$createType10;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("lastRun" in $$parsedSource) {
+            $$parsedSource["lastRun"] = $$createField11_0($$parsedSource["lastRun"]);
+        }
+        return new ScheduledJob($$parsedSource as Partial<ScheduledJob>);
+    }
+}
+
 export class SystemState {
     "CpuCores": number;
     "CpuUsage": number;
@@ -140,3 +1363,16 @@ export class SystemState {
         return new SystemState($$parsedSource as Partial<SystemState>);
     }
 }
+
+// Private type creation functions
+const $$createType0 = $Create.Array($Create.Any);
+const $$createType1 = LogRecord.createFrom;
+const $$createType2 = $Create.Nullable($$createType1);
+const $$createType3 = $Create.Map($Create.Any, $Create.Any);
+const $$createType4 = CgroupState.createFrom;
+const $$createType5 = $Create.Nullable($$createType4);
+const $$createType6 = $Create.Array($Create.Any);
+const $$createType7 = RuleAction.createFrom;
+const $$createType8 = $Create.Array($$createType7);
+const $$createType9 = JobRun.createFrom;
+const $$createType10 = $Create.Nullable($$createType9);
@@ -5,6 +5,109 @@
 // @ts-ignore: Unused imports
 import {Create as $Create} from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../../../../time/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as entity$0 from "../Entity/models.js";
+
+/**
+ * CommandRequest 需要收集响应的控制台命令
+ */
+export class CommandRequest {
+    "command": string;
+
+    /**
+     * 可选，匹配响应结束行的正则，匹配的行包含在响应中
+     */
+    "terminator": string;
+
+    /**
+     * 超过该时长没有新输出即认为响应结束
+     */
+    "quietMs": number;
+
+    /**
+     * 整个请求的超时，包括等待其他命令完成的时间
+     */
+    "timeoutMs": number;
+
+    /** Creates a new CommandRequest instance. */
+    constructor($$source: Partial<CommandRequest> = {}) {
+        if (!("command" in $$source)) {
+            this["command"] = "";
+        }
+        if (!("terminator" in $$source)) {
+            this["terminator"] = "";
+        }
+        if (!("quietMs" in $$source)) {
+            this["quietMs"] = 0;
+        }
+        if (!("timeoutMs" in $$source)) {
+            this["timeoutMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CommandRequest instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CommandRequest {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new CommandRequest($$parsedSource as Partial<CommandRequest>);
+    }
+}
+
+/**
+ * CommandResponse 命令之后收集到的输出
+ */
+export class CommandResponse {
+    "lines": string[];
+
+    /**
+     * 是否因匹配到 Terminator 而结束
+     */
+    "terminated": boolean;
+
+    /**
+     * 是否因超时而结束
+     */
+    "timedOut": boolean;
+    "durationMs": number;
+
+    /** Creates a new CommandResponse instance. */
+    constructor($$source: Partial<CommandResponse> = {}) {
+        if (!("lines" in $$source)) {
+            this["lines"] = [];
+        }
+        if (!("terminated" in $$source)) {
+            this["terminated"] = false;
+        }
+        if (!("timedOut" in $$source)) {
+            this["timedOut"] = false;
+        }
+        if (!("durationMs" in $$source)) {
+            this["durationMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new CommandResponse instance from a string or object.
+     */
+    static createFrom($$source: any = {}): CommandResponse {
+        const $$createField0_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("lines" in $$parsedSource) {
+            $$parsedSource["lines"] = $$createField0_0($$parsedSource["lines"]);
+        }
+        return new CommandResponse($$parsedSource as Partial<CommandResponse>);
+    }
+}
+
 /**
  * ConfigType 配置文件类型枚举
  */
@@ -20,6 +123,216 @@ export enum ConfigType {
     YAML = 3,
 };
 
+/**
+ * LaunchProfile 命名的启动配置，同一个可执行文件可以按不同配置启动，例如测试与生产环境
+ */
+export class LaunchProfile {
+    "name": string;
+
+    /**
+     * 为 nil 时使用进程定义中的参数
+     */
+    "args": string[];
+
+    /**
+     * 在进程定义的环境变量之上覆盖或追加
+     */
+    "env": { [_: string]: string };
+
+    /**
+     * 从继承的环境变量中移除
+     */
+    "unset": string[];
+
+    /**
+     * 为空时使用进程定义中的工作目录
+     */
+    "workingDir": string;
+
+    /**
+     * 发送命令时使用的编码，例如 gbk，为空时使用 UTF-8
+     */
+    "stdinEncoding": string;
+
+    /** Creates a new LaunchProfile instance. */
+    constructor($$source: Partial<LaunchProfile> = {}) {
+        if (!("name" in $$source)) {
+            this["name"] = "";
+        }
+        if (!("args" in $$source)) {
+            this["args"] = [];
+        }
+        if (!("env" in $$source)) {
+            this["env"] = {};
+        }
+        if (!("unset" in $$source)) {
+            this["unset"] = [];
+        }
+        if (!("workingDir" in $$source)) {
+            this["workingDir"] = "";
+        }
+        if (!("stdinEncoding" in $$source)) {
+            this["stdinEncoding"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new LaunchProfile instance from a string or object.
+     */
+    static createFrom($$source: any = {}): LaunchProfile {
+        const $$createField1_0 = $$createType0;
+        const $$createField2_0 = $$createType1;
+        const $$createField3_0 = $$createType0;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("args" in $$parsedSource) {
+            $$parsedSource["args"] = $$createField1_0($$parsedSource["args"]);
+        }
+        if ("env" in $$parsedSource) {
+            $$parsedSource["env"] = $$createField2_0($$parsedSource["env"]);
+        }
+        if ("unset" in $$parsedSource) {
+            $$parsedSource["unset"] = $$createField3_0($$parsedSource["unset"]);
+        }
+        return new LaunchProfile($$parsedSource as Partial<LaunchProfile>);
+    }
+}
+
+/**
+ * OutputReplay 某个序号之后的控制台输出
+ */
+export class OutputReplay {
+    "lines": entity$0.OutputLine[];
+
+    /**
+     * 当前最新一行的序号
+     */
+    "lastSeq": number;
+
+    /**
+     * 请求的序号之后有行已被丢弃
+     */
+    "truncated": boolean;
+
+    /** Creates a new OutputReplay instance. */
+    constructor($$source: Partial<OutputReplay> = {}) {
+        if (!("lines" in $$source)) {
+            this["lines"] = [];
+        }
+        if (!("lastSeq" in $$source)) {
+            this["lastSeq"] = 0;
+        }
+        if (!("truncated" in $$source)) {
+            this["truncated"] = false;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new OutputReplay instance from a string or object.
+     */
+    static createFrom($$source: any = {}): OutputReplay {
+        const $$createField0_0 = $$createType3;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("lines" in $$parsedSource) {
+            $$parsedSource["lines"] = $$createField0_0($$parsedSource["lines"]);
+        }
+        return new OutputReplay($$parsedSource as Partial<OutputReplay>);
+    }
+}
+
+/**
+ * ProbeType 定义就绪检测的方式
+ */
+export enum ProbeType {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * 不检测，启动即就绪
+     */
+    ProbeNone = "none",
+
+    /**
+     * 输出匹配正则后就绪，例如 "Server started\\."
+     */
+    ProbeRegex = "regex",
+
+    /**
+     * 基岩版服务器响应 UDP Ping 后就绪
+     */
+    ProbeBedrock = "bedrock",
+
+    /**
+     * TCP 端口可连接后就绪
+     */
+    ProbeTCP = "tcp",
+};
+
+/**
+ * ProcessOptions 创建进程时的可选配置，未设置的字段保持默认值
+ */
+export class ProcessOptions {
+    "restartPolicy"?: RestartPolicy | null;
+    "restartNotice"?: RestartNotice | null;
+    "stopSequence"?: StopSequence | null;
+    "scrollback"?: ScrollbackConfig | null;
+    "readiness"?: ReadinessProbe | null;
+    "profiles"?: LaunchProfile[];
+
+    /**
+     * 选择的启动配置
+     */
+    "profile"?: string | null;
+    "limits"?: entity$0.ResourceLimits | null;
+
+    /** Creates a new ProcessOptions instance. */
+    constructor($$source: Partial<ProcessOptions> = {}) {
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProcessOptions instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProcessOptions {
+        const $$createField0_0 = $$createType5;
+        const $$createField1_0 = $$createType7;
+        const $$createField2_0 = $$createType9;
+        const $$createField3_0 = $$createType11;
+        const $$createField4_0 = $$createType13;
+        const $$createField5_0 = $$createType15;
+        const $$createField7_0 = $$createType17;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("restartPolicy" in $$parsedSource) {
+            $$parsedSource["restartPolicy"] = $$createField0_0($$parsedSource["restartPolicy"]);
+        }
+        if ("restartNotice" in $$parsedSource) {
+            $$parsedSource["restartNotice"] = $$createField1_0($$parsedSource["restartNotice"]);
+        }
+        if ("stopSequence" in $$parsedSource) {
+            $$parsedSource["stopSequence"] = $$createField2_0($$parsedSource["stopSequence"]);
+        }
+        if ("scrollback" in $$parsedSource) {
+            $$parsedSource["scrollback"] = $$createField3_0($$parsedSource["scrollback"]);
+        }
+        if ("readiness" in $$parsedSource) {
+            $$parsedSource["readiness"] = $$createField4_0($$parsedSource["readiness"]);
+        }
+        if ("profiles" in $$parsedSource) {
+            $$parsedSource["profiles"] = $$createField5_0($$parsedSource["profiles"]);
+        }
+        if ("limits" in $$parsedSource) {
+            $$parsedSource["limits"] = $$createField7_0($$parsedSource["limits"]);
+        }
+        return new ProcessOptions($$parsedSource as Partial<ProcessOptions>);
+    }
+}
+
 /**
  * ProcessType 定义进程类型
  */
@@ -31,4 +344,569 @@ export enum ProcessType {
 
     ConPty = 0,
     Ordinary = 1,
+    UnixPty = 2,
+
+    /**
+     * 在独立的会话托管进程中以普通进程运行，Voxesis 重启后可重新连接
+     */
+    Detached = 3,
+
+    /**
+     * 在独立的会话托管进程中以伪终端运行，Voxesis 重启后可重新连接
+     */
+    DetachedPty = 4,
 };
+
+/**
+ * ProfileList 进程的启动配置列表与当前选择的启动配置
+ */
+export class ProfileList {
+    "profiles": LaunchProfile[];
+    "selected": string;
+
+    /** Creates a new ProfileList instance. */
+    constructor($$source: Partial<ProfileList> = {}) {
+        if (!("profiles" in $$source)) {
+            this["profiles"] = [];
+        }
+        if (!("selected" in $$source)) {
+            this["selected"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ProfileList instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ProfileList {
+        const $$createField0_0 = $$createType15;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("profiles" in $$parsedSource) {
+            $$parsedSource["profiles"] = $$createField0_0($$parsedSource["profiles"]);
+        }
+        return new ProfileList($$parsedSource as Partial<ProfileList>);
+    }
+}
+
+/**
+ * QueuedCommand 等待进程就绪后发送的命令
+ */
+export class QueuedCommand {
+    "id": number;
+    "command": string;
+    "queuedAt": time$0.Time;
+
+    /**
+     * 过期后不再发送
+     */
+    "expiresAt": time$0.Time;
+
+    /** Creates a new QueuedCommand instance. */
+    constructor($$source: Partial<QueuedCommand> = {}) {
+        if (!("id" in $$source)) {
+            this["id"] = 0;
+        }
+        if (!("command" in $$source)) {
+            this["command"] = "";
+        }
+        if (!("queuedAt" in $$source)) {
+            this["queuedAt"] = null;
+        }
+        if (!("expiresAt" in $$source)) {
+            this["expiresAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new QueuedCommand instance from a string or object.
+     */
+    static createFrom($$source: any = {}): QueuedCommand {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new QueuedCommand($$parsedSource as Partial<QueuedCommand>);
+    }
+}
+
+/**
+ * ReadinessProbe 进程的就绪检测配置
+ */
+export class ReadinessProbe {
+    "type": ProbeType;
+
+    /**
+     * regex 检测使用的正则
+     */
+    "pattern": string;
+
+    /**
+     * bedrock / tcp 检测的地址，默认 127.0.0.1
+     */
+    "host": string;
+
+    /**
+     * bedrock / tcp 检测的端口
+     */
+    "port": number;
+
+    /**
+     * bedrock / tcp 检测的轮询间隔
+     */
+    "intervalMs": number;
+
+    /**
+     * 启动后超过该时长仍未就绪则判定检测失败
+     */
+    "timeoutMs": number;
+
+    /** Creates a new ReadinessProbe instance. */
+    constructor($$source: Partial<ReadinessProbe> = {}) {
+        if (!("type" in $$source)) {
+            this["type"] = ("" as ProbeType);
+        }
+        if (!("pattern" in $$source)) {
+            this["pattern"] = "";
+        }
+        if (!("host" in $$source)) {
+            this["host"] = "";
+        }
+        if (!("port" in $$source)) {
+            this["port"] = 0;
+        }
+        if (!("intervalMs" in $$source)) {
+            this["intervalMs"] = 0;
+        }
+        if (!("timeoutMs" in $$source)) {
+            this["timeoutMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ReadinessProbe instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ReadinessProbe {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ReadinessProbe($$parsedSource as Partial<ReadinessProbe>);
+    }
+}
+
+/**
+ * RestartMode 定义进程退出后的重启策略
+ */
+export enum RestartMode {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * 从不自动重启
+     */
+    RestartNever = "never",
+
+    /**
+     * 仅在异常退出时重启
+     */
+    RestartOnFailure = "on-failure",
+
+    /**
+     * 只要不是手动停止就重启
+     */
+    RestartAlways = "always",
+};
+
+/**
+ * RestartNotice 计划重启时向玩家发送的广播
+ */
+export class RestartNotice {
+    /**
+     * 为 nil 时在剩余 10 分钟、5 分钟、1 分钟与 10 秒时广播
+     */
+    "warnings": RestartWarning[];
+
+    /**
+     * 取消重启时发送的命令，为空不发送
+     */
+    "abortCommand": string;
+
+    /** Creates a new RestartNotice instance. */
+    constructor($$source: Partial<RestartNotice> = {}) {
+        if (!("warnings" in $$source)) {
+            this["warnings"] = [];
+        }
+        if (!("abortCommand" in $$source)) {
+            this["abortCommand"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RestartNotice instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RestartNotice {
+        const $$createField0_0 = $$createType19;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("warnings" in $$parsedSource) {
+            $$parsedSource["warnings"] = $$createField0_0($$parsedSource["warnings"]);
+        }
+        return new RestartNotice($$parsedSource as Partial<RestartNotice>);
+    }
+}
+
+/**
+ * RestartPolicy 进程的自动重启策略
+ */
+export class RestartPolicy {
+    "mode": RestartMode;
+
+    /**
+     * 首次重启前的等待时间，之后每次翻倍
+     */
+    "backoffMs": number;
+
+    /**
+     * 退避时间上限
+     */
+    "maxBackoffMs": number;
+
+    /**
+     * 时间窗口内允许的最大重启次数
+     */
+    "maxRestarts": number;
+
+    /**
+     * 统计重启次数的时间窗口
+     */
+    "windowSec": number;
+
+    /** Creates a new RestartPolicy instance. */
+    constructor($$source: Partial<RestartPolicy> = {}) {
+        if (!("mode" in $$source)) {
+            this["mode"] = ("" as RestartMode);
+        }
+        if (!("backoffMs" in $$source)) {
+            this["backoffMs"] = 0;
+        }
+        if (!("maxBackoffMs" in $$source)) {
+            this["maxBackoffMs"] = 0;
+        }
+        if (!("maxRestarts" in $$source)) {
+            this["maxRestarts"] = 0;
+        }
+        if (!("windowSec" in $$source)) {
+            this["windowSec"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RestartPolicy instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RestartPolicy {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RestartPolicy($$parsedSource as Partial<RestartPolicy>);
+    }
+}
+
+/**
+ * RestartWarning 计划重启前的一次倒计时广播
+ */
+export class RestartWarning {
+    /**
+     * 距离重启的秒数
+     */
+    "beforeSec": number;
+
+    /**
+     * 发送给进程的命令，{remaining} 替换为剩余时间 (如 10 minutes)，为空使用 say Restarting in {remaining}
+     */
+    "command": string;
+
+    /** Creates a new RestartWarning instance. */
+    constructor($$source: Partial<RestartWarning> = {}) {
+        if (!("beforeSec" in $$source)) {
+            this["beforeSec"] = 0;
+        }
+        if (!("command" in $$source)) {
+            this["command"] = "";
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new RestartWarning instance from a string or object.
+     */
+    static createFrom($$source: any = {}): RestartWarning {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new RestartWarning($$parsedSource as Partial<RestartWarning>);
+    }
+}
+
+/**
+ * ScrollbackConfig 控制台回滚缓冲区的容量，行数与字节数任一超出即丢弃最旧的行
+ */
+export class ScrollbackConfig {
+    "maxLines": number;
+    "maxBytes": number;
+
+    /** Creates a new ScrollbackConfig instance. */
+    constructor($$source: Partial<ScrollbackConfig> = {}) {
+        if (!("maxLines" in $$source)) {
+            this["maxLines"] = 0;
+        }
+        if (!("maxBytes" in $$source)) {
+            this["maxBytes"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new ScrollbackConfig instance from a string or object.
+     */
+    static createFrom($$source: any = {}): ScrollbackConfig {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new ScrollbackConfig($$parsedSource as Partial<ScrollbackConfig>);
+    }
+}
+
+/**
+ * StopResult 一次停止操作的结果
+ */
+export class StopResult {
+    "stage": StopStage;
+
+    /**
+     * 是否匹配到了 SavedPattern
+     */
+    "saved": boolean;
+
+    /**
+     * 整个停止流程耗时
+     */
+    "durationMs": number;
+
+    /** Creates a new StopResult instance. */
+    constructor($$source: Partial<StopResult> = {}) {
+        if (!("stage" in $$source)) {
+            this["stage"] = ("" as StopStage);
+        }
+        if (!("saved" in $$source)) {
+            this["saved"] = false;
+        }
+        if (!("durationMs" in $$source)) {
+            this["durationMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new StopResult instance from a string or object.
+     */
+    static createFrom($$source: any = {}): StopResult {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new StopResult($$parsedSource as Partial<StopResult>);
+    }
+}
+
+/**
+ * StopSequence 进程的优雅停止流程
+ * 依次执行: 发送控制台命令 -> 等待退出 (最长 GraceTimeoutMs) -> 终止信号 -> 等待 TermTimeoutMs -> 强制杀死
+ */
+export class StopSequence {
+    /**
+     * 控制台停止命令，例如 "stop"，为空则直接发送信号
+     */
+    "command": string;
+
+    /**
+     * 可选，匹配 "存档完成/已停止" 输出的正则
+     */
+    "savedPattern": string;
+
+    /**
+     * 发送命令后等待进程自行退出的时长
+     */
+    "graceTimeoutMs": number;
+
+    /**
+     * 发送终止信号后等待进程退出的时长
+     */
+    "termTimeoutMs": number;
+
+    /** Creates a new StopSequence instance. */
+    constructor($$source: Partial<StopSequence> = {}) {
+        if (!("command" in $$source)) {
+            this["command"] = "";
+        }
+        if (!("savedPattern" in $$source)) {
+            this["savedPattern"] = "";
+        }
+        if (!("graceTimeoutMs" in $$source)) {
+            this["graceTimeoutMs"] = 0;
+        }
+        if (!("termTimeoutMs" in $$source)) {
+            this["termTimeoutMs"] = 0;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new StopSequence instance from a string or object.
+     */
+    static createFrom($$source: any = {}): StopSequence {
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        return new StopSequence($$parsedSource as Partial<StopSequence>);
+    }
+}
+
+/**
+ * StopStage 表示进程最终在停止流程的哪个阶段退出
+ */
+export enum StopStage {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * 进程本就未在运行
+     */
+    StopStageNone = "none",
+
+    /**
+     * 响应控制台停止命令后自行退出
+     */
+    StopStageCommand = "command",
+
+    /**
+     * 响应终止信号 (SIGTERM / Ctrl+C) 后退出
+     */
+    StopStageSignal = "signal",
+
+    /**
+     * 超时后被强制杀死
+     */
+    StopStageKill = "kill",
+};
+
+/**
+ * SupervisorState 描述守护器当前所处的阶段
+ */
+export enum SupervisorState {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * 未启动或已手动停止
+     */
+    SupervisorIdle = "idle",
+
+    /**
+     * 进程正在运行
+     */
+    SupervisorRunning = "running",
+
+    /**
+     * 进程已退出，等待退避后重启
+     */
+    SupervisorBackoff = "backoff",
+
+    /**
+     * 进程已退出，策略不要求重启
+     */
+    SupervisorExited = "exited",
+
+    /**
+     * 时间窗口内重启次数过多，已放弃
+     */
+    SupervisorCrashLoop = "crash-loop",
+};
+
+/**
+ * SupervisorStatus 守护器的运行状况
+ */
+export class SupervisorStatus {
+    "state": SupervisorState;
+    "policy": RestartPolicy;
+
+    /**
+     * 当前时间窗口内的重启次数
+     */
+    "restarts": number;
+
+    /**
+     * 最近一次退出的错误，正常退出为空
+     */
+    "lastExitError": string;
+
+    /**
+     * 处于退避阶段时的下次重启时间
+     */
+    "nextRestartAt": time$0.Time | null;
+
+    /** Creates a new SupervisorStatus instance. */
+    constructor($$source: Partial<SupervisorStatus> = {}) {
+        if (!("state" in $$source)) {
+            this["state"] = ("" as SupervisorState);
+        }
+        if (!("policy" in $$source)) {
+            this["policy"] = (new RestartPolicy());
+        }
+        if (!("restarts" in $$source)) {
+            this["restarts"] = 0;
+        }
+        if (!("lastExitError" in $$source)) {
+            this["lastExitError"] = "";
+        }
+        if (!("nextRestartAt" in $$source)) {
+            this["nextRestartAt"] = null;
+        }
+
+        Object.assign(this, $$source);
+    }
+
+    /**
+     * Creates a new SupervisorStatus instance from a string or object.
+     */
+    static createFrom($$source: any = {}): SupervisorStatus {
+        const $$createField1_0 = $$createType4;
+        let $$parsedSource = typeof $$source === 'string' ? JSON.parse($$source) : $$source;
+        if ("policy" in $$parsedSource) {
+            $$parsedSource["policy"] = $$createField1_0($$parsedSource["policy"]);
+        }
+        return new SupervisorStatus($$parsedSource as Partial<SupervisorStatus>);
+    }
+}
+
+// Private type creation functions
+const $$createType0 = $Create.Array($Create.Any);
+const $$createType1 = $Create.Map($Create.Any, $Create.Any);
+const $$createType2 = entity$0.OutputLine.createFrom;
+const $$createType3 = $Create.Array($$createType2);
+const $$createType4 = RestartPolicy.createFrom;
+const $$createType5 = $Create.Nullable($$createType4);
+const $$createType6 = RestartNotice.createFrom;
+const $$createType7 = $Create.Nullable($$createType6);
+const $$createType8 = StopSequence.createFrom;
+const $$createType9 = $Create.Nullable($$createType8);
+const $$createType10 = ScrollbackConfig.createFrom;
+const $$createType11 = $Create.Nullable($$createType10);
+const $$createType12 = ReadinessProbe.createFrom;
+const $$createType13 = $Create.Nullable($$createType12);
+const $$createType14 = LaunchProfile.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = entity$0.ResourceLimits.createFrom;
+const $$createType17 = $Create.Nullable($$createType16);
+const $$createType18 = RestartWarning.createFrom;
+const $$createType19 = $Create.Array($$createType18);
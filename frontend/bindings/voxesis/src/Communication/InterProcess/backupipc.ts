@@ -0,0 +1,81 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import {Call as $Call, Create as $Create} from "@wailsio/runtime";
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as entity$0 from "../../Common/Entity/models.js";
+
+/**
+ * CreateBackup 立即备份指定ID的进程，备份完成后返回。
+ * 返回 (备份, 错误信息字符串)
+ */
+export function CreateBackup(processId: number): Promise<[entity$0.Backup | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(231758756, processId) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType1($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * DeleteBackup 删除指定ID的备份及其归档文件。
+ */
+export function DeleteBackup(id: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(274053283, id) as any;
+    return $resultPromise;
+}
+
+/**
+ * DownloadBackup 将指定ID的备份复制到 destPath，destPath 为目录时使用备份的文件名。
+ */
+export function DownloadBackup(id: number, destPath: string): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1551038248, id, destPath) as any;
+    return $resultPromise;
+}
+
+/**
+ * GetBackupPolicy 获取指定ID进程的备份设置。
+ */
+export function GetBackupPolicy(processId: number): Promise<[entity$0.BackupPolicy | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2983299934, processId) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType3($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListBackups 按创建时间倒序列出指定ID进程的备份。
+ */
+export function ListBackups(processId: number): Promise<[entity$0.Backup[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1160246693, processId) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType4($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * SetBackupPolicy 设置指定ID进程的备份路径、格式与保留规则。
+ */
+export function SetBackupPolicy(processId: number, policy: entity$0.BackupPolicy): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1094519490, processId, policy) as any;
+    return $resultPromise;
+}
+
+// Private type creation functions
+const $$createType0 = entity$0.Backup.createFrom;
+const $$createType1 = $Create.Nullable($$createType0);
+const $$createType2 = entity$0.BackupPolicy.createFrom;
+const $$createType3 = $Create.Nullable($$createType2);
+const $$createType4 = $Create.Array($$createType0);
@@ -1,17 +1,23 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+import * as BackupIpc from "./backupipc.js";
 import * as ConfigIpc from "./configipc.js";
 import * as LoggerIpc from "./loggeripc.js";
 import * as PluginIpc from "./pluginipc.js";
 import * as ProcessIpc from "./processipc.js";
+import * as RulesIpc from "./rulesipc.js";
+import * as SchedulerIpc from "./scheduleripc.js";
 import * as SystemDialogIpc from "./systemdialogipc.js";
 import * as UtilsIpc from "./utilsipc.js";
 export {
+    BackupIpc,
     ConfigIpc,
     LoggerIpc,
     PluginIpc,
     ProcessIpc,
+    RulesIpc,
+    SchedulerIpc,
     SystemDialogIpc,
     UtilsIpc
 };
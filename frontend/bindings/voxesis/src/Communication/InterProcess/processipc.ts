@@ -5,6 +5,9 @@
 // @ts-ignore: Unused imports
 import {Call as $Call, Create as $Create} from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../../../../time/models.js";
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import * as entity$0 from "../../Common/Entity/models.js";
@@ -12,6 +15,168 @@ import * as entity$0 from "../../Common/Entity/models.js";
 // @ts-ignore: Unused imports
 import * as v_manager$0 from "../../Common/Manager/models.js";
 
+/**
+ * AbortRestart 取消指定ID进程正在倒计时的计划重启。
+ */
+export function AbortRestart(id: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(4118243532, id) as any;
+    return $resultPromise;
+}
+
+/**
+ * Attach 接管指定ID进程对应的、仍在运行但不是由当前 Voxesis 实例启动的服务器。
+ * 优先使用启动时写入的 PID 文件，未找到时按可执行文件路径与工作目录查找。
+ * 返回 (被接管进程的 PID, 错误信息字符串)
+ */
+export function Attach(id: number): Promise<[number | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2609355800, id) as any;
+    return $resultPromise;
+}
+
+/**
+ * AttachRunning 根据 PID 文件接管上次运行时启动、至今仍在运行的所有服务器，应在界面与 Web 服务初始化后调用。
+ */
+export function AttachRunning(): Promise<void> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1659941415) as any;
+    return $resultPromise;
+}
+
+/**
+ * CancelQueuedCommand 取消指定ID进程中的一条排队命令，commandId 为 0 时清空整个队列。
+ */
+export function CancelQueuedCommand(id: number, commandId: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1568270991, id, commandId) as any;
+    return $resultPromise;
+}
+
+/**
+ * DeleteProcess 删除指定ID的进程定义，进程必须处于停止状态。
+ */
+export function DeleteProcess(id: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2159751971, id) as any;
+    return $resultPromise;
+}
+
+/**
+ * ExecuteCommand 向指定ID的进程发送命令并收集其响应输出。
+ * 返回 (响应, 错误信息字符串)，同一进程上的请求依次执行，输出不会混杂。
+ */
+export function ExecuteCommand(id: number, request: v_manager$0.CommandRequest): Promise<[v_manager$0.CommandResponse | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2588158565, id, request) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType1($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetLifecycle 获取指定ID进程的生命周期状态，进程未运行时同样可用。
+ * 返回 (生命周期指针, 错误信息字符串)
+ */
+export function GetLifecycle(id: number): Promise<[entity$0.ProcessLifecycle | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3903907613, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType3($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetMetrics 获取指定ID进程在 [from, to) 内按 step 聚合的资源使用历史。
+ * from、to 为 Unix 毫秒，step 为 0 时按查询范围自动选择。
+ */
+export function GetMetrics(id: number, $from: number, to: number, step: time$0.Duration): Promise<[entity$0.MetricSample[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2939429644, id, $from, to, step) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType5($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetOnlinePlayers 获取指定ID进程中当前在线的玩家，按加入时间排序。
+ */
+export function GetOnlinePlayers(id: number): Promise<[entity$0.OnlinePlayer[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3054615702, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType7($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetOutputSince 获取指定ID进程中序号大于 since 的控制台输出，用于重连后补齐历史。
+ */
+export function GetOutputSince(id: number, since: number): Promise<[v_manager$0.OutputReplay | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(287114208, id, since) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType9($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetPlannedRestart 获取指定ID进程正在进行的计划重启，没有时返回 nil。
+ */
+export function GetPlannedRestart(id: number): Promise<[entity$0.RestartProgress | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2837587446, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType11($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetPlayerSessions 获取玩家在指定ID进程中的会话历史，按加入时间倒序排列，limit 为 0 时不限制。
+ */
+export function GetPlayerSessions(id: number, name: string, limit: number): Promise<[entity$0.PlayerSession[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2526117623, id, name, limit) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType13($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetPlaytime 获取指定ID进程中每个玩家的累计游戏时长，按时长降序排列。
+ */
+export function GetPlaytime(id: number): Promise<[entity$0.PlayerPlaytime[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1192516952, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType15($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetProcess 获取指定ID的进程定义。
+ */
+export function GetProcess(id: number): Promise<[entity$0.ProcessDefinition | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2970607840, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType17($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
 /**
  * GetProcessStatus 获取指定ID进程的状态。
  * 返回 (状态指针, 错误信息字符串)
@@ -19,18 +184,131 @@ import * as v_manager$0 from "../../Common/Manager/models.js";
 export function GetProcessStatus(id: number): Promise<[entity$0.ProcessState | null, string | null]> & { cancel(): void } {
     let $resultPromise = $Call.ByID(1036456930, id) as any;
     let $typingPromise = $resultPromise.then(($result) => {
-        $result[0] = $$createType1($result[0]);
+        $result[0] = $$createType19($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * GetRestartStatus 获取指定ID进程的守护器状态，包含重启策略与崩溃循环信息。
+ */
+export function GetRestartStatus(id: number): Promise<[v_manager$0.SupervisorStatus | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2708752364, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType21($result[0]);
         return $result;
     }) as any;
     $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
     return $typingPromise;
 }
 
+/**
+ * ListProcesses 按ID顺序列出所有进程定义。
+ */
+export function ListProcesses(): Promise<entity$0.ProcessDefinition[]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3797370890) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        return $$createType22($result);
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListProfiles 获取指定ID进程的启动配置列表与当前选择的启动配置。
+ */
+export function ListProfiles(id: number): Promise<[v_manager$0.ProfileList | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(711175415, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType24($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListQueuedCommands 列出指定ID进程中等待发送的命令。
+ */
+export function ListQueuedCommands(id: number): Promise<[v_manager$0.QueuedCommand[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(4265867802, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType26($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * LoadProcesses 从持久化存储中恢复所有进程定义，ID与上次运行时保持一致。
+ */
+export function LoadProcesses(): Promise<void> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3201907418) as any;
+    return $resultPromise;
+}
+
 export function NewProcess(processType: v_manager$0.ProcessType, abs: boolean, relPath: string, ...args: string[]): Promise<number> & { cancel(): void } {
     let $resultPromise = $Call.ByID(2444685578, processType, abs, relPath, args) as any;
     return $resultPromise;
 }
 
+/**
+ * NewProcessWithOptions 创建进程并应用重启策略、停止流程等可选配置，进程定义会被持久化。
+ * 若相同路径与类型的进程已存在，则更新其配置并返回已有的ID。
+ */
+export function NewProcessWithOptions(processType: v_manager$0.ProcessType, abs: boolean, relPath: string, args: string[], options: v_manager$0.ProcessOptions): Promise<[number | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(341605496, processType, abs, relPath, args, options) as any;
+    return $resultPromise;
+}
+
+/**
+ * PlanRestart 在 delaySec 秒后重启指定ID的进程，期间按倒计时广播设置通知玩家。
+ * 返回 (计划重启的进度, 错误信息字符串)，之后的进度通过 restart-* 事件通知
+ */
+export function PlanRestart(id: number, delaySec: number, reason: string): Promise<[entity$0.RestartProgress | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2865663017, id, delaySec, reason) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType11($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * QueueCommand 向指定ID的进程发送命令，进程尚未就绪时排队等待，就绪后按顺序发送。
+ * ttlMs 为命令在队列中的有效期 (毫秒)，不大于 0 时使用默认值。
+ * 返回 (排队的命令, 错误信息字符串)，命令被立即发送时排队的命令为 nil。
+ */
+export function QueueCommand(id: number, command: string, ttlMs: number): Promise<[v_manager$0.QueuedCommand | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3474887757, id, command, ttlMs) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType27($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * Resize 调整指定ID进程的终端尺寸。
+ */
+export function Resize(id: number, cols: number, rows: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3961132453, id, cols, rows) as any;
+    return $resultPromise;
+}
+
+/**
+ * Restart 按停止流程停止指定ID的进程后重新启动，进程未运行时直接启动。
+ */
+export function Restart(id: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(312470912, id) as any;
+    return $resultPromise;
+}
+
 /**
  * SendCommand 向指定ID的进程发送命令。
  */
@@ -39,6 +317,62 @@ export function SendCommand(id: number, command: string): Promise<string | null>
     return $resultPromise;
 }
 
+/**
+ * SetProfiles 设置指定ID进程的启动配置列表，下次启动时生效。
+ */
+export function SetProfiles(id: number, profiles: v_manager$0.LaunchProfile[]): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2560358009, id, profiles) as any;
+    return $resultPromise;
+}
+
+/**
+ * SetReadinessProbe 设置指定ID进程的就绪检测，下次启动时生效。
+ */
+export function SetReadinessProbe(id: number, probe: v_manager$0.ReadinessProbe): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2232289873, id, probe) as any;
+    return $resultPromise;
+}
+
+/**
+ * SetResourceLimits 设置指定ID进程的资源限制，下次启动时生效，仅 Linux 上的普通进程支持。
+ */
+export function SetResourceLimits(id: number, limits: entity$0.ResourceLimits): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(634627471, id, limits) as any;
+    return $resultPromise;
+}
+
+/**
+ * SetRestartNotice 设置指定ID进程计划重启时的倒计时广播。
+ */
+export function SetRestartNotice(id: number, notice: v_manager$0.RestartNotice): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2041478502, id, notice) as any;
+    return $resultPromise;
+}
+
+/**
+ * SetRestartPolicy 设置指定ID进程的自动重启策略。
+ */
+export function SetRestartPolicy(id: number, policy: v_manager$0.RestartPolicy): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2545214040, id, policy) as any;
+    return $resultPromise;
+}
+
+/**
+ * SetScrollbackConfig 设置指定ID进程的控制台回滚缓冲区容量。
+ */
+export function SetScrollbackConfig(id: number, config: v_manager$0.ScrollbackConfig): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3204853583, id, config) as any;
+    return $resultPromise;
+}
+
+/**
+ * SetStopSequence 设置指定ID进程的优雅停止流程。
+ */
+export function SetStopSequence(id: number, sequence: v_manager$0.StopSequence): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(417897022, id, sequence) as any;
+    return $resultPromise;
+}
+
 /**
  * Start 启动指定ID的进程。
  * 返回一个 string 类型的错误信息，如果成功则为空字符串。
@@ -49,13 +383,72 @@ export function Start(id: number): Promise<string | null> & { cancel(): void } {
 }
 
 /**
- * Stop 停止指定ID的进程。
+ * StartMetrics 开始定期采样所有运行中进程的资源使用情况。
+ */
+export function StartMetrics(): Promise<void> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3162465164) as any;
+    return $resultPromise;
+}
+
+/**
+ * StartWithProfile 选择启动配置后启动指定ID的进程，所选配置在之后的启动与自动重启中继续使用。
+ * profile 为空表示不使用启动配置。
  */
-export function Stop(id: number): Promise<string | null> & { cancel(): void } {
+export function StartWithProfile(id: number, profile: string): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2953352508, id, profile) as any;
+    return $resultPromise;
+}
+
+/**
+ * Stop 按停止流程停止指定ID的进程。
+ * 返回 (停止结果, 错误信息字符串)，停止结果说明进程在哪个阶段退出。
+ */
+export function Stop(id: number): Promise<[v_manager$0.StopResult | null, string | null]> & { cancel(): void } {
     let $resultPromise = $Call.ByID(2009285497, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType29($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * UpdateProcess 更新指定ID的进程定义，进程必须处于停止状态。
+ */
+export function UpdateProcess(id: number, def: entity$0.ProcessDefinition): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2051971141, id, def) as any;
     return $resultPromise;
 }
 
 // Private type creation functions
-const $$createType0 = entity$0.ProcessState.createFrom;
+const $$createType0 = v_manager$0.CommandResponse.createFrom;
 const $$createType1 = $Create.Nullable($$createType0);
+const $$createType2 = entity$0.ProcessLifecycle.createFrom;
+const $$createType3 = $Create.Nullable($$createType2);
+const $$createType4 = entity$0.MetricSample.createFrom;
+const $$createType5 = $Create.Array($$createType4);
+const $$createType6 = entity$0.OnlinePlayer.createFrom;
+const $$createType7 = $Create.Array($$createType6);
+const $$createType8 = v_manager$0.OutputReplay.createFrom;
+const $$createType9 = $Create.Nullable($$createType8);
+const $$createType10 = entity$0.RestartProgress.createFrom;
+const $$createType11 = $Create.Nullable($$createType10);
+const $$createType12 = entity$0.PlayerSession.createFrom;
+const $$createType13 = $Create.Array($$createType12);
+const $$createType14 = entity$0.PlayerPlaytime.createFrom;
+const $$createType15 = $Create.Array($$createType14);
+const $$createType16 = entity$0.ProcessDefinition.createFrom;
+const $$createType17 = $Create.Nullable($$createType16);
+const $$createType18 = entity$0.ProcessState.createFrom;
+const $$createType19 = $Create.Nullable($$createType18);
+const $$createType20 = v_manager$0.SupervisorStatus.createFrom;
+const $$createType21 = $Create.Nullable($$createType20);
+const $$createType22 = $Create.Array($$createType16);
+const $$createType23 = v_manager$0.ProfileList.createFrom;
+const $$createType24 = $Create.Nullable($$createType23);
+const $$createType25 = v_manager$0.QueuedCommand.createFrom;
+const $$createType26 = $Create.Array($$createType25);
+const $$createType27 = $Create.Nullable($$createType25);
+const $$createType28 = v_manager$0.StopResult.createFrom;
+const $$createType29 = $Create.Nullable($$createType28);
@@ -0,0 +1,70 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import {Call as $Call, Create as $Create} from "@wailsio/runtime";
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as entity$0 from "../../Common/Entity/models.js";
+
+/**
+ * CreateRule 新增触发规则，规则立即生效。
+ * 返回 (分配了ID的规则, 错误信息字符串)
+ */
+export function CreateRule(rule: entity$0.Rule): Promise<[entity$0.Rule | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2400403917, rule) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType1($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * DeleteRule 删除指定ID的触发规则。
+ */
+export function DeleteRule(id: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3466516286, id) as any;
+    return $resultPromise;
+}
+
+/**
+ * GetRule 获取指定ID的触发规则。
+ */
+export function GetRule(id: number): Promise<[entity$0.Rule | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1247826219, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType1($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListRules 按ID顺序列出所有触发规则。
+ */
+export function ListRules(): Promise<entity$0.Rule[]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(161956626) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        return $$createType2($result);
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * UpdateRule 更新指定ID的触发规则，规则立即生效。
+ */
+export function UpdateRule(id: number, rule: entity$0.Rule): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1133024836, id, rule) as any;
+    return $resultPromise;
+}
+
+// Private type creation functions
+const $$createType0 = entity$0.Rule.createFrom;
+const $$createType1 = $Create.Nullable($$createType0);
+const $$createType2 = $Create.Array($$createType0);
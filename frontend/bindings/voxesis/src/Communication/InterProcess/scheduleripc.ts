@@ -0,0 +1,100 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import {Call as $Call, Create as $Create} from "@wailsio/runtime";
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as entity$0 from "../../Common/Entity/models.js";
+
+/**
+ * CreateJob 新增定时任务，任务立即生效。
+ * 返回 (分配了ID的任务, 错误信息字符串)
+ */
+export function CreateJob(job: entity$0.ScheduledJob): Promise<[entity$0.ScheduledJob | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(4078071856, job) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType1($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * DeleteJob 删除指定ID的定时任务及其执行记录。
+ */
+export function DeleteJob(id: number): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(3345168833, id) as any;
+    return $resultPromise;
+}
+
+/**
+ * GetJob 获取指定ID的定时任务。
+ */
+export function GetJob(id: number): Promise<[entity$0.ScheduledJob | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(4109939618, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType1($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListJobs 按ID顺序列出所有定时任务。
+ */
+export function ListJobs(): Promise<entity$0.ScheduledJob[]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1331677901) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        return $$createType2($result);
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * ListRuns 按计划时间倒序列出定时任务的执行记录，limit 为 0 时不限制。
+ */
+export function ListRuns(id: number, limit: number): Promise<[entity$0.JobRun[], string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(2706527947, id, limit) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType4($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * RunJob 立即执行一次定时任务，不影响下一次计划执行的时间。
+ * 返回 (执行记录, 错误信息字符串)，任务在后台执行，结果通过 ListRuns 查询
+ */
+export function RunJob(id: number): Promise<[entity$0.JobRun | null, string | null]> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(1907143353, id) as any;
+    let $typingPromise = $resultPromise.then(($result) => {
+        $result[0] = $$createType5($result[0]);
+        return $result;
+    }) as any;
+    $typingPromise.cancel = $resultPromise.cancel.bind($resultPromise);
+    return $typingPromise;
+}
+
+/**
+ * UpdateJob 更新指定ID的定时任务，下一次执行的时间从现在起重新计算。
+ */
+export function UpdateJob(id: number, job: entity$0.ScheduledJob): Promise<string | null> & { cancel(): void } {
+    let $resultPromise = $Call.ByID(740999019, id, job) as any;
+    return $resultPromise;
+}
+
+// Private type creation functions
+const $$createType0 = entity$0.ScheduledJob.createFrom;
+const $$createType1 = $Create.Nullable($$createType0);
+const $$createType2 = $Create.Array($$createType0);
+const $$createType3 = entity$0.JobRun.createFrom;
+const $$createType4 = $Create.Array($$createType3);
+const $$createType5 = $Create.Nullable($$createType3);
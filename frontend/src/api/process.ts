@@ -1,6 +1,6 @@
 import * as ProcessIpc from "../../bindings/voxesis/src/Communication/InterProcess/processipc"
 import {envIsWails} from "./common";
-import {ProcessType} from "../../bindings/voxesis/src/Common/Manager";
+import {ProcessType, StopResult} from "../../bindings/voxesis/src/Common/Manager";
 import { Events } from "@wailsio/runtime";
 import {ProcessState} from "../../bindings/voxesis/src/Common/Entity";
 
@@ -75,7 +75,7 @@ export async function Start(uuid: number): Promise<string | null> {
     }
 }
 
-export async function Stop(uuid: number): Promise<string | null> {
+export async function Stop(uuid: number): Promise<[StopResult | null, string | null]> {
     if (envIsWails) {
         return ProcessIpc.Stop(uuid)
     } else {
@@ -87,21 +87,34 @@ export async function Stop(uuid: number): Promise<string | null> {
             body: JSON.stringify({uuid})
         })
 
-        return res.json()
+        // 成功时返回停止结果，失败时返回错误信息
+        const data = await res.json()
+        return res.ok ? [data, null] : [null, data]
     }
 }
 
 export async function GetProcessOutput(uuid: number, callback: (data: string) => void): Promise<void> {
     if (envIsWails) {
         Events.On("process-" + uuid + "-output", (data) => {
-            callback(data.data);
+            callback(data.data.data);
         });
     } else {
         const ws = new WebSocket("ws://localhost:8080/api/process/GetProcessOutput")
         ws.onmessage = (event) => {
             const data = JSON.parse(event.data)
-            if (data.uuid == uuid) {
-                callback(data.data)
+            if (data.uuid != uuid) {
+                return
+            }
+            // 同一连接上还会收到进程事件与请求响应，只有输出与补发的输出写入控制台
+            switch (data.type) {
+                case "output":
+                    callback(data.data)
+                    break
+                case "replay":
+                    for (const line of data.lines) {
+                        callback(line.data)
+                    }
+                    break
             }
         }
     }
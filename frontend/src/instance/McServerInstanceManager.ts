@@ -221,7 +221,7 @@ export class McServerManager {
 
     async stop(): Promise<void> {
         try {
-            const error = await Stop(this.processid);
+            const [, error] = await Stop(this.processid);
 
             if (error) handleError("stop", error);
         } catch (error) {
@@ -4,7 +4,10 @@ import (
 	"embed"
 	"fmt"
 	"log"
+	"os"
 	"voxesis/src"
+	communication "voxesis/src/Communication"
+	vprocess "voxesis/src/System/Process"
 	vtray "voxesis/src/System/Tray"
 	vweb "voxesis/src/Web"
 	vwindow "voxesis/src/Window"
@@ -24,6 +27,11 @@ var (
 
 func main() {
 
+	// 作为会话托管进程运行时不初始化界面
+	if len(os.Args) > 1 && os.Args[1] == vprocess.SessionHostFlag {
+		os.Exit(vprocess.RunSessionHost())
+	}
+
 	app = src.InitAPP(frontendAssets)
 
 	vwindow.LoadMainWindow(app)
@@ -32,6 +40,10 @@ func main() {
 
 	vweb.Init(frontendAssets)
 
+	// 事件需要推送到界面与 Web 端，接管上次运行时留下的服务器与启动调度器必须在两者初始化之后
+	communication.ProcessIpc.AttachRunning()
+	communication.SchedulerIpc.Scheduler.Start()
+
 	go func() {
 		err := vweb.Run()
 		if err != nil {
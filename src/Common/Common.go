@@ -1,18 +1,50 @@
 package v_common
 
 import (
+	entity "voxesis/src/Common/Entity"
+
 	"github.com/gin-gonic/gin"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type processController interface {
 	NewProcess(c *gin.Context)
+	ListProcesses(c *gin.Context)
+	GetProcess(c *gin.Context)
+	UpdateProcess(c *gin.Context)
+	DeleteProcess(c *gin.Context)
 	Start(c *gin.Context)
+	Attach(c *gin.Context)
+	StartWithProfile(c *gin.Context)
+	ListProfiles(c *gin.Context)
+	SetProfiles(c *gin.Context)
 	Stop(c *gin.Context)
+	SetStopSequence(c *gin.Context)
 	SendCommand(c *gin.Context)
+	QueueCommand(c *gin.Context)
+	ExecuteCommand(c *gin.Context)
+	ListQueuedCommands(c *gin.Context)
+	CancelQueuedCommand(c *gin.Context)
+	Resize(c *gin.Context)
 	GetProcessStatus(c *gin.Context)
+	GetMetrics(c *gin.Context)
+	GetOnlinePlayers(c *gin.Context)
+	GetPlaytime(c *gin.Context)
+	GetPlayerSessions(c *gin.Context)
+	GetLifecycle(c *gin.Context)
+	SetRestartPolicy(c *gin.Context)
+	GetRestartStatus(c *gin.Context)
+	PlanRestart(c *gin.Context)
+	AbortRestart(c *gin.Context)
+	GetPlannedRestart(c *gin.Context)
+	SetRestartNotice(c *gin.Context)
+	SetReadinessProbe(c *gin.Context)
+	SetResourceLimits(c *gin.Context)
+	SetScrollbackConfig(c *gin.Context)
+	GetOutputSince(c *gin.Context)
 	GetProcessOutput(c *gin.Context)
-	WriteProcessOutput(uuid int, output string)
+	WriteProcessOutput(uuid int, line entity.OutputLine)
+	WriteProcessEvent(uuid int, event entity.ProcessEvent)
 }
 
 var (
@@ -1,5 +1,133 @@
 package v_data
 
 import (
-	_ "modernc.org/sqlite"
+	"database/sql"
+	"time"
+	entity "voxesis/src/Common/Entity"
 )
+
+type DataBase interface {
+	// DB 获取底层数据库连接
+	DB() *sql.DB
+
+	// Close 关闭数据库
+	Close() error
+
+	// Path 获取数据库文件路径
+	Path() string
+}
+
+type ProcessStore interface {
+	// ListProcesses 按ID顺序列出所有进程定义
+	ListProcesses() ([]entity.ProcessDefinition, error)
+
+	// GetProcess 获取指定ID的进程定义
+	GetProcess(id int) (*entity.ProcessDefinition, error)
+
+	// CreateProcess 新增进程定义，并回填分配到的ID
+	CreateProcess(def *entity.ProcessDefinition) error
+
+	// UpdateProcess 更新已有的进程定义
+	UpdateProcess(def *entity.ProcessDefinition) error
+
+	// DeleteProcess 删除指定ID的进程定义
+	DeleteProcess(id int) error
+}
+
+type MetricsStore interface {
+	// AddSamples 写入一批原始采样，键为进程ID
+	AddSamples(samples map[int]entity.MetricSample) error
+
+	// Downsample 将已结束的时间段聚合到更粗的粒度，并删除超过保留期限的数据
+	Downsample(now time.Time) error
+
+	// QueryMetrics 查询进程在 [from, to) 内按 step 聚合的指标，step 小于可用数据的粒度时使用该粒度
+	QueryMetrics(processId int, from, to time.Time, step time.Duration) ([]entity.MetricSample, error)
+
+	// DeleteMetrics 删除进程的所有指标
+	DeleteMetrics(processId int) error
+}
+
+type PlayerStore interface {
+	// OpenSession 记录玩家加入，返回会话ID
+	OpenSession(processId int, name, xuid string, joinedAt int64) (int64, error)
+
+	// CloseSession 记录玩家离开
+	CloseSession(id int64, leftAt int64) error
+
+	// ListOpenSessions 列出进程中尚未结束的会话
+	ListOpenSessions(processId int) ([]entity.PlayerSession, error)
+
+	// QueryPlaytime 按累计时长降序统计进程中每个玩家的游戏时长
+	QueryPlaytime(processId int) ([]entity.PlayerPlaytime, error)
+
+	// QuerySessions 按加入时间倒序查询玩家在进程中的会话，limit 为 0 时不限制
+	QuerySessions(processId int, name string, limit int) ([]entity.PlayerSession, error)
+
+	// DeleteSessions 删除进程的所有会话
+	DeleteSessions(processId int) error
+}
+
+type RuleStore interface {
+	// ListRules 按ID顺序列出所有规则
+	ListRules() ([]entity.Rule, error)
+
+	// CreateRule 新增规则，并回填分配到的ID
+	CreateRule(rule *entity.Rule) error
+
+	// UpdateRule 更新已有的规则
+	UpdateRule(rule *entity.Rule) error
+
+	// DeleteRule 删除指定ID的规则
+	DeleteRule(id int) error
+}
+
+type ScheduleStore interface {
+	// ListJobs 按ID顺序列出所有定时任务，并附带每个任务最近一次的执行记录
+	ListJobs() ([]entity.ScheduledJob, error)
+
+	// CreateJob 新增定时任务，并回填分配到的ID
+	CreateJob(job *entity.ScheduledJob) error
+
+	// UpdateJob 更新已有的定时任务，包括下一次执行的时间
+	UpdateJob(job *entity.ScheduledJob) error
+
+	// DeleteJob 删除指定ID的定时任务及其执行记录
+	DeleteJob(id int) error
+
+	// AddRun 写入一条执行记录并更新任务下一次执行的时间，回填记录ID
+	// 同一任务同一计划时间的记录已存在时不写入并返回 false，用于保证每个计划时间只执行一次
+	AddRun(run *entity.JobRun, nextRunAt *int64) (bool, error)
+
+	// FinishRun 记录执行的结果
+	FinishRun(id int64, status string, message string, finishedAt int64) error
+
+	// FailRunningRuns 将所有仍在执行中的记录标记为失败，用于启动时处理上次退出时中断的执行
+	FailRunningRuns(message string, finishedAt int64) error
+
+	// ListRuns 按计划时间倒序列出任务的执行记录，limit 为 0 时不限制
+	ListRuns(jobId int, limit int) ([]entity.JobRun, error)
+
+	// PruneRuns 只保留任务最近的 keep 条执行记录
+	PruneRuns(jobId int, keep int) error
+}
+
+type BackupStore interface {
+	// ListBackups 按创建时间倒序列出进程的备份
+	ListBackups(processId int) ([]entity.Backup, error)
+
+	// GetBackup 获取指定ID的备份
+	GetBackup(id int) (*entity.Backup, error)
+
+	// AddBackup 记录新的备份，并回填分配到的ID
+	AddBackup(backup *entity.Backup) error
+
+	// DeleteBackup 删除指定ID的备份记录
+	DeleteBackup(id int) error
+
+	// GetPolicy 获取进程的备份设置，未设置时返回 nil
+	GetPolicy(processId int) (*entity.BackupPolicy, error)
+
+	// SetPolicy 保存进程的备份设置
+	SetPolicy(processId int, policy entity.BackupPolicy) error
+}
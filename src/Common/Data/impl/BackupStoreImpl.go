@@ -0,0 +1,142 @@
+package v_data_impl
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+)
+
+// BackupStoreImpl 基于 SQLite 的备份目录与备份设置存储
+type BackupStoreImpl struct {
+	*BaseDataBaseImpl
+}
+
+var _ vdata.BackupStore = (*BackupStoreImpl)(nil)
+
+// NewBackupStoreImpl 创建备份存储，并确保数据表存在
+func NewBackupStoreImpl(base *BaseDataBaseImpl) (*BackupStoreImpl, error) {
+	err := base.migrate(`
+		CREATE TABLE IF NOT EXISTS backups (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			process_id  INTEGER NOT NULL,
+			file_name   TEXT    NOT NULL,
+			path        TEXT    NOT NULL,
+			format      TEXT    NOT NULL,
+			size_bytes  INTEGER NOT NULL,
+			files       INTEGER NOT NULL,
+			sha256      TEXT    NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			trigger     TEXT    NOT NULL,
+			created_at  INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_backups_process ON backups (process_id, created_at)`,
+		`CREATE TABLE IF NOT EXISTS backup_policies (
+			process_id INTEGER PRIMARY KEY,
+			policy     TEXT    NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackupStoreImpl{BaseDataBaseImpl: base}, nil
+}
+
+const backupColumns = `id, process_id, file_name, path, format, size_bytes, files, sha256, duration_ms, trigger, created_at`
+
+// ListBackups 按创建时间倒序列出进程的备份
+func (s *BackupStoreImpl) ListBackups(processId int) ([]entity.Backup, error) {
+	rows, err := s.db.Query(`SELECT `+backupColumns+` FROM backups WHERE process_id = ? ORDER BY created_at DESC, id DESC`, processId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	backups := []entity.Backup{}
+	for rows.Next() {
+		backup, err := scanBackup(rows)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, *backup)
+	}
+	return backups, rows.Err()
+}
+
+// GetBackup 获取指定ID的备份
+func (s *BackupStoreImpl) GetBackup(id int) (*entity.Backup, error) {
+	backup, err := scanBackup(s.db.QueryRow(`SELECT `+backupColumns+` FROM backups WHERE id = ?`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("ID为 %d 的备份不存在", id)
+	}
+	return backup, err
+}
+
+// AddBackup 记录新的备份，并回填分配到的ID
+func (s *BackupStoreImpl) AddBackup(backup *entity.Backup) error {
+	result, err := s.db.Exec(`INSERT INTO backups (process_id, file_name, path, format, size_bytes, files, sha256, duration_ms, trigger, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		backup.ProcessId, backup.FileName, backup.Path, backup.Format, backup.SizeBytes, backup.Files, backup.Sha256,
+		backup.DurationMs, backup.Trigger, backup.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	backup.Id = int(id)
+	return nil
+}
+
+// DeleteBackup 删除指定ID的备份记录
+func (s *BackupStoreImpl) DeleteBackup(id int) error {
+	_, err := s.db.Exec(`DELETE FROM backups WHERE id = ?`, id)
+	return err
+}
+
+// GetPolicy 获取进程的备份设置，未设置时返回 nil
+func (s *BackupStoreImpl) GetPolicy(processId int) (*entity.BackupPolicy, error) {
+	var text string
+	err := s.db.QueryRow(`SELECT policy FROM backup_policies WHERE process_id = ?`, processId).Scan(&text)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var policy entity.BackupPolicy
+	if err := json.Unmarshal([]byte(text), &policy); err != nil {
+		return nil, fmt.Errorf("解析ID为 %d 的进程的备份设置失败: %w", processId, err)
+	}
+	return &policy, nil
+}
+
+// SetPolicy 保存进程的备份设置
+func (s *BackupStoreImpl) SetPolicy(processId int, policy entity.BackupPolicy) error {
+	text, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO backup_policies (process_id, policy) VALUES (?, ?)
+		ON CONFLICT (process_id) DO UPDATE SET policy = excluded.policy, updated_at = CURRENT_TIMESTAMP`,
+		processId, string(text))
+	return err
+}
+
+// scanBackup 读取一行备份记录
+func scanBackup(row interface{ Scan(dest ...any) error }) (*entity.Backup, error) {
+	var backup entity.Backup
+	err := row.Scan(&backup.Id, &backup.ProcessId, &backup.FileName, &backup.Path, &backup.Format, &backup.SizeBytes,
+		&backup.Files, &backup.Sha256, &backup.DurationMs, &backup.Trigger, &backup.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &backup, nil
+}
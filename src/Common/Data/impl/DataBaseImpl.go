@@ -3,87 +3,76 @@ package v_data_impl
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"os"
+	"path/filepath"
+	vdata "voxesis/src/Common/Data"
 
 	_ "modernc.org/sqlite"
 )
 
-func a() {
-	// 1. 打开数据库连接
-	// 数据源名称（DSN）就是数据库文件的路径。
-	// 如果文件不存在，sqlite 会自动创建它。
-	// 可以使用 ":memory:" 作为 DSN 来创建一个内存数据库（重启后数据丢失）。
-	db, err := sql.Open("sqlite", "./test.db")
-	if err != nil {
-		log.Fatal(err)
-	}
-	// 使用 defer 确保在函数退出前关闭数据库连接，释放资源
-	defer db.Close()
+var (
+	DB *BaseDataBaseImpl
+)
 
-	// 2. 测试连接是否成功
-	err = db.Ping()
-	if err != nil {
-		log.Fatal(err)
-	}
-	fmt.Println("Successfully connected to the database!")
-
-	// 3. 执行 CREATE TABLE 语句
-	createTableSQL := `
-        CREATE TABLE IF NOT EXISTS users (
-            id INTEGER PRIMARY KEY AUTOINCREMENT,
-            username TEXT NOT NULL UNIQUE,
-            email TEXT NOT NULL,
-            created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-        );
-    `
-	_, err = db.Exec(createTableSQL)
-	if err != nil {
-		log.Fatal(err)
-	}
-	fmt.Println("Table created or already exists!")
+// BaseDataBaseImpl SQLite 数据库基础类，负责连接管理，各类存储在其上建表
+type BaseDataBaseImpl struct {
+	db   *sql.DB
+	path string
+}
 
-	// 4. 插入数据 (使用参数化查询，防止SQL注入)
-	insertSQL := "INSERT INTO users (username, email) VALUES (?, ?)"
-	result, err := db.Exec(insertSQL, "alice", "alice@example.com")
-	if err != nil {
-		log.Fatal(err)
+var _ vdata.DataBase = (*BaseDataBaseImpl)(nil)
+
+// InitDataBase 初始化全局数据库
+func InitDataBase(dataDir string, dataFileName string) error {
+	var err error
+
+	DB, err = NewBaseDataBaseImpl(filepath.Join(dataDir, dataFileName))
+
+	return err
+}
+
+// NewBaseDataBaseImpl 打开 (不存在时创建) 指定路径的 SQLite 数据库
+func NewBaseDataBaseImpl(filePath string) (*BaseDataBaseImpl, error) {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("创建数据目录失败: %w", err)
 	}
-	id, _ := result.LastInsertId()
-	fmt.Printf("Inserted a row with ID: %d\n", id)
 
-	// 5. 查询数据
-	querySQL := "SELECT id, username, email, created_at FROM users"
-	rows, err := db.Query(querySQL)
+	// WAL 模式允许读写并发，busy_timeout 避免并发写入时立即返回 SQLITE_BUSY
+	dsn := fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", filepath.ToSlash(filePath))
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("打开数据库失败: %w", err)
 	}
-	defer rows.Close() // 确保查询结果集被关闭
-
-	fmt.Println("\nQuery results:")
-	for rows.Next() {
-		var (
-			id        int
-			username  string
-			email     string
-			createdAt string
-		)
-		err = rows.Scan(&id, &username, &email, &createdAt)
-		if err != nil {
-			log.Fatal(err)
-		}
-		fmt.Printf("ID: %d, Username: %s, Email: %s, Created: %s\n", id, username, email, createdAt)
-	}
-	// 检查在迭代过程中是否发生错误
-	err = rows.Err()
-	if err != nil {
-		log.Fatal(err)
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("连接数据库失败: %w", err)
 	}
 
-	// 6. 查询单行数据
-	var count int
-	err = db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
-	if err != nil {
-		log.Fatal(err)
+	return &BaseDataBaseImpl{db: db, path: filePath}, nil
+}
+
+// DB 获取底层数据库连接
+func (d *BaseDataBaseImpl) DB() *sql.DB {
+	return d.db
+}
+
+// Close 关闭数据库
+func (d *BaseDataBaseImpl) Close() error {
+	return d.db.Close()
+}
+
+// Path 获取数据库文件路径
+func (d *BaseDataBaseImpl) Path() string {
+	return d.path
+}
+
+// migrate 依次执行建表语句
+func (d *BaseDataBaseImpl) migrate(statements ...string) error {
+	for _, statement := range statements {
+		if _, err := d.db.Exec(statement); err != nil {
+			return fmt.Errorf("初始化数据表失败: %w", err)
+		}
 	}
-	fmt.Printf("\nTotal users: %d\n", count)
+	return nil
 }
@@ -0,0 +1,183 @@
+package v_data_impl
+
+import (
+	"fmt"
+	"time"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+)
+
+// metricsTier 一种粒度的指标数据及其保留期限
+type metricsTier struct {
+	resolution time.Duration
+	retention  time.Duration
+}
+
+// metricsTiers 由细到粗的指标粒度，原始采样逐级聚合到下一级
+var metricsTiers = []metricsTier{
+	{resolution: 10 * time.Second, retention: 24 * time.Hour},
+	{resolution: time.Minute, retention: 7 * 24 * time.Hour},
+	{resolution: time.Hour, retention: 365 * 24 * time.Hour},
+}
+
+// maxMetricPoints 单次查询最多返回的数据点数，超出时自动增大 step
+const maxMetricPoints = 5000
+
+// metricsAggregate 将同一时间段内的多行按采样数加权聚合，%[1]d 为时间段长度 (毫秒)
+const metricsAggregate = `
+	(time / %[1]d) * %[1]d AS bucket,
+	SUM(cpu * samples) / SUM(samples),
+	MAX(cpu_max),
+	SUM(memory * samples) / SUM(samples),
+	MAX(memory_max),
+	MAX(threads),
+	SUM(read_rate * samples) / SUM(samples),
+	SUM(write_rate * samples) / SUM(samples),
+	SUM(samples)`
+
+// MetricsStoreImpl 基于 SQLite 的进程指标存储
+type MetricsStoreImpl struct {
+	*BaseDataBaseImpl
+}
+
+var _ vdata.MetricsStore = (*MetricsStoreImpl)(nil)
+
+// NewMetricsStoreImpl 创建进程指标存储，并确保数据表存在
+func NewMetricsStoreImpl(base *BaseDataBaseImpl) (*MetricsStoreImpl, error) {
+	err := base.migrate(`
+		CREATE TABLE IF NOT EXISTS process_metrics (
+			process_id INTEGER NOT NULL,
+			resolution INTEGER NOT NULL,
+			time       INTEGER NOT NULL,
+			cpu        REAL    NOT NULL,
+			cpu_max    REAL    NOT NULL,
+			memory     REAL    NOT NULL,
+			memory_max REAL    NOT NULL,
+			threads    INTEGER NOT NULL,
+			read_rate  REAL    NOT NULL,
+			write_rate REAL    NOT NULL,
+			samples    INTEGER NOT NULL,
+			PRIMARY KEY (process_id, resolution, time)
+		) WITHOUT ROWID`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricsStoreImpl{BaseDataBaseImpl: base}, nil
+}
+
+// AddSamples 写入一批原始采样，键为进程ID
+func (s *MetricsStoreImpl) AddSamples(samples map[int]entity.MetricSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	raw := metricsTiers[0].resolution.Milliseconds()
+	for processId, sample := range samples {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO process_metrics
+			(process_id, resolution, time, cpu, cpu_max, memory, memory_max, threads, read_rate, write_rate, samples)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`,
+			processId, raw, sample.Time/raw*raw, sample.Cpu, sample.Cpu, sample.MemoryMB, sample.MemoryMB,
+			sample.Threads, sample.ReadRate, sample.WriteRate)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Downsample 将已结束的时间段聚合到更粗的粒度，并删除超过保留期限的数据
+// 每次重新计算最近几个已结束的时间段，可重复执行
+func (s *MetricsStoreImpl) Downsample(now time.Time) error {
+	nowMs := now.UnixMilli()
+	for i := 1; i < len(metricsTiers); i++ {
+		src, dst := metricsTiers[i-1].resolution.Milliseconds(), metricsTiers[i].resolution.Milliseconds()
+		end := nowMs / dst * dst
+		start := end - 3*dst
+
+		_, err := s.db.Exec(fmt.Sprintf(`INSERT INTO process_metrics
+			(process_id, resolution, time, cpu, cpu_max, memory, memory_max, threads, read_rate, write_rate, samples)
+			SELECT process_id, %d, `+metricsAggregate+`
+			FROM process_metrics WHERE resolution = ? AND time >= ? AND time < ?
+			GROUP BY process_id, bucket
+			ON CONFLICT (process_id, resolution, time) DO UPDATE SET
+				cpu = excluded.cpu, cpu_max = excluded.cpu_max,
+				memory = excluded.memory, memory_max = excluded.memory_max,
+				threads = excluded.threads, read_rate = excluded.read_rate,
+				write_rate = excluded.write_rate, samples = excluded.samples`, dst),
+			src, start, end)
+		if err != nil {
+			return fmt.Errorf("聚合 %v 粒度的指标失败: %w", metricsTiers[i].resolution, err)
+		}
+	}
+
+	for _, tier := range metricsTiers {
+		_, err := s.db.Exec(`DELETE FROM process_metrics WHERE resolution = ? AND time < ?`,
+			tier.resolution.Milliseconds(), now.Add(-tier.retention).UnixMilli())
+		if err != nil {
+			return fmt.Errorf("清理 %v 粒度的过期指标失败: %w", tier.resolution, err)
+		}
+	}
+	return nil
+}
+
+// QueryMetrics 查询进程在 [from, to) 内按 step 聚合的指标，step 小于可用数据的粒度时使用该粒度
+func (s *MetricsStoreImpl) QueryMetrics(processId int, from, to time.Time, step time.Duration) ([]entity.MetricSample, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("查询的结束时间必须晚于开始时间")
+	}
+	if span := to.Sub(from); step < span/maxMetricPoints {
+		step = span / maxMetricPoints
+	}
+
+	tier := selectMetricsTier(from)
+	if step < tier.resolution {
+		step = tier.resolution
+	}
+	// 向上取整到数据粒度的整数倍，保证每个时间段包含完整的数据
+	step = (step + tier.resolution - 1) / tier.resolution * tier.resolution
+
+	rows, err := s.db.Query(fmt.Sprintf(`SELECT `+metricsAggregate+`
+		FROM process_metrics WHERE process_id = ? AND resolution = ? AND time >= ? AND time < ?
+		GROUP BY bucket ORDER BY bucket`, step.Milliseconds()),
+		processId, tier.resolution.Milliseconds(), from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := []entity.MetricSample{}
+	for rows.Next() {
+		var sample entity.MetricSample
+		err := rows.Scan(&sample.Time, &sample.Cpu, &sample.CpuMax, &sample.MemoryMB, &sample.MemoryMaxMB,
+			&sample.Threads, &sample.ReadRate, &sample.WriteRate, &sample.Samples)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
+
+// DeleteMetrics 删除进程的所有指标
+func (s *MetricsStoreImpl) DeleteMetrics(processId int) error {
+	_, err := s.db.Exec(`DELETE FROM process_metrics WHERE process_id = ?`, processId)
+	return err
+}
+
+// selectMetricsTier 选择保留期限覆盖 from 的最细一级粒度，都不覆盖时使用最粗一级
+// 较粗的粒度只包含已结束的时间段，优先使用较细的粒度以包含最近的数据
+func selectMetricsTier(from time.Time) metricsTier {
+	for _, tier := range metricsTiers {
+		if time.Since(from) <= tier.retention {
+			return tier
+		}
+	}
+	return metricsTiers[len(metricsTiers)-1]
+}
@@ -0,0 +1,123 @@
+package v_data_impl
+
+import (
+	"database/sql"
+	"time"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+)
+
+// PlayerStoreImpl 基于 SQLite 的玩家会话存储
+type PlayerStoreImpl struct {
+	*BaseDataBaseImpl
+}
+
+var _ vdata.PlayerStore = (*PlayerStoreImpl)(nil)
+
+// NewPlayerStoreImpl 创建玩家会话存储，并确保数据表存在
+func NewPlayerStoreImpl(base *BaseDataBaseImpl) (*PlayerStoreImpl, error) {
+	err := base.migrate(`
+		CREATE TABLE IF NOT EXISTS player_sessions (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			process_id INTEGER NOT NULL,
+			name       TEXT    NOT NULL,
+			xuid       TEXT    NOT NULL DEFAULT '',
+			joined_at  INTEGER NOT NULL,
+			left_at    INTEGER
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_player_sessions_player ON player_sessions (process_id, name, joined_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_player_sessions_open ON player_sessions (process_id) WHERE left_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlayerStoreImpl{BaseDataBaseImpl: base}, nil
+}
+
+// OpenSession 记录玩家加入，返回会话ID
+func (s *PlayerStoreImpl) OpenSession(processId int, name, xuid string, joinedAt int64) (int64, error) {
+	result, err := s.db.Exec(`INSERT INTO player_sessions (process_id, name, xuid, joined_at) VALUES (?, ?, ?, ?)`,
+		processId, name, xuid, joinedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// CloseSession 记录玩家离开，离开时间早于加入时间时视为同时
+func (s *PlayerStoreImpl) CloseSession(id int64, leftAt int64) error {
+	_, err := s.db.Exec(`UPDATE player_sessions SET left_at = MAX(joined_at, ?) WHERE id = ? AND left_at IS NULL`, leftAt, id)
+	return err
+}
+
+// ListOpenSessions 列出进程中尚未结束的会话
+func (s *PlayerStoreImpl) ListOpenSessions(processId int) ([]entity.PlayerSession, error) {
+	return s.querySessions(`SELECT id, process_id, name, xuid, joined_at, left_at FROM player_sessions
+		WHERE process_id = ? AND left_at IS NULL ORDER BY joined_at`, processId)
+}
+
+// QueryPlaytime 按累计时长降序统计进程中每个玩家的游戏时长，仍在进行的会话计算到当前时间
+func (s *PlayerStoreImpl) QueryPlaytime(processId int) ([]entity.PlayerPlaytime, error) {
+	now := time.Now().UnixMilli()
+	rows, err := s.db.Query(`SELECT name, MAX(xuid), COUNT(*),
+			SUM(COALESCE(left_at, ?) - joined_at), MAX(COALESCE(left_at, ?))
+		FROM player_sessions WHERE process_id = ?
+		GROUP BY name ORDER BY 4 DESC, name`, now, now, processId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	playtimes := []entity.PlayerPlaytime{}
+	for rows.Next() {
+		var playtime entity.PlayerPlaytime
+		if err := rows.Scan(&playtime.Name, &playtime.Xuid, &playtime.Sessions, &playtime.TotalMs, &playtime.LastSeen); err != nil {
+			return nil, err
+		}
+		playtimes = append(playtimes, playtime)
+	}
+	return playtimes, rows.Err()
+}
+
+// QuerySessions 按加入时间倒序查询玩家在进程中的会话，limit 为 0 时不限制
+func (s *PlayerStoreImpl) QuerySessions(processId int, name string, limit int) ([]entity.PlayerSession, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite 中负数表示不限制
+	}
+	return s.querySessions(`SELECT id, process_id, name, xuid, joined_at, left_at FROM player_sessions
+		WHERE process_id = ? AND name = ? ORDER BY joined_at DESC LIMIT ?`, processId, name, limit)
+}
+
+// DeleteSessions 删除进程的所有会话
+func (s *PlayerStoreImpl) DeleteSessions(processId int) error {
+	_, err := s.db.Exec(`DELETE FROM player_sessions WHERE process_id = ?`, processId)
+	return err
+}
+
+// querySessions 执行查询并读取会话列表
+func (s *PlayerStoreImpl) querySessions(query string, args ...interface{}) ([]entity.PlayerSession, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now().UnixMilli()
+	sessions := []entity.PlayerSession{}
+	for rows.Next() {
+		var (
+			session entity.PlayerSession
+			leftAt  sql.NullInt64
+		)
+		if err := rows.Scan(&session.Id, &session.ProcessId, &session.Name, &session.Xuid, &session.JoinedAt, &leftAt); err != nil {
+			return nil, err
+		}
+		session.DurationMs = now - session.JoinedAt
+		if leftAt.Valid {
+			session.LeftAt = &leftAt.Int64
+			session.DurationMs = leftAt.Int64 - session.JoinedAt
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
@@ -0,0 +1,151 @@
+package v_data_impl
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+)
+
+// ProcessStoreImpl 基于 SQLite 的进程定义存储
+type ProcessStoreImpl struct {
+	*BaseDataBaseImpl
+}
+
+var _ vdata.ProcessStore = (*ProcessStoreImpl)(nil)
+
+// NewProcessStoreImpl 创建进程定义存储，并确保数据表存在
+func NewProcessStoreImpl(base *BaseDataBaseImpl) (*ProcessStoreImpl, error) {
+	err := base.migrate(`
+		CREATE TABLE IF NOT EXISTS processes (
+			id           INTEGER PRIMARY KEY AUTOINCREMENT,
+			process_type INTEGER NOT NULL,
+			path         TEXT    NOT NULL,
+			args         TEXT    NOT NULL DEFAULT '[]',
+			working_dir  TEXT    NOT NULL DEFAULT '',
+			env          TEXT    NOT NULL DEFAULT '{}',
+			options      TEXT    NOT NULL DEFAULT '{}',
+			created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at   DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessStoreImpl{BaseDataBaseImpl: base}, nil
+}
+
+// ListProcesses 按ID顺序列出所有进程定义
+func (s *ProcessStoreImpl) ListProcesses() ([]entity.ProcessDefinition, error) {
+	rows, err := s.db.Query(`SELECT id, process_type, path, args, working_dir, env, options FROM processes ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var defs []entity.ProcessDefinition
+	for rows.Next() {
+		def, err := scanProcess(rows)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, *def)
+	}
+
+	return defs, rows.Err()
+}
+
+// GetProcess 获取指定ID的进程定义
+func (s *ProcessStoreImpl) GetProcess(id int) (*entity.ProcessDefinition, error) {
+	row := s.db.QueryRow(`SELECT id, process_type, path, args, working_dir, env, options FROM processes WHERE id = ?`, id)
+
+	def, err := scanProcess(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("ID为 %d 的进程定义不存在", id)
+	}
+	return def, err
+}
+
+// CreateProcess 新增进程定义，并回填分配到的ID
+func (s *ProcessStoreImpl) CreateProcess(def *entity.ProcessDefinition) error {
+	args, env, options, err := encodeProcess(def)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`INSERT INTO processes (process_type, path, args, working_dir, env, options) VALUES (?, ?, ?, ?, ?, ?)`,
+		def.ProcessType, def.Path, args, def.WorkingDir, env, options)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	def.Id = int(id)
+	return nil
+}
+
+// UpdateProcess 更新已有的进程定义
+func (s *ProcessStoreImpl) UpdateProcess(def *entity.ProcessDefinition) error {
+	args, env, options, err := encodeProcess(def)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`UPDATE processes SET process_type = ?, path = ?, args = ?, working_dir = ?, env = ?, options = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		def.ProcessType, def.Path, args, def.WorkingDir, env, options, def.Id)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("ID为 %d 的进程定义不存在", def.Id)
+	}
+	return nil
+}
+
+// DeleteProcess 删除指定ID的进程定义
+func (s *ProcessStoreImpl) DeleteProcess(id int) error {
+	_, err := s.db.Exec(`DELETE FROM processes WHERE id = ?`, id)
+	return err
+}
+
+// encodeProcess 将进程定义中的复合字段序列化为 JSON 文本
+func encodeProcess(def *entity.ProcessDefinition) (string, string, string, error) {
+	args, err := json.Marshal(def.Args)
+	if err != nil {
+		return "", "", "", err
+	}
+	env, err := json.Marshal(def.Env)
+	if err != nil {
+		return "", "", "", err
+	}
+	options := string(def.Options)
+	if options == "" {
+		options = "{}"
+	}
+	return string(args), string(env), options, nil
+}
+
+// scanProcess 从查询结果中解析一条进程定义
+func scanProcess(row interface{ Scan(dest ...any) error }) (*entity.ProcessDefinition, error) {
+	var (
+		def                entity.ProcessDefinition
+		args, env, options string
+	)
+	if err := row.Scan(&def.Id, &def.ProcessType, &def.Path, &args, &def.WorkingDir, &env, &options); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args), &def.Args); err != nil {
+		return nil, fmt.Errorf("解析ID为 %d 的进程参数失败: %w", def.Id, err)
+	}
+	if err := json.Unmarshal([]byte(env), &def.Env); err != nil {
+		return nil, fmt.Errorf("解析ID为 %d 的进程环境变量失败: %w", def.Id, err)
+	}
+	def.Options = json.RawMessage(options)
+	return &def, nil
+}
@@ -0,0 +1,127 @@
+package v_data_impl
+
+import (
+	"encoding/json"
+	"fmt"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+)
+
+// RuleStoreImpl 基于 SQLite 的触发规则存储
+type RuleStoreImpl struct {
+	*BaseDataBaseImpl
+}
+
+var _ vdata.RuleStore = (*RuleStoreImpl)(nil)
+
+// NewRuleStoreImpl 创建触发规则存储，并确保数据表存在
+func NewRuleStoreImpl(base *BaseDataBaseImpl) (*RuleStoreImpl, error) {
+	err := base.migrate(`
+		CREATE TABLE IF NOT EXISTS rules (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			name        TEXT    NOT NULL DEFAULT '',
+			enabled     INTEGER NOT NULL DEFAULT 1,
+			pattern     TEXT    NOT NULL,
+			process_ids TEXT    NOT NULL DEFAULT '[]',
+			cooldown_ms INTEGER NOT NULL DEFAULT 0,
+			actions     TEXT    NOT NULL DEFAULT '[]',
+			created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at  DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuleStoreImpl{BaseDataBaseImpl: base}, nil
+}
+
+// ListRules 按ID顺序列出所有规则
+func (s *RuleStoreImpl) ListRules() ([]entity.Rule, error) {
+	rows, err := s.db.Query(`SELECT id, name, enabled, pattern, process_ids, cooldown_ms, actions FROM rules ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []entity.Rule{}
+	for rows.Next() {
+		var (
+			rule                entity.Rule
+			processIds, actions string
+		)
+		if err := rows.Scan(&rule.Id, &rule.Name, &rule.Enabled, &rule.Pattern, &processIds, &rule.CooldownMs, &actions); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(processIds), &rule.ProcessIds); err != nil {
+			return nil, fmt.Errorf("解析ID为 %d 的规则的进程列表失败: %w", rule.Id, err)
+		}
+		if err := json.Unmarshal([]byte(actions), &rule.Actions); err != nil {
+			return nil, fmt.Errorf("解析ID为 %d 的规则的动作失败: %w", rule.Id, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// CreateRule 新增规则，并回填分配到的ID
+func (s *RuleStoreImpl) CreateRule(rule *entity.Rule) error {
+	processIds, actions, err := encodeRule(rule)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`INSERT INTO rules (name, enabled, pattern, process_ids, cooldown_ms, actions) VALUES (?, ?, ?, ?, ?, ?)`,
+		rule.Name, rule.Enabled, rule.Pattern, processIds, rule.CooldownMs, actions)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	rule.Id = int(id)
+	return nil
+}
+
+// UpdateRule 更新已有的规则
+func (s *RuleStoreImpl) UpdateRule(rule *entity.Rule) error {
+	processIds, actions, err := encodeRule(rule)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.Exec(`UPDATE rules SET name = ?, enabled = ?, pattern = ?, process_ids = ?, cooldown_ms = ?, actions = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		rule.Name, rule.Enabled, rule.Pattern, processIds, rule.CooldownMs, actions, rule.Id)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("ID为 %d 的规则不存在", rule.Id)
+	}
+	return nil
+}
+
+// DeleteRule 删除指定ID的规则
+func (s *RuleStoreImpl) DeleteRule(id int) error {
+	_, err := s.db.Exec(`DELETE FROM rules WHERE id = ?`, id)
+	return err
+}
+
+// encodeRule 将规则中的复合字段序列化为 JSON 文本
+func encodeRule(rule *entity.Rule) (string, string, error) {
+	processIds := rule.ProcessIds
+	if processIds == nil {
+		processIds = []int{}
+	}
+	ids, err := json.Marshal(processIds)
+	if err != nil {
+		return "", "", err
+	}
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return "", "", err
+	}
+	return string(ids), string(actions), nil
+}
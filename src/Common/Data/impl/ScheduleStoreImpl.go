@@ -0,0 +1,217 @@
+package v_data_impl
+
+import (
+	"database/sql"
+	"fmt"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+)
+
+// ScheduleStoreImpl 基于 SQLite 的定时任务与执行记录存储
+type ScheduleStoreImpl struct {
+	*BaseDataBaseImpl
+}
+
+var _ vdata.ScheduleStore = (*ScheduleStoreImpl)(nil)
+
+// NewScheduleStoreImpl 创建定时任务存储，并确保数据表存在
+func NewScheduleStoreImpl(base *BaseDataBaseImpl) (*ScheduleStoreImpl, error) {
+	err := base.migrate(`
+		CREATE TABLE IF NOT EXISTS scheduled_jobs (
+			id            INTEGER PRIMARY KEY AUTOINCREMENT,
+			name          TEXT    NOT NULL DEFAULT '',
+			enabled       INTEGER NOT NULL DEFAULT 1,
+			cron          TEXT    NOT NULL,
+			timezone      TEXT    NOT NULL DEFAULT '',
+			type          TEXT    NOT NULL,
+			process_id    INTEGER NOT NULL DEFAULT 0,
+			command       TEXT    NOT NULL DEFAULT '',
+			delay_sec     INTEGER NOT NULL DEFAULT 0,
+			missed_policy TEXT    NOT NULL DEFAULT 'skip',
+			next_run_at   INTEGER,
+			created_at    DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at    DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS scheduled_job_runs (
+			id           INTEGER PRIMARY KEY AUTOINCREMENT,
+			job_id       INTEGER NOT NULL REFERENCES scheduled_jobs (id) ON DELETE CASCADE,
+			scheduled_at INTEGER NOT NULL,
+			started_at   INTEGER NOT NULL,
+			finished_at  INTEGER,
+			status       TEXT    NOT NULL,
+			error        TEXT    NOT NULL DEFAULT '',
+			UNIQUE (job_id, scheduled_at)
+		)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduleStoreImpl{BaseDataBaseImpl: base}, nil
+}
+
+// ListJobs 按ID顺序列出所有定时任务，并附带每个任务最近一次的执行记录
+func (s *ScheduleStoreImpl) ListJobs() ([]entity.ScheduledJob, error) {
+	rows, err := s.db.Query(`SELECT j.id, j.name, j.enabled, j.cron, j.timezone, j.type, j.process_id, j.command, j.delay_sec, j.missed_policy, j.next_run_at,
+			r.id, r.scheduled_at, r.started_at, r.finished_at, r.status, r.error
+		FROM scheduled_jobs j
+		LEFT JOIN scheduled_job_runs r ON r.id = (SELECT id FROM scheduled_job_runs WHERE job_id = j.id ORDER BY id DESC LIMIT 1)
+		ORDER BY j.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []entity.ScheduledJob{}
+	for rows.Next() {
+		var (
+			job                    entity.ScheduledJob
+			nextRunAt              sql.NullInt64
+			runId                  sql.NullInt64
+			scheduledAt, startedAt sql.NullInt64
+			finishedAt             sql.NullInt64
+			status, message        sql.NullString
+		)
+		if err := rows.Scan(&job.Id, &job.Name, &job.Enabled, &job.Cron, &job.Timezone, &job.Type, &job.ProcessId, &job.Command, &job.DelaySec, &job.MissedPolicy, &nextRunAt,
+			&runId, &scheduledAt, &startedAt, &finishedAt, &status, &message); err != nil {
+			return nil, err
+		}
+		if nextRunAt.Valid {
+			job.NextRunAt = &nextRunAt.Int64
+		}
+		if runId.Valid {
+			job.LastRun = &entity.JobRun{
+				Id:          runId.Int64,
+				JobId:       job.Id,
+				ScheduledAt: scheduledAt.Int64,
+				StartedAt:   startedAt.Int64,
+				Status:      status.String,
+				Error:       message.String,
+			}
+			if finishedAt.Valid {
+				job.LastRun.FinishedAt = &finishedAt.Int64
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// CreateJob 新增定时任务，并回填分配到的ID
+func (s *ScheduleStoreImpl) CreateJob(job *entity.ScheduledJob) error {
+	result, err := s.db.Exec(`INSERT INTO scheduled_jobs (name, enabled, cron, timezone, type, process_id, command, delay_sec, missed_policy, next_run_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.Name, job.Enabled, job.Cron, job.Timezone, job.Type, job.ProcessId, job.Command, job.DelaySec, job.MissedPolicy, job.NextRunAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	job.Id = int(id)
+	return nil
+}
+
+// UpdateJob 更新已有的定时任务，包括下一次执行的时间
+func (s *ScheduleStoreImpl) UpdateJob(job *entity.ScheduledJob) error {
+	result, err := s.db.Exec(`UPDATE scheduled_jobs SET name = ?, enabled = ?, cron = ?, timezone = ?, type = ?, process_id = ?, command = ?,
+			delay_sec = ?, missed_policy = ?, next_run_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`,
+		job.Name, job.Enabled, job.Cron, job.Timezone, job.Type, job.ProcessId, job.Command, job.DelaySec, job.MissedPolicy, job.NextRunAt, job.Id)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("ID为 %d 的定时任务不存在", job.Id)
+	}
+	return nil
+}
+
+// DeleteJob 删除指定ID的定时任务及其执行记录
+func (s *ScheduleStoreImpl) DeleteJob(id int) error {
+	_, err := s.db.Exec(`DELETE FROM scheduled_jobs WHERE id = ?`, id)
+	return err
+}
+
+// AddRun 写入一条执行记录并更新任务下一次执行的时间，回填记录ID
+// 同一任务同一计划时间的记录已存在时不写入并返回 false，两者在同一事务中完成，进程在执行期间退出也不会重复执行
+func (s *ScheduleStoreImpl) AddRun(run *entity.JobRun, nextRunAt *int64) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`INSERT INTO scheduled_job_runs (job_id, scheduled_at, started_at, finished_at, status, error)
+		VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (job_id, scheduled_at) DO NOTHING`,
+		run.JobId, run.ScheduledAt, run.StartedAt, run.FinishedAt, run.Status, run.Error)
+	if err != nil {
+		return false, err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tx.Exec(`UPDATE scheduled_jobs SET next_run_at = ? WHERE id = ?`, nextRunAt, run.JobId); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	run.Id = id
+	return true, nil
+}
+
+// FinishRun 记录执行的结果
+func (s *ScheduleStoreImpl) FinishRun(id int64, status string, message string, finishedAt int64) error {
+	_, err := s.db.Exec(`UPDATE scheduled_job_runs SET status = ?, error = ?, finished_at = ? WHERE id = ?`, status, message, finishedAt, id)
+	return err
+}
+
+// FailRunningRuns 将所有仍在执行中的记录标记为失败
+func (s *ScheduleStoreImpl) FailRunningRuns(message string, finishedAt int64) error {
+	_, err := s.db.Exec(`UPDATE scheduled_job_runs SET status = 'failed', error = ?, finished_at = ? WHERE status = 'running'`, message, finishedAt)
+	return err
+}
+
+// ListRuns 按计划时间倒序列出任务的执行记录，limit 为 0 时不限制
+func (s *ScheduleStoreImpl) ListRuns(jobId int, limit int) ([]entity.JobRun, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite 中负数表示不限制
+	}
+	rows, err := s.db.Query(`SELECT id, job_id, scheduled_at, started_at, finished_at, status, error FROM scheduled_job_runs
+		WHERE job_id = ? ORDER BY scheduled_at DESC, id DESC LIMIT ?`, jobId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []entity.JobRun{}
+	for rows.Next() {
+		var (
+			run        entity.JobRun
+			finishedAt sql.NullInt64
+		)
+		if err := rows.Scan(&run.Id, &run.JobId, &run.ScheduledAt, &run.StartedAt, &finishedAt, &run.Status, &run.Error); err != nil {
+			return nil, err
+		}
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Int64
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// PruneRuns 只保留任务最近的 keep 条执行记录
+func (s *ScheduleStoreImpl) PruneRuns(jobId int, keep int) error {
+	_, err := s.db.Exec(`DELETE FROM scheduled_job_runs WHERE job_id = ? AND id NOT IN (
+			SELECT id FROM scheduled_job_runs WHERE job_id = ? ORDER BY id DESC LIMIT ?)`, jobId, jobId, keep)
+	return err
+}
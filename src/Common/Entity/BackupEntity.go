@@ -0,0 +1,28 @@
+package entity
+
+// Backup 备份目录中的一个归档文件
+type Backup struct {
+	Id         int    `json:"id"`
+	ProcessId  int    `json:"processId"`
+	FileName   string `json:"fileName"`
+	Path       string `json:"path"`       // 归档文件的完整路径
+	Format     string `json:"format"`     // zip / tar.zst
+	SizeBytes  int64  `json:"sizeBytes"`  // 归档文件的大小
+	Files      int    `json:"files"`      // 归档中的文件数
+	Sha256     string `json:"sha256"`     // 归档文件的 SHA-256，十六进制
+	DurationMs int64  `json:"durationMs"` // 创建归档的耗时
+	Trigger    string `json:"trigger"`    // manual / schedule
+	CreatedAt  int64  `json:"createdAt"`  // 开始备份的时间，Unix 毫秒
+}
+
+// BackupPolicy 进程的备份设置
+// 保留规则同时生效: 最近的 KeepLast 个备份，以及最近 KeepDaily 天、KeepWeekly 周中每天、每周最新的一个备份，全部为 0 时不删除旧备份
+type BackupPolicy struct {
+	Paths      []string `json:"paths"`      // 要备份的文件或目录，相对路径基于服务器目录，为空时自动识别世界目录
+	Format     string   `json:"format"`     // zip / tar.zst，为空使用 zip
+	Directory  string   `json:"directory"`  // 存放备份的目录，为空使用 Voxesis 数据目录下的 backups/<进程ID>
+	KeepLast   int      `json:"keepLast"`   // 保留最近的备份数
+	KeepDaily  int      `json:"keepDaily"`  // 按天保留的天数
+	KeepWeekly int      `json:"keepWeekly"` // 按周保留的周数
+	SaveMode   string   `json:"saveMode"`   // 备份运行中的服务器时暂停保存的方式: auto / bedrock / java / off，为空使用 auto
+}
@@ -0,0 +1,28 @@
+package entity
+
+// OnlinePlayer 当前在线的玩家
+type OnlinePlayer struct {
+	Name     string `json:"name"`
+	Xuid     string `json:"xuid,omitempty"` // 仅基岩版
+	JoinedAt int64  `json:"joinedAt"`       // 加入时间，Unix 毫秒
+}
+
+// PlayerSession 玩家的一次游戏会话
+type PlayerSession struct {
+	Id         int64  `json:"id"`
+	ProcessId  int    `json:"processId"`
+	Name       string `json:"name"`
+	Xuid       string `json:"xuid,omitempty"`
+	JoinedAt   int64  `json:"joinedAt"`   // Unix 毫秒
+	LeftAt     *int64 `json:"leftAt"`     // Unix 毫秒，仍在线时为空
+	DurationMs int64  `json:"durationMs"` // 仍在线时计算到当前时间
+}
+
+// PlayerPlaytime 玩家在一个进程上的累计游戏时长
+type PlayerPlaytime struct {
+	Name     string `json:"name"`
+	Xuid     string `json:"xuid,omitempty"`
+	Sessions int    `json:"sessions"` // 会话次数
+	TotalMs  int64  `json:"totalMs"`  // 累计时长，包括仍在进行的会话
+	LastSeen int64  `json:"lastSeen"` // 最近一次在线的时间，Unix 毫秒
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ProcessDefinition 持久化的进程定义，重启 Voxesis 后据此恢复进程列表
+type ProcessDefinition struct {
+	Id          int               `json:"id"`
+	ProcessType int               `json:"processType"`
+	Path        string            `json:"path"`
+	Args        []string          `json:"args"`
+	WorkingDir  string            `json:"workingDir"` // 为空时使用可执行文件所在目录
+	Env         map[string]string `json:"env"`        // 覆盖或追加到系统环境变量
+	Options     json.RawMessage   `json:"options"`    // 重启策略、停止流程等进程配置
+}
+
+// ResourceLimits 进程的资源限制，仅在 Linux 上通过 cgroup v2 对普通进程生效，0 表示不限制
+type ResourceLimits struct {
+	MemoryMaxMB int64   `json:"memoryMaxMb"` // memory.max，单位 MB
+	CpuPercent  float64 `json:"cpuPercent"`  // cpu.max，100 表示一个完整的 CPU 核心
+	PidsMax     int64   `json:"pidsMax"`     // pids.max
+}
+
+// OutputLine 带序号的一行进程输出，序号在进程管理器的生命周期内单调递增
+type OutputLine struct {
+	Seq    uint64     `json:"seq"`
+	Time   int64      `json:"time"` // 输出时间，Unix 毫秒
+	Data   string     `json:"data"`
+	Record *LogRecord `json:"record,omitempty"` // 识别出服务器日志格式时的结构化记录
+}
+
+// LogRecord 从一行服务器日志中解析出的结构化记录
+type LogRecord struct {
+	Format  string `json:"format"`           // bedrock / java
+	Stream  string `json:"stream,omitempty"` // stdout / stderr，伪终端输出为空
+	Time    int64  `json:"time"`             // 日志中的时间，Unix 毫秒
+	Level   string `json:"level"`            // INFO / WARN / ERROR 等，统一为大写
+	Source  string `json:"source,omitempty"` // Java 版的线程名，基岩版为空
+	Message string `json:"message"`
+}
+
+// ProcessEvent 进程生命周期事件
+type ProcessEvent struct {
+	Type  string `json:"type"` // started / stopped / exited / restarting / crash-loop
+	Time  int64  `json:"time"` // 事件时间，Unix 毫秒
+	Error string `json:"error,omitempty"`
+	Stage string `json:"stage,omitempty"` // stopped 事件中进程在停止流程的哪个阶段退出
+
+	Lifecycle *ProcessLifecycle `json:"lifecycle,omitempty"` // state-changed 事件中变化后的生命周期
+	Player    *OnlinePlayer     `json:"player,omitempty"`    // player-joined / player-left 事件中的玩家
+	Rule      *RuleMatch        `json:"rule,omitempty"`      // rule-matched 事件中触发的规则
+	Restart   *RestartProgress  `json:"restart,omitempty"`   // restart-* 事件中计划重启的进度
+}
+
+// RestartProgress 带倒计时广播的计划重启的进度
+type RestartProgress struct {
+	Reason      string `json:"reason,omitempty"`
+	Phase       string `json:"phase"`       // countdown / stopping / starting / completed / aborted / failed
+	PlannedAt   int64  `json:"plannedAt"`   // 发起重启的时间，Unix 毫秒
+	RestartAt   int64  `json:"restartAt"`   // 倒计时结束、开始停止进程的时间，Unix 毫秒
+	RemainingMs int64  `json:"remainingMs"` // 距离 RestartAt 的剩余时间
+	Error       string `json:"error,omitempty"`
+}
+
+// LifecycleState 进程的生命周期状态
+type LifecycleState string
+
+const (
+	LifecycleCreated  LifecycleState = "created"  // 已创建，从未启动
+	LifecycleStarting LifecycleState = "starting" // 正在启动
+	LifecycleRunning  LifecycleState = "running"  // 正在运行
+	LifecycleStopping LifecycleState = "stopping" // 正在执行停止流程
+	LifecycleStopped  LifecycleState = "stopped"  // 被手动停止或正常退出
+	LifecycleCrashed  LifecycleState = "crashed"  // 异常退出或启动失败
+)
+
+// ProcessLifecycle 进程的生命周期记录
+type ProcessLifecycle struct {
+	State     LifecycleState `json:"state"`
+	ExitCode  *int           `json:"exitCode"`  // 最近一次退出的退出码，未退出或未知时为空
+	Signal    string         `json:"signal"`    // 最近一次退出时终止进程的信号，仅类 Unix 平台
+	StartedAt *time.Time     `json:"startedAt"` // 最近一次启动成功的时间
+	StoppedAt *time.Time     `json:"stoppedAt"` // 最近一次退出的时间
+	LastError string         `json:"lastError"` // 最近一次启动失败或异常退出的错误
+	Ready     bool           `json:"ready"`     // 是否已通过就绪检测
+	ReadyAt   *time.Time     `json:"readyAt"`   // 最近一次通过就绪检测的时间
+}
@@ -0,0 +1,31 @@
+package entity
+
+// Rule 匹配进程输出并执行动作的触发规则
+type Rule struct {
+	Id         int          `json:"id"`
+	Name       string       `json:"name"`
+	Enabled    bool         `json:"enabled"`
+	Pattern    string       `json:"pattern"`    // 正则表达式，匹配控制台中显示的原始输出行
+	ProcessIds []int        `json:"processIds"` // 只匹配这些进程的输出，为空表示所有进程
+	CooldownMs int64        `json:"cooldownMs"` // 同一进程上两次触发的最短间隔，0 表示不限制；含命令、停止或重启动作时至少为 1 秒
+	Actions    []RuleAction `json:"actions"`
+}
+
+// RuleAction 规则触发后依次执行的动作
+type RuleAction struct {
+	Type    string `json:"type"`              // command / event / webhook / stop / restart
+	Command string `json:"command,omitempty"` // command: 发送给触发规则的进程，$1 或 ${1} 替换为捕获组
+	Event   string `json:"event,omitempty"`   // event: 事件名，随 rule-matched 事件发出
+	Url     string `json:"url,omitempty"`     // webhook: 以 POST 发送匹配结果的地址
+}
+
+// RuleMatch 规则的一次触发
+type RuleMatch struct {
+	RuleId    int      `json:"ruleId"`
+	RuleName  string   `json:"ruleName"`
+	ProcessId int      `json:"processId"`
+	Event     string   `json:"event,omitempty"` // 由 event 动作发出时的事件名
+	Line      string   `json:"line"`
+	Groups    []string `json:"groups"` // 完整匹配与各捕获组
+	Time      int64    `json:"time"`   // Unix 毫秒
+}
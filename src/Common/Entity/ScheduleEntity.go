@@ -0,0 +1,29 @@
+package entity
+
+// ScheduledJob 按 cron 表达式定时执行的任务
+type ScheduledJob struct {
+	Id           int    `json:"id"`
+	Name         string `json:"name"`
+	Enabled      bool   `json:"enabled"`
+	Cron         string `json:"cron"`                // 分 时 日 月 星期，或 @daily 等简写
+	Timezone     string `json:"timezone"`            // IANA 时区名，如 Asia/Shanghai，为空表示本机时区
+	Type         string `json:"type"`                // command / restart / start / stop / backup
+	ProcessId    int    `json:"processId"`           // 任务作用的进程
+	Command      string `json:"command,omitempty"`   // command: 发送给进程的命令
+	DelaySec     int    `json:"delaySec,omitempty"`  // restart: 重启前的倒计时秒数，期间按进程的倒计时广播设置通知玩家，为 0 时立即重启
+	MissedPolicy string `json:"missedPolicy"`        // skip / once，Voxesis 未运行期间错过的执行如何处理
+	NextRunAt    *int64 `json:"nextRunAt,omitempty"` // 下一次计划执行的时间，Unix 毫秒，禁用或不会再触发时为空
+
+	LastRun *JobRun `json:"lastRun,omitempty"` // 最近一次执行，只读
+}
+
+// JobRun 任务的一次执行记录
+type JobRun struct {
+	Id          int64  `json:"id"`
+	JobId       int    `json:"jobId"`
+	ScheduledAt int64  `json:"scheduledAt"`          // 计划执行的时间，Unix 毫秒
+	StartedAt   int64  `json:"startedAt"`            // 实际开始的时间，Unix 毫秒
+	FinishedAt  *int64 `json:"finishedAt,omitempty"` // 执行中为空
+	Status      string `json:"status"`               // running / success / failed / missed
+	Error       string `json:"error,omitempty"`      // 失败原因
+}
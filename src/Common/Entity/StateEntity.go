@@ -1,10 +1,32 @@
 package entity
 
+// ProcessState 进程的资源使用情况，资源字段为进程及其所有后代进程的汇总
 type ProcessState struct {
 	Pid     string  `json:"pid"`
-	Cpu     float64 `json:"cpu"`
-	Memory  float64 `json:"memory"`
+	Cpu     float64 `json:"cpu"`    // 占整机 CPU 的百分比
+	Memory  float64 `json:"memory"` // RSS，单位 MB
 	RunTime string  `json:"runTime"`
+	Ready   bool    `json:"ready"` // 是否已通过就绪检测
+
+	CpuPerCore float64 `json:"cpuPerCore"` // 100 表示占满一个 CPU 核心
+	Threads    int32   `json:"threads"`    // 线程数
+	OpenFiles  int32   `json:"openFiles"`  // 打开的文件描述符数，平台不支持时为 0
+	ReadBytes  uint64  `json:"readBytes"`  // 累计读取字节数，平台不支持时为 0
+	WriteBytes uint64  `json:"writeBytes"` // 累计写入字节数，平台不支持时为 0
+	Processes  int     `json:"processes"`  // 进程树中的进程数
+
+	Cgroup *CgroupState `json:"cgroup,omitempty"` // 配置了资源限制时的 cgroup 状态
+}
+
+// CgroupState 进程所在 cgroup 的资源限制状态
+type CgroupState struct {
+	Enabled        bool    `json:"enabled"`           // 资源限制是否已生效
+	Warning        string  `json:"warning,omitempty"` // 未能启用资源限制的原因
+	MemoryMB       float64 `json:"memoryMb"`          // memory.current，包括子进程
+	ThrottledCount uint64  `json:"throttledCount"`    // 因超出 cpu.max 被限流的次数
+	ThrottledUsec  uint64  `json:"throttledUsec"`     // 被限流的总时长，微秒
+	OomCount       uint64  `json:"oomCount"`          // 内存达到 memory.max 的次数
+	OomKillCount   uint64  `json:"oomKillCount"`      // 被 OOM killer 杀死的进程数
 }
 
 type SystemState struct {
@@ -26,3 +48,16 @@ type BedrockMcServerStatus struct {
 	PortV4        *uint16 `json:"port_v4,omitempty"`
 	PortV6        *uint16 `json:"port_v6,omitempty"`
 }
+
+// MetricSample 进程在一个时间段内的资源使用指标，原始采样的 Samples 为 1
+type MetricSample struct {
+	Time        int64   `json:"time"`        // 时间段的起点，Unix 毫秒
+	Cpu         float64 `json:"cpu"`         // 平均 CPU 使用率
+	CpuMax      float64 `json:"cpuMax"`      // 最高 CPU 使用率
+	MemoryMB    float64 `json:"memoryMb"`    // 平均 RSS
+	MemoryMaxMB float64 `json:"memoryMaxMb"` // 最高 RSS
+	Threads     int32   `json:"threads"`     // 最多线程数
+	ReadRate    float64 `json:"readRate"`    // 平均读取速率，字节/秒
+	WriteRate   float64 `json:"writeRate"`   // 平均写入速率，字节/秒
+	Samples     int     `json:"samples"`     // 聚合的原始采样数
+}
@@ -0,0 +1,426 @@
+package v_manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	vdata "voxesis/src/Common/Data"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vutils "voxesis/src/Common/Utils"
+)
+
+// 备份的触发方式
+const (
+	BackupTriggerManual   = "manual"   // 手动创建
+	BackupTriggerSchedule = "schedule" // 定时任务创建
+)
+
+// 备份运行中的服务器时暂停保存的方式，另可指定 SaveEditionBedrock / SaveEditionJava
+const (
+	BackupSaveAuto = "auto" // 按服务器目录中的世界目录识别版本，无法识别时不暂停
+	BackupSaveOff  = "off"  // 不暂停保存，直接复制
+)
+
+// defaultWorldPaths 未设置备份路径时按顺序识别的世界目录，基岩版为 worlds，Java 版为 world 及其维度目录
+var defaultWorldPaths = [][]string{
+	{"worlds"},
+	{"world", "world_nether", "world_the_end"},
+}
+
+// BackupSource 提供进程的服务器目录，并在备份期间暂停服务器的保存
+type BackupSource interface {
+	ServerDir(processId int) (string, error)
+	// HoldSave 暂停正在运行的进程的保存，进程未运行时返回 nil
+	HoldSave(processId int, edition string) (*SaveHold, error)
+}
+
+// BackupManager 将进程的世界目录或指定路径归档为带时间戳的备份，并按保留规则清理旧备份
+type BackupManager struct {
+	store   vdata.BackupStore
+	source  BackupSource
+	rootDir string // 未设置备份目录时，备份存放在 rootDir/<进程ID>
+
+	mu      sync.Mutex
+	running map[int]bool // 正在备份的进程，同一进程不会同时进行多个备份
+}
+
+// NewBackupManager 创建备份管理器
+func NewBackupManager(store vdata.BackupStore, source BackupSource, rootDir string) *BackupManager {
+	return &BackupManager{
+		store:   store,
+		source:  source,
+		rootDir: rootDir,
+		running: make(map[int]bool),
+	}
+}
+
+// normalizeBackupPolicy 校验备份设置并填充默认值
+func normalizeBackupPolicy(policy entity.BackupPolicy) (entity.BackupPolicy, error) {
+	switch policy.Format {
+	case "":
+		policy.Format = vutils.ArchiveZip
+	case vutils.ArchiveZip, vutils.ArchiveTarZst:
+	default:
+		return policy, fmt.Errorf("不支持的备份格式: %s", policy.Format)
+	}
+	switch policy.SaveMode {
+	case "":
+		policy.SaveMode = BackupSaveAuto
+	case BackupSaveAuto, BackupSaveOff, SaveEditionBedrock, SaveEditionJava:
+	default:
+		return policy, fmt.Errorf("不支持的暂停保存方式: %s", policy.SaveMode)
+	}
+	if policy.KeepLast < 0 || policy.KeepDaily < 0 || policy.KeepWeekly < 0 {
+		return policy, fmt.Errorf("保留数量不能为负数")
+	}
+	for _, path := range policy.Paths {
+		if path == "" {
+			return policy, fmt.Errorf("备份路径不能为空")
+		}
+	}
+	return policy, nil
+}
+
+// GetPolicy 获取进程的备份设置，未设置时返回默认值
+func (m *BackupManager) GetPolicy(processId int) (entity.BackupPolicy, error) {
+	policy, err := m.store.GetPolicy(processId)
+	if err != nil {
+		return entity.BackupPolicy{}, err
+	}
+	if policy == nil {
+		policy = &entity.BackupPolicy{}
+	}
+	return normalizeBackupPolicy(*policy)
+}
+
+// SetPolicy 校验并保存进程的备份设置，对下一次备份生效
+func (m *BackupManager) SetPolicy(processId int, policy entity.BackupPolicy) error {
+	policy, err := normalizeBackupPolicy(policy)
+	if err != nil {
+		return err
+	}
+	return m.store.SetPolicy(processId, policy)
+}
+
+// ListBackups 按创建时间倒序列出进程的备份
+func (m *BackupManager) ListBackups(processId int) ([]entity.Backup, error) {
+	return m.store.ListBackups(processId)
+}
+
+// GetBackup 获取指定ID的备份
+func (m *BackupManager) GetBackup(id int) (entity.Backup, error) {
+	backup, err := m.store.GetBackup(id)
+	if err != nil {
+		return entity.Backup{}, err
+	}
+	return *backup, nil
+}
+
+// OpenBackup 打开备份的归档文件用于下载，调用方负责关闭
+func (m *BackupManager) OpenBackup(id int) (entity.Backup, *os.File, error) {
+	backup, err := m.GetBackup(id)
+	if err != nil {
+		return backup, nil, err
+	}
+	file, err := os.Open(backup.Path)
+	if err != nil {
+		return backup, nil, fmt.Errorf("打开备份文件失败: %w", err)
+	}
+	return backup, file, nil
+}
+
+// DeleteBackup 删除备份的归档文件与记录，文件已不存在时只删除记录
+func (m *BackupManager) DeleteBackup(id int) error {
+	backup, err := m.GetBackup(id)
+	if err != nil {
+		return err
+	}
+	return m.deleteBackup(backup)
+}
+
+// deleteBackup 删除备份的归档文件与记录
+func (m *BackupManager) deleteBackup(backup entity.Backup) error {
+	if err := os.Remove(backup.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("删除备份文件失败: %w", err)
+	}
+	return m.store.DeleteBackup(backup.Id)
+}
+
+// CreateBackup 按进程的备份设置创建一个备份，完成后按保留规则清理旧备份
+// 进程正在运行时先暂停服务器的保存，归档写入完成后恢复，暂停失败时不备份
+// 归档先写入临时文件，完成后再改名，中途失败不会留下不完整的备份
+func (m *BackupManager) CreateBackup(processId int, trigger string) (entity.Backup, error) {
+	m.mu.Lock()
+	if m.running[processId] {
+		m.mu.Unlock()
+		return entity.Backup{}, fmt.Errorf("ID为 %d 的进程正在备份", processId)
+	}
+	m.running[processId] = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, processId)
+		m.mu.Unlock()
+	}()
+
+	policy, err := m.GetPolicy(processId)
+	if err != nil {
+		return entity.Backup{}, fmt.Errorf("读取备份设置失败: %w", err)
+	}
+	serverDir, err := m.source.ServerDir(processId)
+	if err != nil {
+		return entity.Backup{}, err
+	}
+	paths, err := resolveBackupPaths(serverDir, policy.Paths)
+	if err != nil {
+		return entity.Backup{}, err
+	}
+	dir := policy.Directory
+	if dir == "" {
+		dir = filepath.Join(m.rootDir, strconv.Itoa(processId))
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return entity.Backup{}, fmt.Errorf("创建备份目录失败: %w", err)
+	}
+
+	started := time.Now()
+	var hold *SaveHold
+	if edition := resolveSaveEdition(serverDir, policy.SaveMode); edition != "" {
+		if hold, err = m.source.HoldSave(processId, edition); err != nil {
+			return entity.Backup{}, fmt.Errorf("暂停保存失败: %w", err)
+		}
+	}
+	if hold != nil {
+		defer hold.Resume()
+	}
+
+	backup := entity.Backup{
+		ProcessId: processId,
+		Format:    policy.Format,
+		Trigger:   trigger,
+		CreatedAt: started.UnixMilli(),
+	}
+	backup.FileName, backup.Path = backupFileName(dir, started, policy.Format)
+	vlogger.AppLogger.Infof("开始备份ID为 %d 的进程: %v -> %s", processId, paths, backup.Path)
+
+	err = writeBackup(&backup, archiveSource(serverDir, paths, dir, hold))
+	if hold != nil {
+		_ = hold.Resume()
+	}
+	if err != nil {
+		return entity.Backup{}, fmt.Errorf("创建备份失败: %w", err)
+	}
+	backup.DurationMs = time.Since(started).Milliseconds()
+
+	if err := m.store.AddBackup(&backup); err != nil {
+		_ = os.Remove(backup.Path)
+		return entity.Backup{}, fmt.Errorf("记录备份失败: %w", err)
+	}
+	vlogger.AppLogger.Infof("ID为 %d 的进程备份完成: %s, %d 个文件, %d 字节, 耗时 %d 毫秒",
+		processId, backup.FileName, backup.Files, backup.SizeBytes, backup.DurationMs)
+
+	if err := m.applyRetention(processId, policy); err != nil {
+		vlogger.AppLogger.Warnf("清理ID为 %d 的进程的旧备份失败: %v", processId, err)
+	}
+	return backup, nil
+}
+
+// resolveBackupPaths 返回要备份的路径，未设置时识别服务器目录中的世界目录
+func resolveBackupPaths(serverDir string, paths []string) ([]string, error) {
+	if len(paths) > 0 {
+		for _, path := range paths {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(serverDir, path)
+			}
+			if _, err := os.Stat(path); err != nil {
+				return nil, fmt.Errorf("备份路径不可用: %w", err)
+			}
+		}
+		return paths, nil
+	}
+
+	for _, candidates := range defaultWorldPaths {
+		var found []string
+		for _, name := range candidates {
+			if info, err := os.Stat(filepath.Join(serverDir, name)); err == nil && info.IsDir() {
+				found = append(found, name)
+			}
+		}
+		if len(found) > 0 {
+			return found, nil
+		}
+	}
+	return nil, fmt.Errorf("未在 %s 中找到世界目录，请在备份设置中指定要备份的路径", serverDir)
+}
+
+// resolveSaveEdition 返回暂停保存所用的服务器版本，不需要暂停时返回空
+func resolveSaveEdition(serverDir string, mode string) string {
+	switch mode {
+	case BackupSaveOff:
+		return ""
+	case BackupSaveAuto:
+		if info, err := os.Stat(filepath.Join(serverDir, "worlds")); err == nil && info.IsDir() {
+			return SaveEditionBedrock
+		}
+		if info, err := os.Stat(filepath.Join(serverDir, "world")); err == nil && info.IsDir() {
+			return SaveEditionJava
+		}
+		return ""
+	default:
+		return mode
+	}
+}
+
+// archiveSource 返回要写入归档的内容，备份目录位于备份路径中时跳过它，不归档正在写入的临时文件与旧备份
+// 基岩版暂停保存时，save query 报告的世界只写入报告的文件，并截断到报告的长度，其余路径照常写入
+func archiveSource(serverDir string, paths []string, backupDir string, hold *SaveHold) vutils.ArchiveSource {
+	source := vutils.ArchiveSource{Root: serverDir, Paths: paths}
+	if dir, err := filepath.Abs(backupDir); err == nil {
+		source.Exclude = append(source.Exclude, dir)
+	}
+	if hold == nil || len(hold.Files) == 0 {
+		return source
+	}
+
+	selected := make([]string, len(paths))
+	for i, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(serverDir, path)
+		}
+		selected[i] = path
+	}
+
+	worldsDir := filepath.Join(serverDir, "worlds")
+	worlds := make(map[string]bool)
+	for _, file := range hold.Files {
+		path := filepath.Join(worldsDir, filepath.FromSlash(file.Path))
+		if !withinAny(path, selected) {
+			continue
+		}
+		source.Files = append(source.Files, vutils.ArchiveFile{Path: path, Size: file.Size})
+
+		world, _, _ := strings.Cut(file.Path, "/")
+		if world = filepath.Join(worldsDir, world); !worlds[world] {
+			worlds[world] = true
+			source.Exclude = append(source.Exclude, world)
+		}
+	}
+	return source
+}
+
+// withinAny 判断 path 是否在 dirs 中的任一路径中
+func withinAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if vutils.IsWithinDir(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// backupFileName 返回不与已有文件重名的带时间戳的备份文件名与完整路径
+func backupFileName(dir string, at time.Time, format string) (string, string) {
+	base := "backup-" + at.Format("20060102-150405")
+	name := base + "." + format
+	for i := 2; ; i++ {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return name, path
+		}
+		name = fmt.Sprintf("%s-%d.%s", base, i, format)
+	}
+}
+
+// writeBackup 写入归档并计算大小与校验和
+func writeBackup(backup *entity.Backup, source vutils.ArchiveSource) error {
+	tmp := backup.Path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	files, err := vutils.WriteArchive(io.MultiWriter(file, hash, counter), backup.Format, source)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, backup.Path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	backup.Files = files
+	backup.SizeBytes = counter.n
+	backup.Sha256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// countingWriter 统计写入的字节数
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// applyRetention 按保留规则删除进程的旧备份，保留规则全部为 0 时不删除
+// 每天、每周的最新备份按本机时区划分，每周从星期一开始
+func (m *BackupManager) applyRetention(processId int, policy entity.BackupPolicy) error {
+	if policy.KeepLast == 0 && policy.KeepDaily == 0 && policy.KeepWeekly == 0 {
+		return nil
+	}
+
+	backups, err := m.store.ListBackups(processId)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[int]bool)
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	for i, backup := range backups {
+		if i < policy.KeepLast {
+			keep[backup.Id] = true
+		}
+		at := time.UnixMilli(backup.CreatedAt)
+		if day := at.Format("2006-01-02"); !days[day] && len(days) < policy.KeepDaily {
+			days[day] = true
+			keep[backup.Id] = true
+		}
+		year, week := at.ISOWeek()
+		if key := fmt.Sprintf("%d-%d", year, week); !weeks[key] && len(weeks) < policy.KeepWeekly {
+			weeks[key] = true
+			keep[backup.Id] = true
+		}
+	}
+
+	var errs []error
+	for _, backup := range backups {
+		if keep[backup.Id] {
+			continue
+		}
+		if err := m.deleteBackup(backup); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		vlogger.AppLogger.Infof("已按保留规则删除备份 %s", backup.FileName)
+	}
+	return errors.Join(errs...)
+}
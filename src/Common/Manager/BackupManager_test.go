@@ -0,0 +1,172 @@
+package v_manager
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+// memoryBackupStore 保存在内存中的备份记录与设置
+type memoryBackupStore struct {
+	mu      sync.Mutex
+	backups []entity.Backup // 按创建时间倒序
+	policy  *entity.BackupPolicy
+}
+
+func (s *memoryBackupStore) ListBackups(processId int) ([]entity.Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entity.Backup{}, s.backups...), nil
+}
+
+func (s *memoryBackupStore) GetBackup(id int) (*entity.Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, backup := range s.backups {
+		if backup.Id == id {
+			return &backup, nil
+		}
+	}
+	return nil, fmt.Errorf("ID为 %d 的备份不存在", id)
+}
+
+func (s *memoryBackupStore) AddBackup(backup *entity.Backup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	backup.Id = len(s.backups) + 1
+	s.backups = append([]entity.Backup{*backup}, s.backups...)
+	return nil
+}
+
+func (s *memoryBackupStore) DeleteBackup(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backups = slices.DeleteFunc(s.backups, func(backup entity.Backup) bool { return backup.Id == id })
+	return nil
+}
+
+func (s *memoryBackupStore) GetPolicy(processId int) (*entity.BackupPolicy, error) {
+	return s.policy, nil
+}
+
+func (s *memoryBackupStore) SetPolicy(processId int, policy entity.BackupPolicy) error {
+	s.policy = &policy
+	return nil
+}
+
+// dirBackupSource 所有进程都使用同一个服务器目录，不暂停保存
+type dirBackupSource string
+
+func (d dirBackupSource) ServerDir(processId int) (string, error) { return string(d), nil }
+
+func (d dirBackupSource) HoldSave(processId int, edition string) (*SaveHold, error) {
+	return nil, nil
+}
+
+func TestCreateBackupExcludesBackupDirectory(t *testing.T) {
+	serverDir := t.TempDir()
+	for name, content := range map[string]string{"world/level.dat": "level", "server.properties": "motd=test"} {
+		path := filepath.Join(serverDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 备份整个服务器目录，备份也存放在服务器目录中
+	store := &memoryBackupStore{policy: &entity.BackupPolicy{Paths: []string{"."}, Directory: filepath.Join(serverDir, "backups"), SaveMode: BackupSaveOff}}
+	m := NewBackupManager(store, dirBackupSource(serverDir), t.TempDir())
+	if _, err := m.CreateBackup(1, BackupTriggerManual); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := m.CreateBackup(1, BackupTriggerManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.OpenReader(backup.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+	var names []string
+	for _, file := range archive.File {
+		if !strings.HasSuffix(file.Name, "/") {
+			names = append(names, file.Name)
+		}
+	}
+	slices.Sort(names)
+	if want := []string{"server.properties", "world/level.dat"}; !equalStrings(names, want) {
+		t.Errorf("归档中的文件 = %v, want %v", names, want)
+	}
+}
+
+func TestApplyRetention(t *testing.T) {
+	at := func(day, hour int) int64 {
+		return time.Date(2026, time.March, day, hour, 0, 0, 0, time.Local).UnixMilli()
+	}
+	// 按创建时间倒序，2026-03-16 为星期一
+	created := []int64{
+		at(16, 18), // 1: 第 12 周
+		at(16, 9),  // 2
+		at(15, 20), // 3: 星期日，第 11 周
+		at(14, 10), // 4
+		at(9, 10),  // 5: 第 11 周的星期一
+		at(8, 10),  // 6: 第 10 周
+		at(1, 10),  // 7: 第 9 周
+	}
+	tests := []struct {
+		name   string
+		policy entity.BackupPolicy
+		want   []int
+	}{
+		{"keep nothing configured", entity.BackupPolicy{}, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"keep last", entity.BackupPolicy{KeepLast: 2}, []int{1, 2}},
+		{"keep daily", entity.BackupPolicy{KeepDaily: 3}, []int{1, 3, 4}},
+		{"keep weekly", entity.BackupPolicy{KeepWeekly: 3}, []int{1, 3, 6}},
+		{"combined", entity.BackupPolicy{KeepLast: 1, KeepDaily: 2, KeepWeekly: 4}, []int{1, 3, 6, 7}},
+		{"more than available", entity.BackupPolicy{KeepLast: 10}, []int{1, 2, 3, 4, 5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := &memoryBackupStore{}
+			for i, createdAt := range created {
+				backup := entity.Backup{Id: i + 1, ProcessId: 1, FileName: fmt.Sprintf("backup-%d.zip", i+1), CreatedAt: createdAt}
+				backup.Path = filepath.Join(dir, backup.FileName)
+				if err := os.WriteFile(backup.Path, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+				store.backups = append(store.backups, backup)
+			}
+
+			m := NewBackupManager(store, dirBackupSource(dir), dir)
+			if err := m.applyRetention(1, tt.policy); err != nil {
+				t.Fatal(err)
+			}
+			var kept []int
+			for _, backup := range store.backups {
+				kept = append(kept, backup.Id)
+				if _, err := os.Stat(backup.Path); err != nil {
+					t.Errorf("保留的备份 %d 的文件不存在: %v", backup.Id, err)
+				}
+			}
+			if !slices.Equal(kept, tt.want) {
+				t.Errorf("保留的备份 = %v, want %v", kept, tt.want)
+			}
+			if files, _ := os.ReadDir(dir); len(files) != len(tt.want) {
+				t.Errorf("剩余 %d 个备份文件, want %d", len(files), len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package v_manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vprocess "voxesis/src/System/Process"
+)
+
+// PidRecord 进程启动后写入 PID 文件的内容，Voxesis 重启后据此接管仍在运行的进程
+type PidRecord struct {
+	Pid        int32  `json:"pid"`
+	CreateTime int64  `json:"createTime"`          // 进程创建时间 (Unix 毫秒)，用于识别 PID 复用
+	StdinFifo  string `json:"stdinFifo,omitempty"` // 标准输入命名管道，为空表示接管后无法发送命令
+}
+
+// SetPidFile 设置 PID 文件路径，进程启动后写入、退出后删除，为空表示不写入
+// 非 Windows 平台上的普通进程同时使用同目录下的命名管道作为标准输入，被接管后仍可发送命令
+func (pm *ProcessManager) SetPidFile(path string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.pidFile = path
+}
+
+// Attach 接管一个仍在运行、但不是由当前 Voxesis 实例启动的进程，返回其 PID
+// 会话类型的进程重新连接到会话托管进程，并将期间的输出回放到回滚缓冲区
+// 其他类型优先使用启动时写入的 PID 文件，matchPath 为 true 时再按可执行文件路径与工作目录查找
+// 被接管的非会话进程没有输出，logCallback 用于之后由守护器重新启动的进程
+func (pm *ProcessManager) Attach(logCallback func(line entity.OutputLine), matchPath bool) (int32, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.activeProcess != nil && pm.activeProcess.IsRunning() {
+		return 0, fmt.Errorf("进程已在运行")
+	}
+
+	var (
+		proc    IProcess
+		connect func() error
+	)
+	if socket := pm.sessionSocketPath(); socket != "" {
+		if _, err := os.Stat(socket); err != nil {
+			return 0, fmt.Errorf("未找到仍在运行的会话")
+		}
+		created, err := pm.createProcess()
+		if err != nil {
+			return 0, err
+		}
+		session, ok := created.(ISession)
+		if !ok {
+			return 0, fmt.Errorf("%s 类型的进程不支持重新连接", pm.ProcessType)
+		}
+		proc = created
+		connect = func() error { return session.Reconnect(pm.newOutputHandler()) }
+	} else {
+		record, err := pm.findAttachable(matchPath)
+		if err != nil {
+			return 0, err
+		}
+		attached := vprocess.NewAttachedProcess(record.Pid, record.CreateTime, record.StdinFifo)
+		proc = attached
+		connect = func() error { return attached.Start(nil, nil) }
+	}
+	if config, err := pm.resolveLaunchConfig(); err == nil && config.encoder != nil {
+		proc = &encodingProcess{IProcess: proc, encoder: config.encoder}
+	}
+
+	exited := make(chan struct{})
+	proc.SetExitCallback(func(err error) {
+		close(exited)
+		go pm.handleExit(proc, err)
+	})
+	// 回放的输出同样交给 logCallback
+	pm.logCallback = logCallback
+	if err := connect(); err != nil {
+		return 0, fmt.Errorf("接管进程失败: %w", err)
+	}
+
+	pm.cancelRestart()
+	pm.stopRequested = false
+	pm.restartHistory = nil
+	pm.activeProcess = proc
+	pm.exited = exited
+	if pm.sessionSocketPath() == "" {
+		pm.writePidFile(proc)
+	}
+
+	var pid int32
+	if status, err := proc.GetStatus(); err == nil {
+		if parsed, err := strconv.ParseInt(status.Pid, 10, 32); err == nil {
+			pid = int32(parsed)
+		}
+	}
+
+	// 被接管的进程已运行了一段时间，直接视为就绪
+	pm.supervisorState = SupervisorRunning
+	pm.setLifecycleState(entity.LifecycleStarting)
+	pm.setLifecycleState(entity.LifecycleRunning)
+	pm.emitEvent(entity.ProcessEvent{Type: EventAttached})
+	pm.markReady()
+	vlogger.AppLogger.Infof("已接管进程 %s, PID: %d", pm.Path, pid)
+	return pid, nil
+}
+
+// findAttachable 查找可以接管的进程，调用方需持有写锁
+func (pm *ProcessManager) findAttachable(matchPath bool) (PidRecord, error) {
+	if record, err := pm.readPidFile(); err == nil {
+		if created, err := vprocess.ProcessCreateTime(record.Pid); err == nil && created == record.CreateTime {
+			return record, nil
+		}
+		// 进程已退出或 PID 已被复用
+		pm.removePidFile()
+	}
+	if !matchPath {
+		return PidRecord{}, fmt.Errorf("未找到仍在运行的进程")
+	}
+
+	workingDir := pm.serverDir()
+	pid, err := vprocess.FindProcessByPath(pm.Path, workingDir)
+	if err != nil {
+		return PidRecord{}, err
+	}
+	if pid == 0 {
+		return PidRecord{}, fmt.Errorf("未找到可执行文件为 %s、工作目录为 %s 的进程", pm.Path, workingDir)
+	}
+	created, err := vprocess.ProcessCreateTime(pid)
+	if err != nil {
+		return PidRecord{}, err
+	}
+	return PidRecord{Pid: pid, CreateTime: created}, nil
+}
+
+// stdinFifoPath 返回与 PID 文件同名的标准输入命名管道路径，Windows 下不支持，调用方需持有锁
+func (pm *ProcessManager) stdinFifoPath() string {
+	if pm.pidFile == "" || runtime.GOOS == "windows" {
+		return ""
+	}
+	return strings.TrimSuffix(pm.pidFile, filepath.Ext(pm.pidFile)) + ".stdin"
+}
+
+// sessionSocketPath 返回与 PID 文件同名的会话套接字路径，非会话类型的进程返回空，调用方需持有锁
+func (pm *ProcessManager) sessionSocketPath() string {
+	if pm.pidFile == "" || (pm.ProcessType != Detached && pm.ProcessType != DetachedPty) {
+		return ""
+	}
+	return strings.TrimSuffix(pm.pidFile, filepath.Ext(pm.pidFile)) + ".sock"
+}
+
+// writePidFile 在进程启动或被接管后写入 PID 文件，失败时只记录警告，调用方需持有写锁
+func (pm *ProcessManager) writePidFile(proc IProcess) {
+	if pm.pidFile == "" {
+		return
+	}
+
+	err := func() error {
+		status, err := proc.GetStatus()
+		if err != nil {
+			return err
+		}
+		pid, err := strconv.ParseInt(status.Pid, 10, 32)
+		if err != nil {
+			return fmt.Errorf("无效的 PID: %s", status.Pid)
+		}
+		record := PidRecord{Pid: int32(pid)}
+		if record.CreateTime, err = vprocess.ProcessCreateTime(record.Pid); err != nil {
+			return err
+		}
+		if attached, err := pm.readPidFile(); err == nil && attached.Pid == record.Pid {
+			record.StdinFifo = attached.StdinFifo // 接管时沿用原来的命名管道
+		} else if _, ok := unwrapProcess(proc).(IStdinFifo); ok && pm.stdinFifoPath() != "" {
+			// 命名管道创建失败时进程使用普通管道，接管后无法发送命令
+			if info, err := os.Stat(pm.stdinFifoPath()); err == nil && info.Mode()&os.ModeNamedPipe != 0 {
+				record.StdinFifo = pm.stdinFifoPath()
+			}
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(pm.pidFile), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(pm.pidFile, data, 0644)
+	}()
+	if err != nil {
+		vlogger.AppLogger.Warnf("写入进程 %s 的 PID 文件失败，Voxesis 重启后将无法接管该进程: %v", pm.Path, err)
+	}
+}
+
+// readPidFile 读取 PID 文件，调用方需持有锁
+func (pm *ProcessManager) readPidFile() (PidRecord, error) {
+	var record PidRecord
+	if pm.pidFile == "" {
+		return record, fmt.Errorf("未设置 PID 文件")
+	}
+	data, err := os.ReadFile(pm.pidFile)
+	if err != nil {
+		return record, err
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return record, fmt.Errorf("解析 PID 文件 %s 失败: %w", pm.pidFile, err)
+	}
+	return record, nil
+}
+
+// removePidFile 在进程退出后删除 PID 文件与残留的命名管道，调用方需持有写锁
+func (pm *ProcessManager) removePidFile() {
+	if pm.pidFile == "" {
+		return
+	}
+	_ = os.Remove(pm.pidFile)
+	if fifo := pm.stdinFifoPath(); fifo != "" {
+		_ = os.Remove(fifo)
+	}
+}
+
+// unwrapProcess 返回被编码包装的底层进程
+func unwrapProcess(proc IProcess) IProcess {
+	if encoded, ok := proc.(*encodingProcess); ok {
+		return encoded.IProcess
+	}
+	return proc
+}
@@ -0,0 +1,147 @@
+package v_manager
+
+import (
+	"fmt"
+	"time"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// 排队命令的默认有效期
+const defaultCommandTTL = 5 * time.Minute
+
+// QueuedCommand 等待进程就绪后发送的命令
+type QueuedCommand struct {
+	Id        int       `json:"id"`
+	Command   string    `json:"command"`
+	QueuedAt  time.Time `json:"queuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"` // 过期后不再发送
+}
+
+// QueueCommand 向进程发送命令，进程已就绪时立即发送；
+// 进程正在启动、等待就绪检测或等待自动重启时放入队列，就绪后按顺序发送。
+// ttl 为命令在队列中的有效期，不大于 0 时使用默认值。立即发送时返回的命令为 nil。
+func (pm *ProcessManager) QueueCommand(command string, ttl time.Duration) (*QueuedCommand, error) {
+	queued, err := pm.enqueueCommand(command, ttl)
+	if err != nil || queued != nil {
+		return queued, err
+	}
+	return nil, pm.SendCommand(command)
+}
+
+// enqueueCommand 进程未就绪或队列中的命令尚未发送完时将命令放入队列，返回 nil 表示可以立即发送
+func (pm *ProcessManager) enqueueCommand(command string, ttl time.Duration) (*QueuedCommand, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.lifecycle.State == entity.LifecycleRunning && pm.lifecycle.Ready && !pm.flushingQueue {
+		return nil, nil
+	}
+
+	starting := pm.lifecycle.State == entity.LifecycleStarting ||
+		pm.lifecycle.State == entity.LifecycleRunning ||
+		pm.supervisorState == SupervisorBackoff
+	if !starting {
+		return nil, fmt.Errorf("进程未在运行，无法排队发送命令")
+	}
+
+	if ttl <= 0 {
+		ttl = defaultCommandTTL
+	}
+	now := time.Now()
+	pm.pruneCommandQueue(now)
+
+	pm.nextCommandID++
+	queued := QueuedCommand{
+		Id:        pm.nextCommandID,
+		Command:   command,
+		QueuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+	pm.commandQueue = append(pm.commandQueue, queued)
+	return &queued, nil
+}
+
+// ListQueuedCommands 列出队列中尚未过期的命令
+func (pm *ProcessManager) ListQueuedCommands() []QueuedCommand {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.pruneCommandQueue(time.Now())
+	return append([]QueuedCommand{}, pm.commandQueue...)
+}
+
+// CancelQueuedCommand 从队列中移除指定ID的命令
+func (pm *ProcessManager) CancelQueuedCommand(id int) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	for i, queued := range pm.commandQueue {
+		if queued.Id == id {
+			pm.commandQueue = append(pm.commandQueue[:i], pm.commandQueue[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("ID为 %d 的排队命令未找到", id)
+}
+
+// ClearCommandQueue 清空命令队列
+func (pm *ProcessManager) ClearCommandQueue() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.commandQueue = nil
+}
+
+// flushCommandQueue 进程就绪后在后台按顺序发送队列中的命令，调用方需持有写锁
+// SendCommand 可能需要等待 ExecuteCommand 收集完响应，不能在持有锁时发送
+func (pm *ProcessManager) flushCommandQueue() {
+	if pm.flushingQueue || len(pm.commandQueue) == 0 {
+		return
+	}
+	pm.flushingQueue = true
+	go pm.drainCommandQueue()
+}
+
+// drainCommandQueue 逐条取出并发送队列中的命令，队列为空或进程不再就绪时结束
+// 取出与发送期间持有 execSem，发送最后一条命令前即可结束排队，新命令也不会抢在它前面发送
+func (pm *ProcessManager) drainCommandQueue() {
+	for {
+		pm.acquireExec(nil)
+		pm.mu.Lock()
+		pm.pruneCommandQueue(time.Now())
+		if len(pm.commandQueue) == 0 || !pm.lifecycle.Ready {
+			pm.flushingQueue = false
+			pm.mu.Unlock()
+			pm.releaseExec()
+			return
+		}
+		queued := pm.commandQueue[0]
+		pm.commandQueue = pm.commandQueue[1:]
+		last := len(pm.commandQueue) == 0
+		if last {
+			pm.flushingQueue = false
+		}
+		pm.mu.Unlock()
+
+		err := pm.sendCommand(queued.Command)
+		pm.releaseExec()
+		if err != nil {
+			vlogger.AppLogger.Warnf("向进程 %s 发送排队命令 %q 失败: %v", pm.Path, queued.Command, err)
+		}
+		if last {
+			return
+		}
+	}
+}
+
+// pruneCommandQueue 移除已过期的命令，调用方需持有写锁
+func (pm *ProcessManager) pruneCommandQueue(now time.Time) {
+	kept := pm.commandQueue[:0]
+	for _, queued := range pm.commandQueue {
+		if now.Before(queued.ExpiresAt) {
+			kept = append(kept, queued)
+		}
+	}
+	pm.commandQueue = kept
+}
@@ -0,0 +1,105 @@
+package v_manager
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// serverSaid 返回模拟服务器响应 say 命令输出的内容
+func serverSaid(lines []string) []string {
+	said := []string{}
+	for _, line := range lines {
+		if text, ok := strings.CutPrefix(line, "[Server] "); ok {
+			said = append(said, text)
+		}
+	}
+	return said
+}
+
+func TestQueueCommandNotRunning(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	if queued, err := pm.QueueCommand("say hello", 0); err == nil {
+		t.Errorf("进程未运行时 QueueCommand() = %+v, want error", queued)
+	}
+}
+
+func TestQueueCommandUntilReady(t *testing.T) {
+	pm := newFakeServer(t, "slow")
+	if err := pm.SetReadinessProbe(ReadinessProbe{Type: ProbeRegex, Pattern: `Server started\.`}); err != nil {
+		t.Fatal(err)
+	}
+	output := recordOutput(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	queue := func(command string, ttl time.Duration) *QueuedCommand {
+		t.Helper()
+		queued, err := pm.QueueCommand(command, ttl)
+		if err != nil || queued == nil {
+			t.Fatalf("QueueCommand(%q) = %+v, %v, want queued", command, queued, err)
+		}
+		return queued
+	}
+	first := queue("say one", 0)
+	cancelled := queue("say cancelled", 0)
+	queue("say expired", time.Millisecond)
+	last := queue("say two", time.Minute)
+
+	if err := pm.CancelQueuedCommand(cancelled.Id); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.CancelQueuedCommand(cancelled.Id); err == nil {
+		t.Error("重复取消同一条命令应返回错误")
+	}
+	time.Sleep(5 * time.Millisecond)
+	queued := pm.ListQueuedCommands()
+	if len(queued) != 2 || queued[0].Id != first.Id || queued[1].Id != last.Id {
+		t.Fatalf("ListQueuedCommands() = %+v, want [%d %d]", queued, first.Id, last.Id)
+	}
+	if !last.ExpiresAt.Equal(last.QueuedAt.Add(time.Minute)) {
+		t.Errorf("命令的过期时间 = %s, want %s", last.ExpiresAt, last.QueuedAt.Add(time.Minute))
+	}
+	if said := serverSaid(output.snapshot()); len(said) != 0 {
+		t.Fatalf("就绪前已发送了命令: %v", said)
+	}
+
+	// 就绪后按排队的顺序发送，跳过已取消与已过期的命令
+	output.waitLine(t, "[Server] two", 5*time.Second)
+	if said := serverSaid(output.snapshot()); !equalStrings(said, []string{"one", "two"}) {
+		t.Errorf("发送的命令 = %v, want [one two]", said)
+	}
+	if queued, ready := pm.ListQueuedCommands(), pm.GetLifecycle().Ready; len(queued) != 0 || !ready {
+		t.Errorf("发送后 Ready = %v，队列中的命令 = %+v", ready, queued)
+	}
+
+	// 已就绪时立即发送
+	if queued, err := pm.QueueCommand("say now", 0); queued != nil || err != nil {
+		t.Fatalf("就绪后 QueueCommand() = %+v, %v, want nil, nil", queued, err)
+	}
+	output.waitLine(t, "[Server] now", 5*time.Second)
+}
+
+func TestStopClearsCommandQueue(t *testing.T) {
+	pm := newFakeServer(t, "slow")
+	if err := pm.SetReadinessProbe(ReadinessProbe{Type: ProbeRegex, Pattern: `Server started\.`}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pm.QueueCommand("say dropped", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pm.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if queued := pm.ListQueuedCommands(); len(queued) != 0 {
+		t.Errorf("停止后队列中仍有命令: %+v", queued)
+	}
+	if _, err := pm.QueueCommand("say late", 0); err == nil {
+		t.Error("停止后排队命令应返回错误")
+	}
+}
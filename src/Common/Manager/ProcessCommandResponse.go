@@ -0,0 +1,151 @@
+package v_manager
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 命令响应收集的默认值
+const (
+	defaultCommandQuiet   = 500 * time.Millisecond
+	defaultCommandTimeout = 10 * time.Second
+)
+
+// CommandRequest 需要收集响应的控制台命令
+type CommandRequest struct {
+	Command    string `json:"command"`
+	Terminator string `json:"terminator"` // 可选，匹配响应结束行的正则，匹配的行包含在响应中
+	QuietMs    int    `json:"quietMs"`    // 超过该时长没有新输出即认为响应结束
+	TimeoutMs  int    `json:"timeoutMs"`  // 整个请求的超时，包括等待其他命令完成的时间
+}
+
+// CommandResponse 命令之后收集到的输出
+type CommandResponse struct {
+	Lines      []string `json:"lines"`
+	Terminated bool     `json:"terminated"` // 是否因匹配到 Terminator 而结束
+	TimedOut   bool     `json:"timedOut"`   // 是否因超时而结束
+	DurationMs int64    `json:"durationMs"`
+}
+
+// normalize 校验请求并为未设置的字段填充默认值
+func (r CommandRequest) normalize() (CommandRequest, *regexp.Regexp, error) {
+	if strings.TrimSpace(r.Command) == "" {
+		return r, nil, fmt.Errorf("命令不能为空")
+	}
+
+	var terminator *regexp.Regexp
+	if r.Terminator != "" {
+		var err error
+		if terminator, err = regexp.Compile(r.Terminator); err != nil {
+			return r, nil, fmt.Errorf("无效的 terminator 正则: %w", err)
+		}
+	}
+	if r.QuietMs <= 0 {
+		r.QuietMs = int(defaultCommandQuiet / time.Millisecond)
+	}
+	if r.TimeoutMs <= 0 {
+		r.TimeoutMs = int(defaultCommandTimeout / time.Millisecond)
+	}
+	return r, terminator, nil
+}
+
+// ExecuteCommand 发送命令并收集其后的输出，直到匹配 Terminator、静默 QuietMs 或超时。
+// 同一进程上的请求依次执行；收集期间 SendCommand 发送的命令 (包括排队命令、规则、计划任务与重启广播)
+// 会等待请求结束，输出不会混杂。停止流程的停止命令不等待，可能出现在响应中。
+// 终端回显的命令本身不会出现在响应中。
+func (pm *ProcessManager) ExecuteCommand(request CommandRequest) (CommandResponse, error) {
+	request, terminator, err := request.normalize()
+	if err != nil {
+		return CommandResponse{}, err
+	}
+
+	begin := time.Now()
+	timeout := time.NewTimer(time.Duration(request.TimeoutMs) * time.Millisecond)
+	defer timeout.Stop()
+
+	// 等待同一进程上的其他命令完成
+	if !pm.acquireExec(timeout.C) {
+		return CommandResponse{TimedOut: true, DurationMs: time.Since(begin).Milliseconds()},
+			fmt.Errorf("等待其他命令完成超时")
+	}
+	defer pm.releaseExec()
+
+	// 输出回调不能阻塞，先缓存再由当前 goroutine 处理
+	var (
+		mu      sync.Mutex
+		pending []string
+		notify  = make(chan struct{}, 1)
+	)
+	cancel := pm.WatchOutput(func(line string) {
+		mu.Lock()
+		pending = append(pending, line)
+		mu.Unlock()
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	})
+	defer cancel()
+
+	if err := pm.sendCommand(request.Command); err != nil {
+		return CommandResponse{DurationMs: time.Since(begin).Milliseconds()}, err
+	}
+
+	response := CommandResponse{Lines: []string{}}
+	quietPeriod := time.Duration(request.QuietMs) * time.Millisecond
+	quiet := time.NewTimer(quietPeriod)
+	defer quiet.Stop()
+	echo := strings.TrimSpace(request.Command)
+	first := true
+
+	for {
+		select {
+		case <-notify:
+			mu.Lock()
+			lines := pending
+			pending = nil
+			mu.Unlock()
+
+			for _, line := range lines {
+				// 伪终端会把输入的命令回显为第一行
+				if first && strings.TrimSpace(line) == echo {
+					first = false
+					continue
+				}
+				first = false
+				response.Lines = append(response.Lines, line)
+				if terminator != nil && terminator.MatchString(line) {
+					response.Terminated = true
+					response.DurationMs = time.Since(begin).Milliseconds()
+					return response, nil
+				}
+			}
+			quiet.Reset(quietPeriod)
+		case <-quiet.C:
+			response.DurationMs = time.Since(begin).Milliseconds()
+			return response, nil
+		case <-timeout.C:
+			response.TimedOut = true
+			response.DurationMs = time.Since(begin).Milliseconds()
+			return response, nil
+		}
+	}
+}
+
+// acquireExec 获取发送命令的信号量，timeout 先触发时返回 false，为 nil 时一直等待
+func (pm *ProcessManager) acquireExec(timeout <-chan time.Time) bool {
+	select {
+	case pm.execSem <- struct{}{}:
+		return true
+	case <-timeout:
+		return false
+	}
+}
+
+// releaseExec 释放发送命令的信号量
+func (pm *ProcessManager) releaseExec() {
+	<-pm.execSem
+}
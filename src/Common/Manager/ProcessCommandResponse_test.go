@@ -0,0 +1,153 @@
+package v_manager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// responseText 返回响应中去掉前缀后的输出行
+func responseText(response CommandResponse) []string {
+	lines := []string{}
+	for _, line := range response.Lines {
+		lines = append(lines, outputText(line))
+	}
+	return lines
+}
+
+func TestCommandRequestNormalize(t *testing.T) {
+	if _, _, err := (CommandRequest{Command: "  "}).normalize(); err == nil {
+		t.Error("空命令应返回错误")
+	}
+	if _, _, err := (CommandRequest{Command: "list", Terminator: "("}).normalize(); err == nil {
+		t.Error("无效的 terminator 应返回错误")
+	}
+
+	request, terminator, err := CommandRequest{Command: "list"}.normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if terminator != nil || request.QuietMs != 500 || request.TimeoutMs != 10000 {
+		t.Errorf("默认请求 = %+v, terminator = %v", request, terminator)
+	}
+}
+
+func TestExecuteCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        CommandRequest
+		wantLines      []string
+		wantTerminated bool
+		wantTimedOut   bool
+	}{
+		{"terminator", CommandRequest{Command: "lines 3 a", Terminator: `a done`}, []string{"a 1", "a 2", "a 3", "a done"}, true, false},
+		{"quiet period", CommandRequest{Command: "say hi", QuietMs: 200}, []string{"[Server] hi"}, false, false},
+		{"timeout", CommandRequest{Command: "lines 50 x", Terminator: `never printed`, TimeoutMs: 200}, nil, false, true},
+	}
+
+	pm := newFakeServer(t, "server")
+	output := recordOutput(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	output.waitLine(t, "Server started.", 5*time.Second)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := pm.ExecuteCommand(tt.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Terminated != tt.wantTerminated || response.TimedOut != tt.wantTimedOut {
+				t.Errorf("ExecuteCommand() = %+v, want terminated %v timed out %v", response, tt.wantTerminated, tt.wantTimedOut)
+			}
+			if tt.wantLines != nil && !equalStrings(responseText(response), tt.wantLines) {
+				t.Errorf("响应 = %q, want %q", responseText(response), tt.wantLines)
+			}
+		})
+	}
+	// 超时的命令仍在输出，等它结束再停止进程
+	output.waitLine(t, "x done", 5*time.Second)
+}
+
+func TestExecuteCommandNotRunning(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	if response, err := pm.ExecuteCommand(CommandRequest{Command: "list"}); err == nil {
+		t.Errorf("进程未运行时 ExecuteCommand() = %+v, want error", response)
+	}
+}
+
+func TestExecuteCommandIsolation(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	output := recordOutput(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	output.waitLine(t, "Server started.", 5*time.Second)
+
+	result := make(chan CommandResponse, 1)
+	go func() {
+		response, err := pm.ExecuteCommand(CommandRequest{Command: "lines 5 a", Terminator: `a done`})
+		if err != nil {
+			t.Error(err)
+		}
+		result <- response
+	}()
+
+	// 收集响应期间发送的命令要等响应结束后才发送
+	output.waitLine(t, "a 1", 5*time.Second)
+	if err := pm.SendCommand("say interloper"); err != nil {
+		t.Fatal(err)
+	}
+	response := <-result
+	if want := []string{"a 1", "a 2", "a 3", "a 4", "a 5", "a done"}; !equalStrings(responseText(response), want) {
+		t.Errorf("响应 = %q, want %q", responseText(response), want)
+	}
+
+	output.waitLine(t, "[Server] interloper", 5*time.Second)
+	lines := output.snapshot()
+	if done, said := slices.Index(lines, "a done"), slices.Index(lines, "[Server] interloper"); said < done {
+		t.Errorf("SendCommand 的输出出现在响应结束之前: %q", lines)
+	}
+}
+
+func TestExecuteCommandConcurrent(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	output := recordOutput(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	output.waitLine(t, "Server started.", 5*time.Second)
+
+	labels := []string{"a", "b", "c"}
+	responses := make([]CommandResponse, len(labels))
+	var wg sync.WaitGroup
+	for i, label := range labels {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := pm.ExecuteCommand(CommandRequest{Command: fmt.Sprintf("lines 3 %s", label), Terminator: label + ` done`})
+			if err != nil {
+				t.Error(err)
+			}
+			responses[i] = response
+		}()
+	}
+	wg.Wait()
+
+	// 每个请求只收到自己命令的输出
+	for i, label := range labels {
+		lines := responseText(responses[i])
+		if len(lines) != 4 || !responses[i].Terminated {
+			t.Errorf("%s 的响应 = %+v", label, responses[i])
+		}
+		for _, line := range lines {
+			if !strings.HasPrefix(line, label+" ") {
+				t.Errorf("%s 的响应中混入了 %q", label, line)
+			}
+		}
+	}
+}
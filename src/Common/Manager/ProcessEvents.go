@@ -0,0 +1,82 @@
+package v_manager
+
+import (
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+// 进程生命周期事件类型
+const (
+	EventStarted    = "started"    // 进程启动成功
+	EventAttached   = "attached"   // 接管了一个仍在运行的进程
+	EventStopped    = "stopped"    // 进程被手动停止
+	EventExited     = "exited"     // 进程自行退出或崩溃
+	EventRestarting = "restarting" // 守护器将在退避后重启进程
+	EventCrashLoop  = "crash-loop" // 重启次数过多，守护器已放弃
+
+	EventStateChanged = "state-changed" // 生命周期状态发生变化
+	EventReady        = "ready"         // 进程已通过就绪检测
+	EventReadyTimeout = "ready-timeout" // 就绪检测超时未通过
+)
+
+// WatchEvents 注册一个生命周期事件监听器，返回用于取消监听的函数
+// 监听器在独立的分发协程中按事件发生的顺序调用，可以调用管理器的方法，但应尽快返回以免后续事件积压
+func (pm *ProcessManager) WatchEvents(listener func(event entity.ProcessEvent)) (cancel func()) {
+	pm.listenersMu.Lock()
+	defer pm.listenersMu.Unlock()
+
+	id := pm.nextListenerID
+	pm.nextListenerID++
+	pm.listeners[id] = listener
+
+	return func() {
+		pm.listenersMu.Lock()
+		defer pm.listenersMu.Unlock()
+		delete(pm.listeners, id)
+	}
+}
+
+// emitEvent 将事件放入队列，由分发协程交给所有监听器
+// 调用方常持有管理器锁，监听器不在调用方的协程中执行，以免回调管理器时死锁
+func (pm *ProcessManager) emitEvent(event entity.ProcessEvent) {
+	event.Time = time.Now().UnixMilli()
+
+	pm.eventsMu.Lock()
+	defer pm.eventsMu.Unlock()
+
+	pm.pendingEvents = append(pm.pendingEvents, event)
+	if !pm.dispatching {
+		pm.dispatching = true
+		go pm.dispatchEvents()
+	}
+}
+
+// dispatchEvents 依次分发队列中的事件，队列为空时退出
+func (pm *ProcessManager) dispatchEvents() {
+	for {
+		pm.eventsMu.Lock()
+		events := pm.pendingEvents
+		pm.pendingEvents = nil
+		if len(events) == 0 {
+			pm.dispatching = false
+			pm.eventsMu.Unlock()
+			return
+		}
+		pm.eventsMu.Unlock()
+
+		// 复制监听器后再调用，监听器中可以取消监听
+		pm.listenersMu.RLock()
+		listeners := make([]func(event entity.ProcessEvent), 0, len(pm.listeners))
+		for _, listener := range pm.listeners {
+			listeners = append(listeners, listener)
+		}
+		pm.listenersMu.RUnlock()
+
+		for _, event := range events {
+			for _, listener := range listeners {
+				listener(event)
+			}
+		}
+	}
+}
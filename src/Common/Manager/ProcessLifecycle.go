@@ -0,0 +1,77 @@
+package v_manager
+
+import (
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+// GetLifecycle 获取进程的生命周期记录，跨越多次启动与自动重启
+func (pm *ProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.lifecycle
+}
+
+// setLifecycleState 切换生命周期状态并广播 state-changed 事件，调用方需持有写锁
+func (pm *ProcessManager) setLifecycleState(state entity.LifecycleState) {
+	if pm.lifecycle.State == state {
+		return
+	}
+
+	now := time.Now()
+	switch state {
+	case entity.LifecycleStarting:
+		pm.lifecycle.ExitCode = nil
+		pm.lifecycle.Signal = ""
+		pm.lifecycle.StoppedAt = nil
+	case entity.LifecycleRunning:
+		// 停止失败回到运行状态时保留原来的启动时间
+		if pm.lifecycle.State == entity.LifecycleStarting {
+			pm.lifecycle.StartedAt = &now
+		}
+	case entity.LifecycleStopped, entity.LifecycleCrashed:
+		if pm.lifecycle.StoppedAt == nil {
+			pm.lifecycle.StoppedAt = &now
+		}
+	}
+	pm.lifecycle.State = state
+	if state != entity.LifecycleRunning {
+		pm.cancelReadinessCheck()
+	}
+
+	lifecycle := pm.lifecycle
+	pm.emitEvent(entity.ProcessEvent{Type: EventStateChanged, Lifecycle: &lifecycle})
+}
+
+// startFailed 记录启动失败，调用方需持有写锁
+func (pm *ProcessManager) startFailed(err error) {
+	pm.lifecycle.LastError = err.Error()
+	pm.setLifecycleState(entity.LifecycleCrashed)
+}
+
+// recordExit 根据底层进程记录的退出码与信号更新生命周期，调用方需持有写锁
+// 手动停止与异常退出两条路径都会调用，只有第一次调用生效
+func (pm *ProcessManager) recordExit(proc IProcess, exitErr error) {
+	switch pm.lifecycle.State {
+	case entity.LifecycleStarting, entity.LifecycleRunning, entity.LifecycleStopping:
+	default:
+		return
+	}
+
+	pm.removePidFile()
+
+	info := proc.GetLifecycle()
+	pm.lifecycle.ExitCode = info.ExitCode
+	pm.lifecycle.Signal = info.Signal
+	pm.lifecycle.StoppedAt = info.StoppedAt
+	if exitErr != nil {
+		pm.lifecycle.LastError = exitErr.Error()
+	}
+
+	if exitErr != nil && !pm.stopRequested {
+		pm.setLifecycleState(entity.LifecycleCrashed)
+	} else {
+		pm.setLifecycleState(entity.LifecycleStopped)
+	}
+}
@@ -0,0 +1,55 @@
+package v_manager
+
+import (
+	"fmt"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// normalizeLimits 校验资源限制，全部为 0 时返回 nil 表示不限制
+func normalizeLimits(limits entity.ResourceLimits) (*entity.ResourceLimits, error) {
+	if limits.MemoryMaxMB < 0 || limits.CpuPercent < 0 || limits.PidsMax < 0 {
+		return nil, fmt.Errorf("资源限制不能为负数")
+	}
+	if limits == (entity.ResourceLimits{}) {
+		return nil, nil
+	}
+	return &limits, nil
+}
+
+// SetResourceLimits 设置进程的资源限制，正在运行的进程在下次启动时生效
+// 仅 Linux 上的普通进程支持，全部为 0 表示不限制
+func (pm *ProcessManager) SetResourceLimits(limits entity.ResourceLimits) error {
+	normalized, err := normalizeLimits(limits)
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.limits = normalized
+	return nil
+}
+
+// GetResourceLimits 获取进程的资源限制
+func (pm *ProcessManager) GetResourceLimits() entity.ResourceLimits {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if pm.limits == nil {
+		return entity.ResourceLimits{}
+	}
+	return *pm.limits
+}
+
+// applyResourceLimits 将资源限制传给新创建的进程实例，调用方需持有锁
+func (pm *ProcessManager) applyResourceLimits(proc IProcess) {
+	limitable, ok := proc.(ILimitable)
+	if !ok {
+		if pm.limits != nil {
+			vlogger.AppLogger.Warnf("进程 %s: %s 类型的进程不支持资源限制，将不受限制地运行", pm.Path, pm.ProcessType)
+		}
+		return
+	}
+	limitable.SetResourceLimits(pm.limits)
+}
@@ -2,10 +2,14 @@ package v_manager
 
 import (
 	"fmt"
+	"runtime"
+	"strings"
 	"sync"
+	"time"
 
 	"voxesis/src/Common/Entity"
 	vlogger "voxesis/src/Common/Logger"
+	vutils "voxesis/src/Common/Utils"
 	vprocess "voxesis/src/System/Process"
 )
 
@@ -15,6 +19,9 @@ type ProcessType int
 const (
 	ConPty ProcessType = iota
 	Ordinary
+	UnixPty
+	Detached    // 在独立的会话托管进程中以普通进程运行，Voxesis 重启后可重新连接
+	DetachedPty // 在独立的会话托管进程中以伪终端运行，Voxesis 重启后可重新连接
 )
 
 // String 方法让 ProcessType 在日志中更具可读性
@@ -24,11 +31,30 @@ func (pt ProcessType) String() string {
 		return "ConPty"
 	case Ordinary:
 		return "Ordinary"
+	case UnixPty:
+		return "UnixPty"
+	case Detached:
+		return "Detached"
+	case DetachedPty:
+		return "DetachedPty"
 	default:
 		return "Unknown"
 	}
 }
 
+// ResolveProcessType 将终端类进程类型映射为当前平台可用的实现
+// Windows 使用 ConPTY，其他平台使用 POSIX 伪终端
+func ResolveProcessType(pt ProcessType) ProcessType {
+	switch {
+	case pt == ConPty && runtime.GOOS != "windows":
+		return UnixPty
+	case pt == UnixPty && runtime.GOOS == "windows":
+		return ConPty
+	default:
+		return pt
+	}
+}
+
 // IProcess 统一进程接口
 type IProcess interface {
 	Start(logCallback func(string), args []string) error
@@ -36,6 +62,31 @@ type IProcess interface {
 	SendCommand(command string) error
 	IsRunning() bool
 	GetStatus() (entity.ProcessState, error)
+	SetExitCallback(callback func(err error))
+	SetStopTimeout(timeout time.Duration)
+	SetWorkingDir(dir string)
+	SetEnv(env []string)
+	GetLifecycle() entity.ProcessLifecycle
+}
+
+// IResizable 由支持调整终端尺寸的进程实现
+type IResizable interface {
+	Resize(cols, rows uint16) error
+}
+
+// ILimitable 由支持资源限制的进程实现
+type ILimitable interface {
+	SetResourceLimits(limits *entity.ResourceLimits)
+}
+
+// ISession 由运行在会话托管进程中、可以重新连接的进程实现
+type ISession interface {
+	Reconnect(logCallback func(string)) error
+}
+
+// IStdinFifo 由支持使用命名管道作为标准输入的进程实现
+type IStdinFifo interface {
+	SetStdinFifo(path string)
 }
 
 // ProcessManager 统一进程管理器
@@ -43,20 +94,210 @@ type ProcessManager struct {
 	ProcessType ProcessType
 	Path        string
 	args        []string
+	workingDir  string            // 为空时使用可执行文件所在目录
+	env         map[string]string // 覆盖或追加到系统环境变量
+	profiles    []LaunchProfile
+	profile     string                 // 启动时使用的启动配置，为空表示直接使用进程定义
+	limits      *entity.ResourceLimits // 为 nil 时不限制
+	pidFile     string                 // 进程启动后写入的 PID 文件，为空表示不写入
 
 	activeProcess IProcess
+	lifecycle     entity.ProcessLifecycle
+	logCallback   func(line entity.OutputLine)
+	exited        chan struct{} // 当前进程退出后关闭
+	stopSequence  StopSequence
+	stopping      bool
+
+	// 带倒计时广播的计划重启
+	restartNotice  RestartNotice
+	plannedRestart *plannedRestart // 正在进行的计划重启
+
+	// 就绪检测
+	readinessProbe ReadinessProbe
+	readiness      *readinessCheck // 正在进行的就绪检测
+
+	// 等待进程就绪后发送的命令
+	commandQueue  []QueuedCommand
+	nextCommandID int
+	flushingQueue bool          // 是否正在后台发送队列中的命令，期间新命令继续排队以保持顺序
+	execSem       chan struct{} // 发送命令前获取，保证 ExecuteCommand 收集输出期间不会发送其他命令
 	mu            sync.RWMutex
+
+	// 输出监听器，使用独立的锁以免阻塞输出
+	watchers      map[int]func(line string)
+	nextWatcherID int
+	watchersMu    sync.RWMutex
+
+	// 生命周期事件监听器
+	listeners      map[int]func(event entity.ProcessEvent)
+	nextListenerID int
+	listenersMu    sync.RWMutex
+
+	// 待分发的事件，由分发协程在管理器锁之外按顺序交给监听器
+	pendingEvents []entity.ProcessEvent
+	dispatching   bool // 分发协程是否在运行
+	eventsMu      sync.Mutex
+
+	// 控制台回滚缓冲区，进程重启后保留，序号连续
+	scrollback       *vutils.ScrollbackBuffer
+	scrollbackConfig ScrollbackConfig
+	outputMu         sync.Mutex // 保证分配序号与分发输出的顺序一致
+
+	// 守护器相关字段
+	restartPolicy   RestartPolicy
+	supervisorState SupervisorState
+	stopRequested   bool        // 是否为手动停止，手动停止不会触发自动重启
+	restartHistory  []time.Time // 时间窗口内的重启时间
+	restartTimer    *time.Timer
+	nextRestartAt   *time.Time
+	lastExitError   string
+}
+
+// ProcessOptions 创建进程时的可选配置，未设置的字段保持默认值
+type ProcessOptions struct {
+	RestartPolicy *RestartPolicy         `json:"restartPolicy,omitempty"`
+	RestartNotice *RestartNotice         `json:"restartNotice,omitempty"`
+	StopSequence  *StopSequence          `json:"stopSequence,omitempty"`
+	Scrollback    *ScrollbackConfig      `json:"scrollback,omitempty"`
+	Readiness     *ReadinessProbe        `json:"readiness,omitempty"`
+	Profiles      []LaunchProfile        `json:"profiles,omitempty"`
+	Profile       *string                `json:"profile,omitempty"` // 选择的启动配置
+	Limits        *entity.ResourceLimits `json:"limits,omitempty"`
 }
 
 // NewProcessManager 创建并配置一个新的进程管理器
 func NewProcessManager(processType ProcessType, path string, args ...string) *ProcessManager {
+	policy, _ := RestartPolicy{}.normalize()
+	sequence, _ := StopSequence{}.normalize()
+	scrollback, _ := ScrollbackConfig{}.normalize()
+	probe, _ := ReadinessProbe{}.normalize()
+	notice, _ := RestartNotice{}.normalize()
 	return &ProcessManager{
-		ProcessType: processType,
-		Path:        path,
-		args:        args,
+		ProcessType:      processType,
+		Path:             path,
+		args:             args,
+		restartPolicy:    policy,
+		stopSequence:     sequence,
+		restartNotice:    notice,
+		readinessProbe:   probe,
+		execSem:          make(chan struct{}, 1),
+		supervisorState:  SupervisorIdle,
+		lifecycle:        entity.ProcessLifecycle{State: entity.LifecycleCreated},
+		watchers:         make(map[int]func(line string)),
+		listeners:        make(map[int]func(event entity.ProcessEvent)),
+		scrollback:       vutils.NewScrollbackBuffer(scrollback.MaxLines, scrollback.MaxBytes),
+		scrollbackConfig: scrollback,
+	}
+}
+
+// SetLaunchConfig 设置进程的工作目录与环境变量，下次启动时生效
+func (pm *ProcessManager) SetLaunchConfig(workingDir string, env map[string]string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.workingDir = workingDir
+	pm.env = env
+}
+
+// GetOptions 获取进程当前的可选配置
+func (pm *ProcessManager) GetOptions() ProcessOptions {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	policy, sequence, scrollback, probe := pm.restartPolicy, pm.stopSequence, pm.scrollbackConfig, pm.readinessProbe
+	notice := pm.restartNotice
+	notice.Warnings = append([]RestartWarning{}, notice.Warnings...)
+	profile := pm.profile
+	var limits *entity.ResourceLimits
+	if pm.limits != nil {
+		copied := *pm.limits
+		limits = &copied
+	}
+	return ProcessOptions{
+		RestartPolicy: &policy,
+		RestartNotice: &notice,
+		StopSequence:  &sequence,
+		Scrollback:    &scrollback,
+		Readiness:     &probe,
+		Profiles:      append([]LaunchProfile{}, pm.profiles...),
+		Profile:       &profile,
+		Limits:        limits,
 	}
 }
 
+// ApplyOptions 应用可选配置，任一配置无效时不做任何修改
+func (pm *ProcessManager) ApplyOptions(options ProcessOptions) error {
+	var (
+		policy     RestartPolicy
+		notice     RestartNotice
+		sequence   StopSequence
+		scrollback ScrollbackConfig
+		probe      ReadinessProbe
+		err        error
+	)
+	if options.RestartPolicy != nil {
+		if policy, err = options.RestartPolicy.normalize(); err != nil {
+			return err
+		}
+	}
+	if options.RestartNotice != nil {
+		if notice, err = options.RestartNotice.normalize(); err != nil {
+			return err
+		}
+	}
+	if options.StopSequence != nil {
+		if sequence, err = options.StopSequence.normalize(); err != nil {
+			return err
+		}
+	}
+	if options.Scrollback != nil {
+		if scrollback, err = options.Scrollback.normalize(); err != nil {
+			return err
+		}
+	}
+	if options.Readiness != nil {
+		if probe, err = options.Readiness.normalize(); err != nil {
+			return err
+		}
+	}
+	if err = validateProfiles(options.Profiles); err != nil {
+		return err
+	}
+	if options.Limits != nil {
+		if _, err = normalizeLimits(*options.Limits); err != nil {
+			return err
+		}
+	}
+
+	if options.RestartPolicy != nil {
+		_ = pm.SetRestartPolicy(policy)
+	}
+	if options.RestartNotice != nil {
+		_ = pm.SetRestartNotice(notice)
+	}
+	if options.StopSequence != nil {
+		_ = pm.SetStopSequence(sequence)
+	}
+	if options.Scrollback != nil {
+		_ = pm.SetScrollbackConfig(scrollback)
+	}
+	if options.Readiness != nil {
+		_ = pm.SetReadinessProbe(probe)
+	}
+	if options.Profiles != nil {
+		_ = pm.SetProfiles(options.Profiles)
+	}
+	if options.Limits != nil {
+		_ = pm.SetResourceLimits(*options.Limits)
+	}
+	if options.Profile != nil {
+		// 启动配置可能已被删除，此时回退为不使用启动配置
+		if err := pm.SelectProfile(*options.Profile); err != nil {
+			vlogger.AppLogger.Warnf("进程 %s: %v", pm.Path, err)
+			_ = pm.SelectProfile("")
+		}
+	}
+	return nil
+}
+
 // createProcess 使用管理器的配置来创建一个新的 Process 实例
 func (pm *ProcessManager) createProcess() (IProcess, error) {
 	switch pm.ProcessType {
@@ -64,61 +305,183 @@ func (pm *ProcessManager) createProcess() (IProcess, error) {
 		return vprocess.NewConPtyProcess(pm.Path), nil
 	case Ordinary:
 		return vprocess.NewOrdinaryProcess(pm.Path), nil
+	case UnixPty:
+		return vprocess.NewUnixPtyProcess(pm.Path), nil
+	case Detached, DetachedPty:
+		socket := pm.sessionSocketPath()
+		if socket == "" {
+			return nil, fmt.Errorf("%s 类型的进程需要设置 PID 文件", pm.ProcessType)
+		}
+		session := vprocess.NewSessionProcess(pm.Path, socket, pm.ProcessType == DetachedPty)
+		session.SetScrollbackLimits(pm.scrollbackConfig.MaxLines, pm.scrollbackConfig.MaxBytes)
+		return session, nil
 	default:
 		return nil, fmt.Errorf("不支持的进程类型: %s", pm.ProcessType)
 	}
 }
 
-// Start 创建并启动由该管理器配置的进程
-func (pm *ProcessManager) Start(logCallback func(log string)) error {
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
-
-	// 如果当前有进程正在运行，先停止它
-	if pm.activeProcess != nil && pm.activeProcess.IsRunning() {
+// Start 创建并启动由该管理器配置的进程，每一行输出都会写入回滚缓冲区并附带序号交给 logCallback
+func (pm *ProcessManager) Start(logCallback func(line entity.OutputLine)) error {
+	// 如果当前有进程正在运行，先按停止流程停止它
+	if pm.IsRunning() {
 		vlogger.AppLogger.Infof("进程已在运行，将先停止它...")
-		if err := pm.activeProcess.Stop(); err != nil {
+		if _, err := pm.Stop(); err != nil {
 			vlogger.AppLogger.Errorf("无法停止现有进程: %v", err)
 			return fmt.Errorf("无法停止现有进程: %w", err)
 		}
 	}
 
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	// 停止期间进程可能已被其他调用重新启动
+	if pm.activeProcess != nil && pm.activeProcess.IsRunning() {
+		return fmt.Errorf("进程已在运行")
+	}
+
+	pm.cancelRestart()
+	pm.stopRequested = false
+	pm.restartHistory = nil
+	pm.logCallback = logCallback
+
+	return pm.startLocked()
+}
+
+// startLocked 创建并启动一个新的进程实例，调用方需持有写锁
+func (pm *ProcessManager) startLocked() error {
 	// 创建新的进程实例
 	vlogger.AppLogger.Infof("正在创建新进程, 类型: %s, 路径: %s", pm.ProcessType, pm.Path)
+	pm.setLifecycleState(entity.LifecycleStarting)
+	config, err := pm.resolveLaunchConfig()
+	if err != nil {
+		pm.startFailed(err)
+		return err
+	}
 	proc, err := pm.createProcess()
 	if err != nil {
-		return fmt.Errorf("创建进程实例失败: %w", err)
+		err = fmt.Errorf("创建进程实例失败: %w", err)
+		pm.startFailed(err)
+		return err
+	}
+	proc.SetWorkingDir(config.workingDir)
+	if config.env != nil {
+		proc.SetEnv(config.env)
+	}
+	pm.applyResourceLimits(proc)
+	if fifo, ok := proc.(IStdinFifo); ok {
+		fifo.SetStdinFifo(pm.stdinFifoPath())
+	}
+	if config.encoder != nil {
+		proc = &encodingProcess{IProcess: proc, encoder: config.encoder}
 	}
 	pm.activeProcess = proc
 
+	// 进程退出后交给守护器处理，异步执行以免与 Stop 持有的锁冲突
+	exited := make(chan struct{})
+	pm.exited = exited
+	proc.SetExitCallback(func(err error) {
+		close(exited)
+		go pm.handleExit(proc, err)
+	})
+
 	// 启动进程
 	vlogger.AppLogger.Info("正在启动进程...")
-	if err := pm.activeProcess.Start(logCallback, pm.args); err != nil {
+	check := pm.newReadinessCheck()
+	if err := pm.activeProcess.Start(pm.newOutputHandler(), config.args); err != nil {
 		vlogger.AppLogger.Errorf("启动进程失败: %v", err)
+		check.cancel()
 		pm.activeProcess = nil // 如果启动失败，清除实例引用
-		return fmt.Errorf("启动进程失败: %w", err)
+		err = fmt.Errorf("启动进程失败: %w", err)
+		pm.startFailed(err)
+		return err
 	}
 
+	pm.writePidFile(proc)
+	pm.supervisorState = SupervisorRunning
+	pm.setLifecycleState(entity.LifecycleRunning)
+	pm.emitEvent(entity.ProcessEvent{Type: EventStarted})
+	pm.startReadinessCheck(check)
 	vlogger.AppLogger.Info("进程启动成功。")
 	return nil
 }
 
-// Stop 停止当前正在运行的进程
-func (pm *ProcessManager) Stop() error {
+// newOutputHandler 返回处理进程输出的回调，调用方需持有锁
+// 输出先写入回滚缓冲区分配序号，再交给调用方并分发给所有监听器
+func (pm *ProcessManager) newOutputHandler() func(data string) {
+	logCallback, scrollback := pm.logCallback, pm.scrollback
+	return func(data string) {
+		pm.outputMu.Lock()
+		line := scrollback.Append(data)
+		if logCallback != nil {
+			logCallback(line)
+		}
+		pm.outputMu.Unlock()
+		pm.notifyWatchers(data)
+	}
+}
+
+// Stop 按优雅停止流程停止当前正在运行的进程，并返回进程在哪个阶段退出
+func (pm *ProcessManager) Stop() (StopResult, error) {
 	pm.mu.Lock()
-	defer pm.mu.Unlock()
+
+	// 手动停止时取消待执行的自动重启与正在倒计时的计划重启，并丢弃尚未发送的排队命令
+	pm.stopRequested = true
+	pm.cancelRestart()
+	pm.commandQueue = nil
+	if pm.plannedRestart != nil && pm.plannedRestart.progress.Phase == RestartPhaseCountdown {
+		_ = pm.abortRestartLocked()
+	}
+	if pm.supervisorState == SupervisorBackoff || pm.supervisorState == SupervisorCrashLoop {
+		pm.supervisorState = SupervisorIdle
+	}
 
 	if pm.activeProcess == nil || !pm.activeProcess.IsRunning() {
-		return nil // 没有正在运行的进程
+		pm.mu.Unlock()
+		return StopResult{Stage: StopStageNone}, nil // 没有正在运行的进程
 	}
+	if pm.stopping {
+		pm.mu.Unlock()
+		return StopResult{}, fmt.Errorf("进程正在停止中")
+	}
+
+	// 停止流程可能持续较久，期间释放锁以免阻塞状态查询
+	pm.stopping = true
+	wasReady := pm.lifecycle.Ready
+	pm.setLifecycleState(entity.LifecycleStopping)
+	proc, exited, sequence := pm.activeProcess, pm.exited, pm.stopSequence
+	pm.mu.Unlock()
+
+	defer func() {
+		pm.mu.Lock()
+		pm.stopping = false
+		// 停止失败，进程仍在运行
+		if pm.lifecycle.State == entity.LifecycleStopping && proc.IsRunning() {
+			pm.setLifecycleState(entity.LifecycleRunning)
+			if wasReady {
+				pm.markReady()
+			}
+		}
+		pm.mu.Unlock()
+	}()
 
 	vlogger.AppLogger.Info("正在停止进程...")
-	if err := pm.activeProcess.Stop(); err != nil {
+	result, err := pm.runStopSequence(proc, exited, sequence)
+	if err != nil {
 		vlogger.AppLogger.Errorf("无法停止进程: %v", err)
-		return fmt.Errorf("无法停止进程: %w", err)
+		return result, fmt.Errorf("无法停止进程: %w", err)
 	}
 
-	return nil
+	// 进程已退出，不必等待守护器异步处理即可更新生命周期与守护器状态
+	pm.mu.Lock()
+	if proc == pm.activeProcess {
+		pm.recordExit(proc, nil)
+		pm.supervisorState = SupervisorIdle
+	}
+	pm.mu.Unlock()
+
+	pm.emitEvent(entity.ProcessEvent{Type: EventStopped, Stage: string(result.Stage)})
+	vlogger.AppLogger.Infof("进程已停止, 阶段: %s, 耗时: %d 毫秒", result.Stage, result.DurationMs)
+	return result, nil
 }
 
 // IsRunning 检查由管理器控制的进程当前是否正在运行
@@ -140,11 +503,25 @@ func (pm *ProcessManager) GetStatus() (entity.ProcessState, error) {
 	if pm.activeProcess == nil || !pm.activeProcess.IsRunning() {
 		return entity.ProcessState{}, fmt.Errorf("进程未在运行")
 	}
-	return pm.activeProcess.GetStatus()
+	status, err := pm.activeProcess.GetStatus()
+	status.Ready = pm.lifecycle.Ready
+	return status, err
 }
 
 // SendCommand 向当前运行的进程发送一个命令
+// ExecuteCommand 正在收集响应时等待其结束再发送，以免这条命令的输出被当作它的响应
 func (pm *ProcessManager) SendCommand(command string) error {
+	timeout := time.NewTimer(defaultCommandTimeout)
+	defer timeout.Stop()
+	if !pm.acquireExec(timeout.C) {
+		return fmt.Errorf("等待其他命令完成超时")
+	}
+	defer pm.releaseExec()
+	return pm.sendCommand(command)
+}
+
+// sendCommand 直接向当前运行的进程发送命令，调用方需持有 execSem
+func (pm *ProcessManager) sendCommand(command string) error {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
 
@@ -153,3 +530,68 @@ func (pm *ProcessManager) SendCommand(command string) error {
 	}
 	return pm.activeProcess.SendCommand(command)
 }
+
+// Resize 调整当前运行进程的终端尺寸
+func (pm *ProcessManager) Resize(cols, rows uint16) error {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if pm.activeProcess == nil || !pm.activeProcess.IsRunning() {
+		return fmt.Errorf("进程未在运行，无法调整终端尺寸")
+	}
+	resizable, ok := pm.activeProcess.(IResizable)
+	if !ok {
+		return fmt.Errorf("进程类型 %s 不支持调整终端尺寸", pm.ProcessType)
+	}
+	return resizable.Resize(cols, rows)
+}
+
+// WatchOutput 注册一个输出监听器，每一行输出都会回调，返回用于取消监听的函数
+func (pm *ProcessManager) WatchOutput(watcher func(line string)) (cancel func()) {
+	pm.watchersMu.Lock()
+	defer pm.watchersMu.Unlock()
+
+	id := pm.nextWatcherID
+	pm.nextWatcherID++
+	pm.watchers[id] = watcher
+
+	return func() {
+		pm.watchersMu.Lock()
+		defer pm.watchersMu.Unlock()
+		delete(pm.watchers, id)
+	}
+}
+
+// notifyWatchers 将一行输出分发给所有监听器
+func (pm *ProcessManager) notifyWatchers(line string) {
+	pm.watchersMu.RLock()
+	defer pm.watchersMu.RUnlock()
+
+	for _, watcher := range pm.watchers {
+		watcher(line)
+	}
+}
+
+// mergeEnv 将覆盖项合并到 "KEY=VALUE" 形式的环境变量列表中，并移除 unset 中的变量
+func mergeEnv(base []string, overrides map[string]string, unset []string) []string {
+	removed := make(map[string]struct{}, len(unset))
+	for _, key := range unset {
+		removed[key] = struct{}{}
+	}
+
+	env := make([]string, 0, len(base)+len(overrides))
+	for _, kv := range base {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := overrides[key]; ok {
+			continue
+		}
+		if _, ok := removed[key]; ok {
+			continue
+		}
+		env = append(env, kv)
+	}
+	for key, value := range overrides {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
@@ -0,0 +1,227 @@
+package v_manager
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// fakeServerEnv 设置后测试程序不运行测试，而是作为模拟的服务器运行，值为 runFakeServer 的 mode
+const fakeServerEnv = "VOXESIS_FAKE_SERVER"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeServerEnv); mode != "" {
+		os.Exit(runFakeServer(mode))
+	}
+
+	dir, err := os.MkdirTemp("", "voxesis-manager-test")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if err := vlogger.InitLogger(dir, "test.log"); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// runFakeServer 模拟服务器的控制台: 启动后输出 Server started.，再逐行读取标准输入并响应命令
+// mode 为 crash 时启动后以退出码 1 退出，为 slow 时 300 毫秒后才输出 Server started.，为 stubborn 时忽略 stop 命令，
+// 为 held 时保存已被其他工具暂停
+func runFakeServer(mode string) int {
+	switch mode {
+	case "crash":
+		fmt.Println("Crashing")
+		// 留出时间让父进程完成启动，避免进程在 Start 返回前就已退出
+		time.Sleep(100 * time.Millisecond)
+		return 1
+	case "slow":
+		fmt.Println("Loading...")
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	fmt.Println("Server started.")
+	held, queried := mode == "held", false
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+		name, arg, _ := strings.Cut(command, " ")
+		switch name {
+		case "stop":
+			if mode == "stubborn" {
+				fmt.Println("Ignoring stop")
+				continue
+			}
+			fmt.Println("Saving...")
+			fmt.Println("Saved the game")
+			// 与真实的服务器一样，保存后过一会儿才退出，输出不会因进程退出而丢失
+			time.Sleep(100 * time.Millisecond)
+			return 0
+		case "say":
+			fmt.Println("[Server] " + arg)
+		case "lines":
+			// lines <n> <name>: 每隔 10 毫秒输出一行，最后输出 <name> done
+			countText, label, _ := strings.Cut(arg, " ")
+			count, _ := strconv.Atoi(countText)
+			for i := 1; i <= count; i++ {
+				fmt.Printf("%s %d\n", label, i)
+				time.Sleep(10 * time.Millisecond)
+			}
+			fmt.Printf("%s done\n", label)
+		case "save":
+			// 基岩版的 save hold / save query / save resume，第一次 query 时尚未保存完成
+			switch arg {
+			case "hold":
+				if held {
+					fmt.Println("The command is already running")
+					continue
+				}
+				held = true
+				fmt.Println("Saving...")
+			case "query":
+				if !queried {
+					queried = true
+					fmt.Println("A previous save has not been completed.")
+					continue
+				}
+				fmt.Println("Data saved. Files are now ready to be copied.")
+				fmt.Println("Bedrock level/db/000005.ldb:1234, Bedrock level/level.dat:2345")
+			case "resume":
+				held = false
+				fmt.Println("Changes to the level are resumed.")
+			}
+		case "exit":
+			code, _ := strconv.Atoi(arg)
+			return code
+		default:
+			fmt.Printf("Unknown command: %s\n", command)
+		}
+	}
+	return 0
+}
+
+// newFakeServer 创建以模拟服务器运行的进程管理器，测试结束时停止进程
+func newFakeServer(t *testing.T, mode string) *ProcessManager {
+	t.Helper()
+	pm := NewProcessManager(Ordinary, os.Args[0])
+	pm.SetLaunchConfig("", map[string]string{fakeServerEnv: mode})
+	_ = pm.SetStopSequence(StopSequence{Command: "stop", GraceTimeoutMs: 2000, TermTimeoutMs: 2000})
+	t.Cleanup(func() { _, _ = pm.Stop() })
+	return pm
+}
+
+// eventRecorder 按顺序记录管理器发出的事件，忽略 state-changed 与 ready 事件
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []entity.ProcessEvent
+	notify chan struct{}
+}
+
+// recordEvents 开始记录管理器发出的事件
+func recordEvents(t *testing.T, pm *ProcessManager) *eventRecorder {
+	r := &eventRecorder{notify: make(chan struct{}, 1)}
+	cancel := pm.WatchEvents(func(event entity.ProcessEvent) {
+		if event.Type == EventStateChanged || event.Type == EventReady {
+			return
+		}
+		r.mu.Lock()
+		r.events = append(r.events, event)
+		r.mu.Unlock()
+		select {
+		case r.notify <- struct{}{}:
+		default:
+		}
+	})
+	t.Cleanup(cancel)
+	return r
+}
+
+// wait 等待出现指定类型的事件并返回它，超时时测试失败
+func (r *eventRecorder) wait(t *testing.T, eventType string, timeout time.Duration) entity.ProcessEvent {
+	t.Helper()
+	deadline := time.After(timeout)
+	for {
+		r.mu.Lock()
+		for _, event := range r.events {
+			if event.Type == eventType {
+				r.mu.Unlock()
+				return event
+			}
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-r.notify:
+		case <-deadline:
+			t.Fatalf("等待 %s 事件超时，已收到: %v", eventType, r.types())
+		}
+	}
+}
+
+// snapshot 返回已记录的事件
+func (r *eventRecorder) snapshot() []entity.ProcessEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]entity.ProcessEvent{}, r.events...)
+}
+
+// types 返回已记录事件的类型
+func (r *eventRecorder) types() []string {
+	var types []string
+	for _, event := range r.snapshot() {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// outputRecorder 记录进程的输出行，去掉 [STDOUT] 等前缀
+type outputRecorder struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+// recordOutput 开始记录进程的输出
+func recordOutput(t *testing.T, pm *ProcessManager) *outputRecorder {
+	r := &outputRecorder{}
+	cancel := pm.WatchOutput(func(line string) {
+		r.mu.Lock()
+		r.lines = append(r.lines, outputText(line))
+		r.mu.Unlock()
+	})
+	t.Cleanup(cancel)
+	return r
+}
+
+// waitLine 等待输出中出现 text，超时时测试失败
+func (r *outputRecorder) waitLine(t *testing.T, text string, timeout time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		found := containsOutput(r.lines, text)
+		r.mu.Unlock()
+		if found {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("等待输出 %q 超时，已收到: %q", text, r.snapshot())
+}
+
+// snapshot 返回已记录的输出
+func (r *outputRecorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.lines...)
+}
@@ -0,0 +1,118 @@
+package v_manager
+
+import (
+	"sync"
+	"time"
+
+	vdata "voxesis/src/Common/Data"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// 指标采集的默认周期
+const (
+	metricsSampleInterval     = 10 * time.Second
+	metricsDownsampleInterval = time.Minute
+)
+
+// ioCounter 上一次采样时进程的累计 IO，用于计算速率
+type ioCounter struct {
+	pid        string
+	time       time.Time
+	readBytes  uint64
+	writeBytes uint64
+}
+
+// MetricsCollector 定期采样所有运行中进程的资源使用情况，写入指标存储并降采样
+type MetricsCollector struct {
+	store   vdata.MetricsStore
+	sources func() map[int]*ProcessManager
+	last    map[int]ioCounter
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// NewMetricsCollector 创建指标采集器
+// sources: 每次采样时调用，返回进程ID到进程管理器的映射
+func NewMetricsCollector(store vdata.MetricsStore, sources func() map[int]*ProcessManager) *MetricsCollector {
+	return &MetricsCollector{
+		store:   store,
+		sources: sources,
+		last:    make(map[int]ioCounter),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start 在后台开始采样
+func (c *MetricsCollector) Start() {
+	go c.run()
+}
+
+// Stop 停止采样，可重复调用
+func (c *MetricsCollector) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+	})
+}
+
+// run 采样与降采样循环
+func (c *MetricsCollector) run() {
+	sampleTicker := time.NewTicker(metricsSampleInterval)
+	defer sampleTicker.Stop()
+	downsampleTicker := time.NewTicker(metricsDownsampleInterval)
+	defer downsampleTicker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-sampleTicker.C:
+			if err := c.store.AddSamples(c.sample(now)); err != nil {
+				vlogger.AppLogger.Errorf("写入进程指标失败: %v", err)
+			}
+		case now := <-downsampleTicker.C:
+			if err := c.store.Downsample(now); err != nil {
+				vlogger.AppLogger.Errorf("%v", err)
+			}
+		}
+	}
+}
+
+// sample 采样所有运行中的进程，未运行的进程不产生数据
+func (c *MetricsCollector) sample(now time.Time) map[int]entity.MetricSample {
+	sources := c.sources()
+	for id := range c.last {
+		if _, ok := sources[id]; !ok {
+			delete(c.last, id)
+		}
+	}
+
+	samples := make(map[int]entity.MetricSample)
+	for id, manager := range sources {
+		status, err := manager.GetStatus()
+		if err != nil {
+			delete(c.last, id)
+			continue
+		}
+
+		sample := entity.MetricSample{
+			Time:     now.UnixMilli(),
+			Cpu:      status.Cpu,
+			MemoryMB: status.Memory,
+			Threads:  status.Threads,
+		}
+
+		// 同一进程实例的两次采样之间才能计算 IO 速率，进程重启后重新计时
+		last, ok := c.last[id]
+		if ok && last.pid == status.Pid && status.ReadBytes >= last.readBytes && status.WriteBytes >= last.writeBytes {
+			if seconds := now.Sub(last.time).Seconds(); seconds > 0 {
+				sample.ReadRate = float64(status.ReadBytes-last.readBytes) / seconds
+				sample.WriteRate = float64(status.WriteBytes-last.writeBytes) / seconds
+			}
+		}
+		c.last[id] = ioCounter{pid: status.Pid, time: now, readBytes: status.ReadBytes, writeBytes: status.WriteBytes}
+
+		samples[id] = sample
+	}
+	return samples
+}
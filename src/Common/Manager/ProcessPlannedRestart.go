@@ -0,0 +1,338 @@
+package v_manager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// 计划重启的进度事件类型
+const (
+	EventRestartPlanned   = "restart-planned"   // 开始倒计时
+	EventRestartCountdown = "restart-countdown" // 发送了一次倒计时广播
+	EventRestartProgress  = "restart-progress"  // 倒计时结束，开始停止或启动进程
+	EventRestartCompleted = "restart-completed" // 进程已重新启动
+	EventRestartAborted   = "restart-aborted"   // 重启被取消，进程保持运行
+	EventRestartFailed    = "restart-failed"    // 停止或启动进程失败
+)
+
+// 计划重启的阶段
+const (
+	RestartPhaseCountdown = "countdown"
+	RestartPhaseStopping  = "stopping"
+	RestartPhaseStarting  = "starting"
+	RestartPhaseCompleted = "completed"
+	RestartPhaseAborted   = "aborted"
+	RestartPhaseFailed    = "failed"
+)
+
+// restartRemainingPlaceholder 广播命令中替换为剩余时间的占位符
+const restartRemainingPlaceholder = "{remaining}"
+
+// defaultRestartWarningCommand 未设置命令的倒计时广播
+const defaultRestartWarningCommand = "say Restarting in " + restartRemainingPlaceholder
+
+// defaultRestartWarnings 未设置倒计时广播时使用的剩余秒数
+var defaultRestartWarnings = []int{600, 300, 60, 10}
+
+// RestartWarning 计划重启前的一次倒计时广播
+type RestartWarning struct {
+	BeforeSec int    `json:"beforeSec"` // 距离重启的秒数
+	Command   string `json:"command"`   // 发送给进程的命令，{remaining} 替换为剩余时间 (如 10 minutes)，为空使用 say Restarting in {remaining}
+}
+
+// RestartNotice 计划重启时向玩家发送的广播
+type RestartNotice struct {
+	Warnings     []RestartWarning `json:"warnings"`     // 为 nil 时在剩余 10 分钟、5 分钟、1 分钟与 10 秒时广播
+	AbortCommand string           `json:"abortCommand"` // 取消重启时发送的命令，为空不发送
+}
+
+// normalize 校验广播设置，按剩余时间从长到短排序并填充默认值
+func (n RestartNotice) normalize() (RestartNotice, error) {
+	if n.Warnings == nil {
+		for _, sec := range defaultRestartWarnings {
+			n.Warnings = append(n.Warnings, RestartWarning{BeforeSec: sec})
+		}
+	}
+
+	warnings := make([]RestartWarning, 0, len(n.Warnings))
+	seen := make(map[int]bool, len(n.Warnings))
+	for _, warning := range n.Warnings {
+		if warning.BeforeSec <= 0 {
+			return n, fmt.Errorf("倒计时广播的剩余秒数必须大于 0")
+		}
+		if seen[warning.BeforeSec] {
+			return n, fmt.Errorf("重复的倒计时广播: %d 秒", warning.BeforeSec)
+		}
+		seen[warning.BeforeSec] = true
+		if warning.Command == "" {
+			warning.Command = defaultRestartWarningCommand
+		}
+		warnings = append(warnings, warning)
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].BeforeSec > warnings[j].BeforeSec })
+	n.Warnings = warnings
+	return n, nil
+}
+
+// formatRemaining 将剩余秒数格式化为广播中的文字，如 10 minutes、1 minute 30 seconds
+func formatRemaining(sec int) string {
+	units := []struct {
+		name string
+		size int
+	}{{"hour", 3600}, {"minute", 60}, {"second", 1}}
+
+	var parts []string
+	for _, unit := range units {
+		if n := sec / unit.size; n > 0 {
+			if n == 1 {
+				parts = append(parts, fmt.Sprintf("1 %s", unit.name))
+			} else {
+				parts = append(parts, fmt.Sprintf("%d %ss", n, unit.name))
+			}
+			sec %= unit.size
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// plannedRestart 正在进行的计划重启
+type plannedRestart struct {
+	progress  entity.RestartProgress
+	abort     chan struct{} // 取消倒计时时关闭
+	abortOnce sync.Once
+	done      chan error // 重启结束后写入结果，容量为 1
+}
+
+// cancel 关闭 abort 通知倒计时结束，可重复调用
+func (p *plannedRestart) cancel() {
+	p.abortOnce.Do(func() { close(p.abort) })
+}
+
+// ended 计划重启是否已被取消或已结束，此后阶段不再改变
+func (p *plannedRestart) ended() bool {
+	switch p.progress.Phase {
+	case RestartPhaseAborted, RestartPhaseCompleted, RestartPhaseFailed:
+		return true
+	}
+	return false
+}
+
+// SetRestartNotice 设置计划重启的倒计时广播，对下一次计划重启生效
+func (pm *ProcessManager) SetRestartNotice(notice RestartNotice) error {
+	notice, err := notice.normalize()
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.restartNotice = notice
+	return nil
+}
+
+// GetRestartNotice 获取计划重启的倒计时广播
+func (pm *ProcessManager) GetRestartNotice() RestartNotice {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.restartNotice
+}
+
+// PlanRestart 在 delay 后按停止流程停止进程并重新启动，期间按倒计时广播设置通知玩家
+// 剩余时间长于 delay 的广播不会发送。返回的通道在重启结束 (完成、失败或被取消) 后收到结果
+func (pm *ProcessManager) PlanRestart(delay time.Duration, reason string) (entity.RestartProgress, <-chan error, error) {
+	if delay < 0 {
+		return entity.RestartProgress{}, nil, fmt.Errorf("倒计时不能为负数")
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.plannedRestart != nil {
+		return entity.RestartProgress{}, nil, fmt.Errorf("已有正在进行的计划重启")
+	}
+	if pm.activeProcess == nil || !pm.activeProcess.IsRunning() || pm.stopping {
+		return entity.RestartProgress{}, nil, fmt.Errorf("进程未在运行")
+	}
+
+	now := time.Now()
+	plan := &plannedRestart{
+		progress: entity.RestartProgress{
+			Reason:      reason,
+			Phase:       RestartPhaseCountdown,
+			PlannedAt:   now.UnixMilli(),
+			RestartAt:   now.Add(delay).UnixMilli(),
+			RemainingMs: delay.Milliseconds(),
+		},
+		abort: make(chan struct{}),
+		done:  make(chan error, 1),
+	}
+	pm.plannedRestart = plan
+	progress := plan.progress
+
+	vlogger.AppLogger.Infof("进程 %s 将在 %s 后重启", pm.Path, delay)
+	pm.emitEvent(entity.ProcessEvent{Type: EventRestartPlanned, Restart: &progress})
+	go pm.runPlannedRestart(plan, pm.restartNotice)
+	return progress, plan.done, nil
+}
+
+// AbortRestart 取消正在倒计时的计划重启，已经开始停止进程时无法取消
+func (pm *ProcessManager) AbortRestart() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return pm.abortRestartLocked()
+}
+
+// abortRestartLocked 取消正在倒计时的计划重启，调用方需持有写锁
+func (pm *ProcessManager) abortRestartLocked() error {
+	plan := pm.plannedRestart
+	if plan == nil {
+		return fmt.Errorf("没有正在进行的计划重启")
+	}
+	if plan.progress.Phase != RestartPhaseCountdown {
+		return fmt.Errorf("重启已开始，无法取消")
+	}
+	plan.progress.Phase = RestartPhaseAborted
+	plan.cancel()
+	return nil
+}
+
+// GetPlannedRestart 获取正在进行的计划重启，没有时返回 nil
+func (pm *ProcessManager) GetPlannedRestart() *entity.RestartProgress {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if pm.plannedRestart == nil {
+		return nil
+	}
+	progress := pm.plannedRestart.progress
+	if progress.Phase == RestartPhaseCountdown {
+		progress.RemainingMs = max(0, progress.RestartAt-time.Now().UnixMilli())
+	}
+	return &progress
+}
+
+// runPlannedRestart 依次发送倒计时广播，倒计时结束后停止并重新启动进程
+func (pm *ProcessManager) runPlannedRestart(plan *plannedRestart, notice RestartNotice) {
+	restartAt := time.UnixMilli(plan.progress.RestartAt)
+	for _, warning := range notice.Warnings {
+		at := restartAt.Add(-time.Duration(warning.BeforeSec) * time.Second)
+		if at.Before(time.UnixMilli(plan.progress.PlannedAt)) {
+			continue
+		}
+		if !pm.waitRestart(plan, at) {
+			pm.finishPlannedRestart(plan, RestartPhaseAborted, nil, notice.AbortCommand)
+			return
+		}
+
+		command := strings.ReplaceAll(warning.Command, restartRemainingPlaceholder, formatRemaining(warning.BeforeSec))
+		if err := pm.SendCommand(command); err != nil {
+			vlogger.AppLogger.Warnf("发送重启倒计时广播失败: %v", err)
+		}
+		pm.emitRestart(plan, EventRestartCountdown, RestartPhaseCountdown, int64(warning.BeforeSec)*1000)
+	}
+	if !pm.waitRestart(plan, restartAt) {
+		pm.finishPlannedRestart(plan, RestartPhaseAborted, nil, notice.AbortCommand)
+		return
+	}
+
+	pm.mu.Lock()
+	aborted := plan.progress.Phase == RestartPhaseAborted
+	if !aborted {
+		plan.progress.Phase = RestartPhaseStopping
+	}
+	pm.mu.Unlock()
+	if aborted {
+		pm.finishPlannedRestart(plan, RestartPhaseAborted, nil, notice.AbortCommand)
+		return
+	}
+
+	pm.emitRestart(plan, EventRestartProgress, RestartPhaseStopping, 0)
+	if _, err := pm.Stop(); err != nil {
+		pm.finishPlannedRestart(plan, RestartPhaseFailed, err, "")
+		return
+	}
+
+	pm.emitRestart(plan, EventRestartProgress, RestartPhaseStarting, 0)
+	pm.mu.RLock()
+	logCallback := pm.logCallback
+	pm.mu.RUnlock()
+	if err := pm.Start(logCallback); err != nil {
+		pm.finishPlannedRestart(plan, RestartPhaseFailed, err, "")
+		return
+	}
+	pm.finishPlannedRestart(plan, RestartPhaseCompleted, nil, "")
+}
+
+// waitRestart 等待到 at，倒计时被取消时返回 false
+func (pm *ProcessManager) waitRestart(plan *plannedRestart, at time.Time) bool {
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+
+	select {
+	case <-plan.abort:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// emitRestart 更新计划重启的阶段并发出进度事件
+// 发送广播期间计划重启可能已被取消，此时不再改变阶段，也不发出事件
+func (pm *ProcessManager) emitRestart(plan *plannedRestart, eventType string, phase string, remainingMs int64) {
+	pm.mu.Lock()
+	if plan.ended() {
+		pm.mu.Unlock()
+		return
+	}
+	plan.progress.Phase = phase
+	plan.progress.RemainingMs = remainingMs
+	progress := plan.progress
+	pm.mu.Unlock()
+
+	pm.emitEvent(entity.ProcessEvent{Type: eventType, Restart: &progress})
+}
+
+// finishPlannedRestart 结束计划重启，发出最终事件并通知等待结果的调用方
+func (pm *ProcessManager) finishPlannedRestart(plan *plannedRestart, phase string, err error, abortCommand string) {
+	eventType := EventRestartCompleted
+	switch phase {
+	case RestartPhaseAborted:
+		eventType = EventRestartAborted
+		err = fmt.Errorf("重启已取消")
+		// 因手动停止而取消时不再通知玩家
+		pm.mu.RLock()
+		stopRequested := pm.stopRequested
+		pm.mu.RUnlock()
+		if abortCommand != "" && !stopRequested && pm.IsRunning() {
+			if sendErr := pm.SendCommand(abortCommand); sendErr != nil {
+				vlogger.AppLogger.Warnf("发送取消重启广播失败: %v", sendErr)
+			}
+		}
+		vlogger.AppLogger.Infof("进程 %s 的计划重启已取消", pm.Path)
+	case RestartPhaseFailed:
+		eventType = EventRestartFailed
+		err = fmt.Errorf("计划重启失败: %w", err)
+		vlogger.AppLogger.Errorf("进程 %s 的%v", pm.Path, err)
+	}
+
+	pm.mu.Lock()
+	plan.progress.Phase = phase
+	plan.progress.RemainingMs = 0
+	if err != nil {
+		plan.progress.Error = err.Error()
+	}
+	progress := plan.progress
+	if pm.plannedRestart == plan {
+		pm.plannedRestart = nil
+	}
+	pm.mu.Unlock()
+
+	pm.emitEvent(entity.ProcessEvent{Type: eventType, Restart: &progress})
+	plan.done <- err
+}
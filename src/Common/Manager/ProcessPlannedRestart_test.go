@@ -0,0 +1,142 @@
+package v_manager
+
+import (
+	"testing"
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+func TestFormatRemaining(t *testing.T) {
+	tests := map[int]string{
+		10:   "10 seconds",
+		60:   "1 minute",
+		90:   "1 minute 30 seconds",
+		600:  "10 minutes",
+		3661: "1 hour 1 minute 1 second",
+	}
+	for sec, want := range tests {
+		if got := formatRemaining(sec); got != want {
+			t.Errorf("formatRemaining(%d) = %q, want %q", sec, got, want)
+		}
+	}
+}
+
+func TestPlannedRestartAbortRace(t *testing.T) {
+	// 模拟发送倒计时广播期间被取消: 广播发送后的 emitRestart 晚于取消
+	abort := func(pm *ProcessManager, _ *plannedRestart) { _ = pm.AbortRestart() }
+	stop := func(pm *ProcessManager, _ *plannedRestart) { _, _ = pm.Stop() }
+	lateCountdown := func(pm *ProcessManager, plan *plannedRestart) {
+		pm.emitRestart(plan, EventRestartCountdown, RestartPhaseCountdown, 1000)
+	}
+	tests := []struct {
+		name  string
+		steps []func(*ProcessManager, *plannedRestart)
+	}{
+		{"abort twice", []func(*ProcessManager, *plannedRestart){abort, lateCountdown, abort}},
+		{"abort then stop", []func(*ProcessManager, *plannedRestart){abort, lateCountdown, stop}},
+		{"stop then abort", []func(*ProcessManager, *plannedRestart){stop, lateCountdown, abort, stop}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := NewProcessManager(Ordinary, "server")
+			plan := &plannedRestart{
+				progress: entity.RestartProgress{Phase: RestartPhaseCountdown},
+				abort:    make(chan struct{}),
+				done:     make(chan error, 1),
+			}
+			pm.plannedRestart = plan
+
+			for _, step := range tt.steps {
+				step(pm, plan)
+			}
+			if phase := pm.GetPlannedRestart().Phase; phase != RestartPhaseAborted {
+				t.Errorf("阶段 = %s, want %s", phase, RestartPhaseAborted)
+			}
+			if err := pm.AbortRestart(); err == nil {
+				t.Error("已取消的重启再次取消应返回错误")
+			}
+			select {
+			case <-plan.abort:
+			default:
+				t.Error("取消后 abort 未关闭")
+			}
+		})
+	}
+}
+
+func TestPlannedRestart(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	if err := pm.SetRestartNotice(RestartNotice{Warnings: []RestartWarning{{BeforeSec: 1, Command: "say {remaining}"}}}); err != nil {
+		t.Fatal(err)
+	}
+	output := recordOutput(t, pm)
+	events := recordEvents(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, done, err := pm.PlanRestart(1200*time.Millisecond, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := pm.PlanRestart(time.Second, "again"); err == nil {
+		t.Error("已有计划重启时再次计划应返回错误")
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("等待重启完成超时")
+	}
+
+	output.waitLine(t, "[Server] 1 second", time.Second)
+	want := []string{
+		EventStarted, EventRestartPlanned, EventRestartCountdown, EventRestartProgress,
+		EventStopped, EventRestartProgress, EventStarted, EventRestartCompleted,
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !equalStrings(events.types(), want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !equalStrings(events.types(), want) {
+		t.Errorf("事件 = %v, want %v", events.types(), want)
+	}
+	if !pm.IsRunning() || pm.GetPlannedRestart() != nil {
+		t.Errorf("重启后进程运行 = %v，计划重启 = %+v", pm.IsRunning(), pm.GetPlannedRestart())
+	}
+}
+
+func TestStopAbortsPlannedRestart(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	if err := pm.SetRestartNotice(RestartNotice{AbortCommand: "say aborted"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	_, done, err := pm.PlanRestart(time.Minute, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pm.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("停止后计划重启应以取消结束")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待计划重启结束超时")
+	}
+	if pm.IsRunning() {
+		t.Error("停止后进程被重新启动")
+	}
+	if err := pm.AbortRestart(); err == nil {
+		t.Error("计划重启结束后取消应返回错误")
+	}
+}
@@ -0,0 +1,185 @@
+package v_manager
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	vdata "voxesis/src/Common/Data"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// 玩家进出事件类型
+const (
+	EventPlayerJoined = "player-joined" // 玩家加入服务器
+	EventPlayerLeft   = "player-left"   // 玩家离开服务器，服务器退出时在线的玩家同样视为离开
+)
+
+var (
+	// bedrockPlayerPattern 基岩版: Player connected: Steve, xuid: 2535400000000000
+	bedrockPlayerPattern = regexp.MustCompile(`Player (connected|disconnected): ([^,]+), xuid: ?(\d*)`)
+	// javaPlayerPattern Java 版: Steve joined the game，只匹配已解析出的日志消息，避免聊天内容被误认
+	javaPlayerPattern = regexp.MustCompile(`^([A-Za-z0-9_.]{1,32}) (joined|left) the game$`)
+)
+
+// trackedPlayer 在线玩家及其会话ID
+type trackedPlayer struct {
+	entity.OnlinePlayer
+	sessionId int64
+}
+
+// PlayerTracker 从服务器输出中识别玩家的加入与离开，维护在线玩家列表并持久化游戏会话
+type PlayerTracker struct {
+	store     vdata.PlayerStore
+	processId int
+	notify    func(event entity.ProcessEvent)
+
+	mu     sync.Mutex
+	online map[string]trackedPlayer // 键为玩家名
+}
+
+// NewPlayerTracker 创建玩家追踪器，并恢复上次运行时尚未结束的会话
+// notify: 玩家加入或离开时调用，可以为 nil
+func NewPlayerTracker(store vdata.PlayerStore, processId int, notify func(event entity.ProcessEvent)) *PlayerTracker {
+	t := &PlayerTracker{
+		store:     store,
+		processId: processId,
+		notify:    notify,
+		online:    make(map[string]trackedPlayer),
+	}
+
+	// 进程可能在 Voxesis 重启期间一直运行，之后被接管时这些玩家仍然在线
+	sessions, err := store.ListOpenSessions(processId)
+	if err != nil {
+		vlogger.AppLogger.Warnf("读取ID为 %d 的进程的在线玩家失败: %v", processId, err)
+	}
+	for _, session := range sessions {
+		t.online[session.Name] = trackedPlayer{
+			OnlinePlayer: entity.OnlinePlayer{Name: session.Name, Xuid: session.Xuid, JoinedAt: session.JoinedAt},
+			sessionId:    session.Id,
+		}
+	}
+	return t
+}
+
+// Observe 检查一行输出是否为玩家的加入或离开
+// 已解析出日志格式的行使用日志中的时间，重新连接后回放的输出也能得到正确的时间
+func (t *PlayerTracker) Observe(line entity.OutputLine) {
+	text, at := strings.TrimSpace(line.Data), line.Time
+	if line.Record != nil {
+		text, at = line.Record.Message, line.Record.Time
+	}
+
+	if match := bedrockPlayerPattern.FindStringSubmatch(text); match != nil {
+		name := strings.TrimSpace(match[2])
+		if match[1] == "connected" {
+			t.join(name, match[3], at)
+		} else {
+			t.leave(name, at)
+		}
+		return
+	}
+
+	if line.Record == nil || line.Record.Format != "java" {
+		return
+	}
+	if match := javaPlayerPattern.FindStringSubmatch(text); match != nil {
+		if match[2] == "joined" {
+			t.join(match[1], "", at)
+		} else {
+			t.leave(match[1], at)
+		}
+	}
+}
+
+// HandleEvent 根据进程生命周期事件结束会话
+// 进程启动时仍未结束的会话来自上次运行，Voxesis 无法得知玩家何时离开，以启动时间作为离开时间
+func (t *PlayerTracker) HandleEvent(event entity.ProcessEvent) {
+	switch event.Type {
+	case EventStarted, EventStopped, EventExited:
+		t.leaveAll(event.Time)
+	}
+}
+
+// Online 按加入时间返回在线玩家
+func (t *PlayerTracker) Online() []entity.OnlinePlayer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	players := make([]entity.OnlinePlayer, 0, len(t.online))
+	for _, player := range t.online {
+		players = append(players, player.OnlinePlayer)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].JoinedAt < players[j].JoinedAt })
+	return players
+}
+
+// join 记录玩家加入，已在线的玩家 (如回放的输出) 忽略
+func (t *PlayerTracker) join(name, xuid string, at int64) {
+	t.mu.Lock()
+	if _, ok := t.online[name]; ok {
+		t.mu.Unlock()
+		return
+	}
+	player := trackedPlayer{OnlinePlayer: entity.OnlinePlayer{Name: name, Xuid: xuid, JoinedAt: at}}
+	id, err := t.store.OpenSession(t.processId, name, xuid, at)
+	if err != nil {
+		vlogger.AppLogger.Errorf("记录玩家 %s 加入失败: %v", name, err)
+	}
+	player.sessionId = id
+	t.online[name] = player
+	t.mu.Unlock()
+
+	t.emit(EventPlayerJoined, player.OnlinePlayer)
+}
+
+// leave 记录玩家离开，不在线的玩家忽略
+func (t *PlayerTracker) leave(name string, at int64) {
+	t.mu.Lock()
+	player, ok := t.online[name]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.online, name)
+	t.closeSession(player, at)
+	t.mu.Unlock()
+
+	t.emit(EventPlayerLeft, player.OnlinePlayer)
+}
+
+// leaveAll 所有在线玩家视为离开
+func (t *PlayerTracker) leaveAll(at int64) {
+	t.mu.Lock()
+	players := t.online
+	t.online = make(map[string]trackedPlayer)
+	for _, player := range players {
+		t.closeSession(player, at)
+	}
+	t.mu.Unlock()
+
+	for _, player := range players {
+		t.emit(EventPlayerLeft, player.OnlinePlayer)
+	}
+}
+
+// closeSession 结束玩家的会话，调用方需持有锁
+func (t *PlayerTracker) closeSession(player trackedPlayer, at int64) {
+	if player.sessionId == 0 {
+		return
+	}
+	if err := t.store.CloseSession(player.sessionId, at); err != nil {
+		vlogger.AppLogger.Errorf("记录玩家 %s 离开失败: %v", player.Name, err)
+	}
+}
+
+// emit 通知玩家的加入或离开
+func (t *PlayerTracker) emit(eventType string, player entity.OnlinePlayer) {
+	if t.notify == nil {
+		return
+	}
+	t.notify(entity.ProcessEvent{Type: eventType, Time: time.Now().UnixMilli(), Player: &player})
+}
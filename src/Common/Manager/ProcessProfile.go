@@ -0,0 +1,210 @@
+package v_manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// LaunchProfile 命名的启动配置，同一个可执行文件可以按不同配置启动，例如测试与生产环境
+type LaunchProfile struct {
+	Name          string            `json:"name"`
+	Args          []string          `json:"args"`          // 为 nil 时使用进程定义中的参数
+	Env           map[string]string `json:"env"`           // 在进程定义的环境变量之上覆盖或追加
+	Unset         []string          `json:"unset"`         // 从继承的环境变量中移除
+	WorkingDir    string            `json:"workingDir"`    // 为空时使用进程定义中的工作目录
+	StdinEncoding string            `json:"stdinEncoding"` // 发送命令时使用的编码，例如 gbk，为空时使用 UTF-8
+}
+
+// ProfileList 进程的启动配置列表与当前选择的启动配置
+type ProfileList struct {
+	Profiles []LaunchProfile `json:"profiles"`
+	Selected string          `json:"selected"`
+}
+
+// launchConfig 合并进程定义与启动配置后的实际启动参数
+type launchConfig struct {
+	args       []string
+	workingDir string
+	env        []string // 为 nil 时继承当前进程的环境变量
+	encoder    *encoding.Encoder
+}
+
+// validateProfiles 校验启动配置列表
+func validateProfiles(profiles []LaunchProfile) error {
+	names := make(map[string]struct{}, len(profiles))
+	for _, profile := range profiles {
+		if strings.TrimSpace(profile.Name) == "" {
+			return fmt.Errorf("启动配置的名称不能为空")
+		}
+		if _, ok := names[profile.Name]; ok {
+			return fmt.Errorf("启动配置 %s 重复", profile.Name)
+		}
+		names[profile.Name] = struct{}{}
+		if _, err := lookupEncoding(profile.StdinEncoding); err != nil {
+			return fmt.Errorf("启动配置 %s: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// lookupEncoding 按名称查找编码，UTF-8 与空名称返回 nil
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	if name == "" {
+		return nil, nil
+	}
+	enc, err := htmlindex.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("不支持的编码: %s", name)
+	}
+	if canonical, _ := htmlindex.Name(enc); canonical == "utf-8" {
+		return nil, nil
+	}
+	return enc, nil
+}
+
+// SetProfiles 设置进程的启动配置列表，正在运行的进程在下次启动时生效
+// 已选择的启动配置不在新列表中时取消选择
+func (pm *ProcessManager) SetProfiles(profiles []LaunchProfile) error {
+	if err := validateProfiles(profiles); err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.profiles = append([]LaunchProfile{}, profiles...)
+	if _, ok := pm.findProfile(pm.profile); !ok {
+		// 已选择的启动配置被删除时回退为不使用启动配置
+		pm.profile = ""
+	}
+	return nil
+}
+
+// ListProfiles 获取进程的启动配置列表
+func (pm *ProcessManager) ListProfiles() []LaunchProfile {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return append([]LaunchProfile{}, pm.profiles...)
+}
+
+// SelectedProfile 获取当前选择的启动配置名称，为空表示未使用启动配置
+func (pm *ProcessManager) SelectedProfile() string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.profile
+}
+
+// SelectProfile 选择下次启动 (包括自动重启) 使用的启动配置，为空表示不使用启动配置
+func (pm *ProcessManager) SelectProfile(name string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if name != "" {
+		if _, ok := pm.findProfile(name); !ok {
+			return fmt.Errorf("启动配置 %s 不存在", name)
+		}
+	}
+	pm.profile = name
+	return nil
+}
+
+// findProfile 按名称查找启动配置，调用方需持有锁
+func (pm *ProcessManager) findProfile(name string) (LaunchProfile, bool) {
+	for _, profile := range pm.profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return LaunchProfile{}, false
+}
+
+// ServerDir 返回进程启动时使用的工作目录，即服务器所在的目录
+func (pm *ProcessManager) ServerDir() string {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.serverDir()
+}
+
+// serverDir 返回进程启动时使用的工作目录，未设置时为可执行文件所在目录，调用方需持有锁
+func (pm *ProcessManager) serverDir() string {
+	workingDir := pm.workingDir
+	if config, err := pm.resolveLaunchConfig(); err == nil {
+		workingDir = config.workingDir
+	}
+	if workingDir == "" {
+		workingDir = filepath.Dir(pm.Path)
+	}
+	return workingDir
+}
+
+// resolveLaunchConfig 合并进程定义与当前选择的启动配置，调用方需持有锁
+func (pm *ProcessManager) resolveLaunchConfig() (launchConfig, error) {
+	config := launchConfig{args: pm.args, workingDir: pm.workingDir}
+	overrides := pm.env
+	var unset []string
+
+	if pm.profile != "" {
+		profile, ok := pm.findProfile(pm.profile)
+		if !ok {
+			return config, fmt.Errorf("启动配置 %s 不存在", pm.profile)
+		}
+		if profile.Args != nil {
+			config.args = profile.Args
+		}
+		if profile.WorkingDir != "" {
+			config.workingDir = profile.WorkingDir
+		}
+		if len(profile.Env) > 0 {
+			merged := make(map[string]string, len(overrides)+len(profile.Env))
+			for key, value := range overrides {
+				merged[key] = value
+			}
+			for key, value := range profile.Env {
+				merged[key] = value
+			}
+			overrides = merged
+		}
+		unset = profile.Unset
+
+		enc, err := lookupEncoding(profile.StdinEncoding)
+		if err != nil {
+			return config, err
+		}
+		if enc != nil {
+			config.encoder = enc.NewEncoder()
+		}
+	}
+
+	if len(overrides) > 0 || len(unset) > 0 {
+		config.env = mergeEnv(os.Environ(), overrides, unset)
+	}
+	return config, nil
+}
+
+// encodingProcess 在发送命令前将其转换为指定编码的进程包装
+type encodingProcess struct {
+	IProcess
+	encoder *encoding.Encoder
+}
+
+// SendCommand 转换编码后发送命令
+func (p *encodingProcess) SendCommand(command string) error {
+	encoded, err := p.encoder.String(command)
+	if err != nil {
+		return fmt.Errorf("转换命令编码失败: %w", err)
+	}
+	return p.IProcess.SendCommand(encoded)
+}
+
+// Resize 转发给被包装的进程
+func (p *encodingProcess) Resize(cols, rows uint16) error {
+	resizable, ok := p.IProcess.(IResizable)
+	if !ok {
+		return fmt.Errorf("当前进程不支持调整终端尺寸")
+	}
+	return resizable.Resize(cols, rows)
+}
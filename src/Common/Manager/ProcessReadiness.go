@@ -0,0 +1,251 @@
+package v_manager
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vutils "voxesis/src/Common/Utils"
+)
+
+// ProbeType 定义就绪检测的方式
+type ProbeType string
+
+const (
+	ProbeNone    ProbeType = "none"    // 不检测，启动即就绪
+	ProbeRegex   ProbeType = "regex"   // 输出匹配正则后就绪，例如 "Server started\\."
+	ProbeBedrock ProbeType = "bedrock" // 基岩版服务器响应 UDP Ping 后就绪
+	ProbeTCP     ProbeType = "tcp"     // TCP 端口可连接后就绪
+)
+
+// 就绪检测的默认值
+const (
+	defaultProbeHost     = "127.0.0.1"
+	defaultProbeInterval = 2 * time.Second
+	defaultProbeTimeout  = 5 * time.Minute
+	probeDialTimeout     = 2 * time.Second
+)
+
+// ReadinessProbe 进程的就绪检测配置
+type ReadinessProbe struct {
+	Type       ProbeType `json:"type"`
+	Pattern    string    `json:"pattern"`    // regex 检测使用的正则
+	Host       string    `json:"host"`       // bedrock / tcp 检测的地址，默认 127.0.0.1
+	Port       int       `json:"port"`       // bedrock / tcp 检测的端口
+	IntervalMs int       `json:"intervalMs"` // bedrock / tcp 检测的轮询间隔
+	TimeoutMs  int       `json:"timeoutMs"`  // 启动后超过该时长仍未就绪则判定检测失败
+}
+
+// readinessCheck 一次启动对应的就绪检测
+type readinessCheck struct {
+	probe       ReadinessProbe
+	passed      chan struct{}
+	stop        chan struct{}
+	passOnce    sync.Once
+	stopOnce    sync.Once
+	cancelWatch func()
+}
+
+// normalize 校验就绪检测配置并为未设置的字段填充默认值
+func (p ReadinessProbe) normalize() (ReadinessProbe, error) {
+	switch p.Type {
+	case "":
+		p.Type = ProbeNone
+	case ProbeNone:
+	case ProbeRegex:
+		if p.Pattern == "" {
+			return p, fmt.Errorf("regex 就绪检测需要设置 pattern")
+		}
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return p, fmt.Errorf("无效的 pattern 正则: %w", err)
+		}
+	case ProbeBedrock, ProbeTCP:
+		if p.Port <= 0 || p.Port > 65535 {
+			return p, fmt.Errorf("无效的就绪检测端口: %d", p.Port)
+		}
+		if p.Host == "" {
+			p.Host = defaultProbeHost
+		}
+	default:
+		return p, fmt.Errorf("不支持的就绪检测方式: %s", p.Type)
+	}
+
+	if p.IntervalMs <= 0 {
+		p.IntervalMs = int(defaultProbeInterval / time.Millisecond)
+	}
+	if p.TimeoutMs <= 0 {
+		p.TimeoutMs = int(defaultProbeTimeout / time.Millisecond)
+	}
+	return p, nil
+}
+
+// SetReadinessProbe 设置进程的就绪检测，下次启动时生效
+func (pm *ProcessManager) SetReadinessProbe(probe ReadinessProbe) error {
+	probe, err := probe.normalize()
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.readinessProbe = probe
+	return nil
+}
+
+// GetReadinessProbe 获取进程的就绪检测配置
+func (pm *ProcessManager) GetReadinessProbe() ReadinessProbe {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.readinessProbe
+}
+
+// IsReady 进程是否正在运行且已通过就绪检测
+func (pm *ProcessManager) IsReady() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.lifecycle.State == entity.LifecycleRunning && pm.lifecycle.Ready
+}
+
+// newReadinessCheck 在进程启动前创建就绪检测，以免错过启动初期的输出，调用方需持有写锁
+func (pm *ProcessManager) newReadinessCheck() *readinessCheck {
+	check := &readinessCheck{
+		probe:  pm.readinessProbe,
+		passed: make(chan struct{}),
+		stop:   make(chan struct{}),
+	}
+	if check.probe.Type == ProbeRegex {
+		pattern := regexp.MustCompile(check.probe.Pattern)
+		check.cancelWatch = pm.WatchOutput(func(line string) {
+			if pattern.MatchString(line) {
+				check.pass()
+			}
+		})
+	}
+	return check
+}
+
+// startReadinessCheck 进程启动成功后开始就绪检测，调用方需持有写锁
+func (pm *ProcessManager) startReadinessCheck(check *readinessCheck) {
+	pm.cancelReadinessCheck()
+	if check.probe.Type == ProbeNone {
+		pm.markReady()
+		return
+	}
+
+	pm.readiness = check
+	vlogger.AppLogger.Infof("进程 %s 已启动，等待就绪检测 (%s) 通过", pm.Path, check.probe.Type)
+	go pm.runReadinessCheck(check)
+}
+
+// cancelReadinessCheck 取消正在进行的就绪检测并清除就绪状态，调用方需持有写锁
+func (pm *ProcessManager) cancelReadinessCheck() {
+	if pm.readiness != nil {
+		pm.readiness.cancel()
+		pm.readiness = nil
+	}
+	pm.lifecycle.Ready = false
+	pm.lifecycle.ReadyAt = nil
+}
+
+// markReady 标记进程已就绪并广播 ready 事件，调用方需持有写锁
+func (pm *ProcessManager) markReady() {
+	now := time.Now()
+	pm.lifecycle.Ready = true
+	pm.lifecycle.ReadyAt = &now
+
+	lifecycle := pm.lifecycle
+	pm.emitEvent(entity.ProcessEvent{Type: EventReady, Lifecycle: &lifecycle})
+	pm.flushCommandQueue()
+}
+
+// runReadinessCheck 等待就绪检测通过或超时
+func (pm *ProcessManager) runReadinessCheck(check *readinessCheck) {
+	defer check.cancel()
+
+	timeout := time.NewTimer(time.Duration(check.probe.TimeoutMs) * time.Millisecond)
+	defer timeout.Stop()
+
+	// bedrock 与 tcp 检测需要主动轮询
+	if check.probe.Type == ProbeBedrock || check.probe.Type == ProbeTCP {
+		go check.poll()
+	}
+
+	select {
+	case <-check.stop:
+		return
+	case <-check.passed:
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		if pm.readiness == check {
+			pm.readiness = nil
+			vlogger.AppLogger.Infof("进程 %s 已就绪", pm.Path)
+			pm.markReady()
+		}
+	case <-timeout.C:
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		if pm.readiness == check {
+			pm.readiness = nil
+			pm.lifecycle.LastError = fmt.Sprintf("就绪检测 (%s) 在 %d 毫秒内未通过", check.probe.Type, check.probe.TimeoutMs)
+			vlogger.AppLogger.Warnf("进程 %s %s", pm.Path, pm.lifecycle.LastError)
+			lifecycle := pm.lifecycle
+			pm.emitEvent(entity.ProcessEvent{Type: EventReadyTimeout, Error: lifecycle.LastError, Lifecycle: &lifecycle})
+		}
+	}
+}
+
+// pass 标记检测通过
+func (c *readinessCheck) pass() {
+	c.passOnce.Do(func() { close(c.passed) })
+}
+
+// cancel 结束检测并释放输出监听
+func (c *readinessCheck) cancel() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		if c.cancelWatch != nil {
+			c.cancelWatch()
+		}
+	})
+}
+
+// poll 按间隔轮询端口，直到检测通过或被取消
+func (c *readinessCheck) poll() {
+	ticker := time.NewTicker(time.Duration(c.probe.IntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if c.check() {
+			c.pass()
+			return
+		}
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check 执行一次端口检测
+func (c *readinessCheck) check() bool {
+	switch c.probe.Type {
+	case ProbeBedrock:
+		_, err := vutils.GetBedrockMcServerStatus(c.probe.Host, uint16(c.probe.Port))
+		return err == nil
+	case ProbeTCP:
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(c.probe.Host, strconv.Itoa(c.probe.Port)), probeDialTimeout)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,231 @@
+package v_manager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	vlogger "voxesis/src/Common/Logger"
+	vutils "voxesis/src/Common/Utils"
+)
+
+// 暂停保存所用的命令的服务器版本
+const (
+	SaveEditionBedrock = "bedrock" // save hold / save query / save resume
+	SaveEditionJava    = "java"    // save-off / save-all flush / save-on
+)
+
+// 暂停保存的默认值
+const (
+	defaultSaveHoldTimeout = 60 * time.Second        // 等待服务器完成保存的总时长
+	saveQueryInterval      = 1 * time.Second         // 基岩版轮询 save query 的间隔
+	saveCommandQuiet       = 1500 * time.Millisecond // 没有匹配到结束行时，等待命令响应的静默时长
+)
+
+// bedrockSavedFilePattern save query 报告的文件列表: Bedrock level/db/000005.ldb:1234, Bedrock level/level.dat:2345
+var bedrockSavedFilePattern = regexp.MustCompile(`(.+?):(\d+)(?:,\s*|\s*$)`)
+
+// 服务器对暂停保存命令的响应
+const (
+	bedrockSaveReady       = "Files are now ready to be copied"
+	bedrockSaveNotReady    = "A previous save has not been completed"
+	bedrockSaveHoldRunning = "The command is already running"
+	javaSaveOff            = "Automatic saving is now disabled"
+	javaSaveAlreadyOff     = "Saving is already turned off"
+	javaSaveFlushed        = "Saved the game"
+)
+
+// SavedFile 基岩版 save query 报告的世界文件，只有前 Size 字节是一致的
+type SavedFile struct {
+	Path string // 相对于 worlds 目录，以 / 分隔
+	Size int64
+}
+
+// SaveHold 一次暂停保存，复制完成后必须调用 Resume 恢复保存
+type SaveHold struct {
+	Edition string
+	Files   []SavedFile // 基岩版需要复制的文件与长度
+
+	pm         *ProcessManager
+	resumeCmd  string // 为空时不需要恢复，如 Java 版原本就关闭了自动保存
+	resumeOnce sync.Once
+	resumeErr  error
+}
+
+// Resume 恢复服务器的保存，多次调用只发送一次命令
+func (h *SaveHold) Resume() error {
+	h.resumeOnce.Do(func() {
+		if h.resumeCmd == "" {
+			return
+		}
+		// 不经过 ExecuteCommand，只等待正在收集的响应结束，不收集恢复命令的输出
+		if h.resumeErr = h.pm.SendCommand(h.resumeCmd); h.resumeErr != nil {
+			vlogger.AppLogger.Errorf("进程 %s 恢复保存失败: %v", h.pm.Path, h.resumeErr)
+			return
+		}
+		vlogger.AppLogger.Infof("进程 %s 已恢复保存", h.pm.Path)
+	})
+	return h.resumeErr
+}
+
+// HoldSave 暂停服务器的保存，使世界文件可以安全复制。timeout 为等待服务器完成保存的总时长，为 0 使用 60 秒
+// 基岩版返回 save query 报告的文件列表；失败或超时时会先恢复保存再返回错误
+func (pm *ProcessManager) HoldSave(edition string, timeout time.Duration) (*SaveHold, error) {
+	if timeout <= 0 {
+		timeout = defaultSaveHoldTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	var (
+		hold *SaveHold
+		err  error
+	)
+	switch edition {
+	case SaveEditionBedrock:
+		hold, err = pm.holdBedrockSave(deadline)
+	case SaveEditionJava:
+		hold, err = pm.holdJavaSave(deadline)
+	default:
+		return nil, fmt.Errorf("不支持的服务器版本: %s", edition)
+	}
+	if err != nil {
+		if hold != nil {
+			_ = hold.Resume()
+		}
+		return nil, err
+	}
+	vlogger.AppLogger.Infof("进程 %s 已暂停保存", pm.Path)
+	return hold, nil
+}
+
+// holdBedrockSave 发送 save hold，并轮询 save query 直到服务器报告可以复制的文件
+// 返回错误时若已发送 save hold，同时返回需要恢复的 SaveHold
+func (pm *ProcessManager) holdBedrockSave(deadline time.Time) (*SaveHold, error) {
+	response, err := pm.executeSaveCommand("save hold", "Saving|"+bedrockSaveHoldRunning, saveCommandQuiet, deadline)
+	if err != nil {
+		return nil, err
+	}
+	// 命令已发出，即使响应超时也可能已生效
+	hold := &SaveHold{Edition: SaveEditionBedrock, pm: pm, resumeCmd: "save resume"}
+	if response.TimedOut {
+		return hold, fmt.Errorf("等待 save hold 响应超时")
+	}
+	if containsOutput(response.Lines, bedrockSaveHoldRunning) {
+		// 其他工具已暂停了保存，复制完成后由它恢复，不能提前恢复它的暂停
+		hold.resumeCmd = ""
+		vlogger.AppLogger.Warnf("进程 %s 的保存已被其他工具暂停，备份完成后不会恢复保存", pm.Path)
+	}
+
+	for {
+		response, err := pm.executeSaveCommand("save query", bedrockSaveNotReady, saveCommandQuiet, deadline)
+		if err != nil {
+			return hold, err
+		}
+		if files, ok := parseBedrockSaveQuery(response.Lines); ok {
+			hold.Files = files
+			return hold, nil
+		}
+		if time.Now().Add(saveQueryInterval).After(deadline) {
+			return hold, fmt.Errorf("等待服务器完成保存超时")
+		}
+		time.Sleep(saveQueryInterval)
+	}
+}
+
+// holdJavaSave 发送 save-off 关闭自动保存，再通过 save-all flush 将世界完整写入磁盘
+func (pm *ProcessManager) holdJavaSave(deadline time.Time) (*SaveHold, error) {
+	response, err := pm.executeSaveCommand("save-off", javaSaveOff+"|"+javaSaveAlreadyOff, saveCommandQuiet, deadline)
+	if err != nil {
+		return nil, err
+	}
+	hold := &SaveHold{Edition: SaveEditionJava, pm: pm, resumeCmd: "save-on"}
+	if response.TimedOut {
+		return hold, fmt.Errorf("等待 save-off 响应超时")
+	}
+	if containsOutput(response.Lines, javaSaveAlreadyOff) {
+		hold.resumeCmd = "" // 保持服务器原本关闭自动保存的状态
+	}
+
+	// 保存较大的世界可能长时间没有输出，只以 Saved the game 为准
+	response, err = pm.executeSaveCommand("save-all flush", javaSaveFlushed, 0, deadline)
+	if err != nil {
+		return hold, err
+	}
+	if !response.Terminated {
+		return hold, fmt.Errorf("等待 save-all flush 完成超时")
+	}
+	return hold, nil
+}
+
+// executeSaveCommand 发送命令并收集响应，直到匹配 terminator、静默 quiet 或到达 deadline，quiet 为 0 时不因静默结束
+func (pm *ProcessManager) executeSaveCommand(command string, terminator string, quiet time.Duration, deadline time.Time) (CommandResponse, error) {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return CommandResponse{TimedOut: true}, fmt.Errorf("发送 %s 前已超时", command)
+	}
+	if quiet <= 0 {
+		quiet = remaining
+	}
+	response, err := pm.ExecuteCommand(CommandRequest{
+		Command:    command,
+		Terminator: terminator,
+		QuietMs:    int(max(quiet/time.Millisecond, 1)),
+		TimeoutMs:  int(max(remaining/time.Millisecond, 1)),
+	})
+	if err != nil {
+		return response, fmt.Errorf("发送 %s 失败: %w", command, err)
+	}
+	return response, nil
+}
+
+// parseBedrockSaveQuery 从 save query 的响应中解析文件列表，服务器尚未完成保存时返回 false
+// 文件列表在 "Data saved. Files are now ready to be copied." 之后，可能在同一行或下一行
+func parseBedrockSaveQuery(lines []string) ([]SavedFile, bool) {
+	ready := false
+	for _, line := range lines {
+		text := outputText(line)
+		if !ready {
+			index := strings.Index(text, bedrockSaveReady)
+			if index < 0 {
+				continue
+			}
+			ready = true
+			text = strings.TrimLeft(text[index+len(bedrockSaveReady):], ". ")
+		}
+		if text == "" {
+			continue
+		}
+
+		var files []SavedFile
+		for _, match := range bedrockSavedFilePattern.FindAllStringSubmatch(text, -1) {
+			size, err := strconv.ParseInt(match[2], 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			files = append(files, SavedFile{Path: strings.TrimSpace(match[1]), Size: size})
+		}
+		return files, len(files) > 0
+	}
+	return nil, false
+}
+
+// containsOutput 判断响应中是否有包含 text 的行
+func containsOutput(lines []string, text string) bool {
+	for _, line := range lines {
+		if strings.Contains(outputText(line), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// outputText 返回输出行的文本，服务器日志只保留消息部分
+func outputText(line string) string {
+	if record := vutils.ParseLogLine(line, 0); record != nil {
+		return record.Message
+	}
+	return strings.TrimSpace(vutils.CleanOutputLine(line))
+}
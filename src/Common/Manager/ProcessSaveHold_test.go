@@ -0,0 +1,85 @@
+package v_manager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseBedrockSaveQuery(t *testing.T) {
+	files := []SavedFile{{Path: "Bedrock level/db/000005.ldb", Size: 1234}, {Path: "Bedrock level/level.dat", Size: 2345}}
+	tests := []struct {
+		name      string
+		lines     []string
+		want      []SavedFile
+		wantReady bool
+	}{
+		{"same line", []string{
+			"[STDOUT] Data saved. Files are now ready to be copied. Bedrock level/db/000005.ldb:1234, Bedrock level/level.dat:2345",
+		}, files, true},
+		{"next line", []string{
+			"[STDOUT] Data saved. Files are now ready to be copied.",
+			"[STDOUT] Bedrock level/db/000005.ldb:1234, Bedrock level/level.dat:2345",
+		}, files, true},
+		{"blank line before files", []string{
+			"Data saved. Files are now ready to be copied.",
+			"",
+			"Bedrock level/db/000005.ldb:1234, Bedrock level/level.dat:2345",
+		}, files, true},
+		{"log prefix", []string{
+			"[STDOUT] [2026-01-01 12:00:00:123 INFO] Data saved. Files are now ready to be copied.",
+			"[STDOUT] Bedrock level/level.dat:2345",
+		}, files[1:], true},
+		{"not ready", []string{"[STDOUT] A previous save has not been completed."}, nil, false},
+		{"ready without files", []string{"Data saved. Files are now ready to be copied."}, nil, false},
+		{"files before ready", []string{"Bedrock level/level.dat:2345"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ready := parseBedrockSaveQuery(tt.lines)
+			if ready != tt.wantReady || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBedrockSaveQuery() = %+v, %v, want %+v, %v", got, ready, tt.want, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestHoldBedrockSave(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		wantResume bool
+	}{
+		{"own hold", "server", true},
+		{"held by another tool", "held", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newFakeServer(t, tt.mode)
+			output := recordOutput(t, pm)
+			if err := pm.Start(nil); err != nil {
+				t.Fatal(err)
+			}
+			output.waitLine(t, "Server started.", 5*time.Second)
+
+			hold, err := pm.HoldSave(SaveEditionBedrock, 10*time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []SavedFile{{"Bedrock level/db/000005.ldb", 1234}, {"Bedrock level/level.dat", 2345}}; !reflect.DeepEqual(hold.Files, want) {
+				t.Errorf("Files = %+v, want %+v", hold.Files, want)
+			}
+
+			if err := hold.Resume(); err != nil {
+				t.Fatal(err)
+			}
+			if err := pm.SendCommand("say after resume"); err != nil {
+				t.Fatal(err)
+			}
+			output.waitLine(t, "[Server] after resume", 5*time.Second)
+			if resumed := containsOutput(output.snapshot(), "Changes to the level are resumed"); resumed != tt.wantResume {
+				t.Errorf("发送了 save resume = %v, want %v", resumed, tt.wantResume)
+			}
+		})
+	}
+}
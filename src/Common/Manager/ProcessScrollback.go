@@ -0,0 +1,84 @@
+package v_manager
+
+import (
+	"fmt"
+
+	"voxesis/src/Common/Entity"
+)
+
+// 回滚缓冲区的默认容量
+const (
+	defaultScrollbackLines = 5000
+	defaultScrollbackBytes = 4 << 20
+)
+
+// ScrollbackConfig 控制台回滚缓冲区的容量，行数与字节数任一超出即丢弃最旧的行
+type ScrollbackConfig struct {
+	MaxLines int `json:"maxLines"`
+	MaxBytes int `json:"maxBytes"`
+}
+
+// OutputReplay 某个序号之后的控制台输出
+type OutputReplay struct {
+	Lines     []entity.OutputLine `json:"lines"`
+	LastSeq   uint64              `json:"lastSeq"`   // 当前最新一行的序号
+	Truncated bool                `json:"truncated"` // 请求的序号之后有行已被丢弃
+}
+
+// normalize 校验回滚配置并为未设置的字段填充默认值
+func (c ScrollbackConfig) normalize() (ScrollbackConfig, error) {
+	if c.MaxLines < 0 || c.MaxBytes < 0 {
+		return c, fmt.Errorf("无效的回滚缓冲区容量: %d 行, %d 字节", c.MaxLines, c.MaxBytes)
+	}
+	if c.MaxLines == 0 {
+		c.MaxLines = defaultScrollbackLines
+	}
+	if c.MaxBytes == 0 {
+		c.MaxBytes = defaultScrollbackBytes
+	}
+	return c, nil
+}
+
+// SetScrollbackConfig 修改回滚缓冲区的容量，保留最新的输出
+func (pm *ProcessManager) SetScrollbackConfig(config ScrollbackConfig) error {
+	config, err := config.normalize()
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.scrollbackConfig = config
+	pm.scrollback.SetLimits(config.MaxLines, config.MaxBytes)
+	return nil
+}
+
+// GetScrollbackConfig 获取回滚缓冲区的容量
+func (pm *ProcessManager) GetScrollbackConfig() ScrollbackConfig {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.scrollbackConfig
+}
+
+// OutputSince 获取序号大于 seq 的控制台输出，seq 为 0 时返回全部缓冲的输出
+func (pm *ProcessManager) OutputSince(seq uint64) OutputReplay {
+	pm.mu.RLock()
+	scrollback := pm.scrollback
+	pm.mu.RUnlock()
+
+	// 行与最新序号取自同一时刻，补发与实时推送衔接时不会漏掉行
+	lines, lastSeq, truncated := scrollback.Since(seq)
+	return OutputReplay{
+		Lines:     lines,
+		LastSeq:   lastSeq,
+		Truncated: truncated,
+	}
+}
+
+// InheritOutput 沿用另一个管理器的回滚缓冲区，使进程配置更新后序号保持连续
+func (pm *ProcessManager) InheritOutput(from *ProcessManager) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.scrollback = from.scrollback
+	pm.scrollback.SetLimits(pm.scrollbackConfig.MaxLines, pm.scrollbackConfig.MaxBytes)
+}
@@ -0,0 +1,148 @@
+package v_manager
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	vlogger "voxesis/src/Common/Logger"
+	vprocess "voxesis/src/System/Process"
+)
+
+// StopStage 表示进程最终在停止流程的哪个阶段退出
+type StopStage string
+
+const (
+	StopStageNone    StopStage = "none"    // 进程本就未在运行
+	StopStageCommand StopStage = "command" // 响应控制台停止命令后自行退出
+	StopStageSignal  StopStage = "signal"  // 响应终止信号 (SIGTERM / Ctrl+C) 后退出
+	StopStageKill    StopStage = "kill"    // 超时后被强制杀死
+)
+
+// 停止流程的默认值
+const defaultStopGraceTimeout = 30 * time.Second
+
+// StopSequence 进程的优雅停止流程
+// 依次执行: 发送控制台命令 -> 等待退出 (最长 GraceTimeoutMs) -> 终止信号 -> 等待 TermTimeoutMs -> 强制杀死
+type StopSequence struct {
+	Command        string `json:"command"`        // 控制台停止命令，例如 "stop"，为空则直接发送信号
+	SavedPattern   string `json:"savedPattern"`   // 可选，匹配 "存档完成/已停止" 输出的正则
+	GraceTimeoutMs int    `json:"graceTimeoutMs"` // 发送命令后等待进程自行退出的时长
+	TermTimeoutMs  int    `json:"termTimeoutMs"`  // 发送终止信号后等待进程退出的时长
+}
+
+// StopResult 一次停止操作的结果
+type StopResult struct {
+	Stage      StopStage `json:"stage"`
+	Saved      bool      `json:"saved"`      // 是否匹配到了 SavedPattern
+	DurationMs int64     `json:"durationMs"` // 整个停止流程耗时
+}
+
+// normalize 校验停止流程并为未设置的字段填充默认值
+func (s StopSequence) normalize() (StopSequence, error) {
+	if s.SavedPattern != "" {
+		if _, err := regexp.Compile(s.SavedPattern); err != nil {
+			return s, fmt.Errorf("无效的 savedPattern 正则: %w", err)
+		}
+	}
+	if s.GraceTimeoutMs <= 0 {
+		s.GraceTimeoutMs = int(defaultStopGraceTimeout / time.Millisecond)
+	}
+	if s.TermTimeoutMs <= 0 {
+		s.TermTimeoutMs = int(vprocess.DefaultStopTimeout / time.Millisecond)
+	}
+	return s, nil
+}
+
+// SetStopSequence 设置进程的优雅停止流程
+func (pm *ProcessManager) SetStopSequence(sequence StopSequence) error {
+	sequence, err := sequence.normalize()
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.stopSequence = sequence
+	return nil
+}
+
+// GetStopSequence 获取进程的优雅停止流程
+func (pm *ProcessManager) GetStopSequence() StopSequence {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.stopSequence
+}
+
+// runStopSequence 按停止流程终止进程，调用期间不持有 pm.mu
+func (pm *ProcessManager) runStopSequence(proc IProcess, exited <-chan struct{}, seq StopSequence) (StopResult, error) {
+	begin := time.Now()
+	result := StopResult{}
+
+	if seq.Command != "" {
+		exitedByCommand, saved := pm.stopByCommand(proc, exited, seq)
+		result.Saved = saved
+		if exitedByCommand {
+			result.Stage = StopStageCommand
+			result.DurationMs = time.Since(begin).Milliseconds()
+			return result, nil
+		}
+		vlogger.AppLogger.Warnf("进程 %s 未在 %d 毫秒内响应停止命令，改为发送终止信号", pm.Path, seq.GraceTimeoutMs)
+	}
+
+	proc.SetStopTimeout(time.Duration(seq.TermTimeoutMs) * time.Millisecond)
+	err := proc.Stop()
+	result.DurationMs = time.Since(begin).Milliseconds()
+	switch {
+	case errors.Is(err, vprocess.ErrForceKilled):
+		result.Stage = StopStageKill
+	case err != nil:
+		return result, err
+	default:
+		result.Stage = StopStageSignal
+	}
+	return result, nil
+}
+
+// stopByCommand 发送控制台停止命令并等待进程退出，返回进程是否已退出以及是否匹配到存档完成的输出
+func (pm *ProcessManager) stopByCommand(proc IProcess, exited <-chan struct{}, seq StopSequence) (bool, bool) {
+	savedCh := make(chan struct{})
+	if seq.SavedPattern != "" {
+		pattern := regexp.MustCompile(seq.SavedPattern)
+		var once sync.Once
+		cancel := pm.WatchOutput(func(line string) {
+			if pattern.MatchString(line) {
+				once.Do(func() { close(savedCh) })
+			}
+		})
+		defer cancel()
+	}
+
+	if err := proc.SendCommand(seq.Command); err != nil {
+		vlogger.AppLogger.Warnf("向进程 %s 发送停止命令失败: %v", pm.Path, err)
+		return false, false
+	}
+
+	saved := false
+	grace := time.NewTimer(time.Duration(seq.GraceTimeoutMs) * time.Millisecond)
+	defer grace.Stop()
+	for {
+		select {
+		case <-exited:
+			select {
+			case <-savedCh:
+				saved = true
+			default:
+			}
+			return true, saved
+		case <-savedCh:
+			// 已确认存档完成，继续等待进程自行退出
+			saved = true
+			savedCh = nil
+		case <-grace.C:
+			return false, saved
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package v_manager
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+func TestStopSequenceNormalize(t *testing.T) {
+	sequence, err := StopSequence{}.normalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sequence.GraceTimeoutMs != 30000 || sequence.TermTimeoutMs <= 0 {
+		t.Errorf("默认停止流程 = %+v", sequence)
+	}
+
+	if _, err := (StopSequence{SavedPattern: "("}).normalize(); err == nil {
+		t.Error("无效的 savedPattern 应返回错误")
+	}
+}
+
+func TestStopSequence(t *testing.T) {
+	// Windows 无法向控制台进程发送终止信号，直接在超时后强制杀死
+	signalStage := StopStageSignal
+	if runtime.GOOS == "windows" {
+		signalStage = StopStageKill
+	}
+
+	tests := []struct {
+		name      string
+		mode      string
+		sequence  StopSequence
+		wantStage StopStage
+		wantSaved bool
+	}{
+		{"command", "server", StopSequence{Command: "stop"}, StopStageCommand, false},
+		{"command saved", "server", StopSequence{Command: "stop", SavedPattern: `Saved the game`}, StopStageCommand, true},
+		{"command not saved", "server", StopSequence{Command: "stop", SavedPattern: `never printed`}, StopStageCommand, false},
+		{"ignored command", "stubborn", StopSequence{Command: "stop", SavedPattern: `never printed`, GraceTimeoutMs: 200}, signalStage, false},
+		{"no command", "stubborn", StopSequence{}, signalStage, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newFakeServer(t, tt.mode)
+			tt.sequence.TermTimeoutMs = 1000
+			if err := pm.SetStopSequence(tt.sequence); err != nil {
+				t.Fatal(err)
+			}
+			events := recordEvents(t, pm)
+			if err := pm.Start(nil); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := pm.Stop()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Stage != tt.wantStage || result.Saved != tt.wantSaved {
+				t.Errorf("Stop() = %+v, want stage %s saved %v", result, tt.wantStage, tt.wantSaved)
+			}
+			if tt.wantStage == StopStageCommand && result.DurationMs >= 2000 {
+				t.Errorf("响应停止命令的进程等待了 %d 毫秒", result.DurationMs)
+			}
+
+			if pm.IsRunning() {
+				t.Error("Stop 返回后进程仍在运行")
+			}
+			if state := pm.GetLifecycle().State; state != entity.LifecycleStopped {
+				t.Errorf("生命周期状态 = %s, want %s", state, entity.LifecycleStopped)
+			}
+			if event := events.wait(t, EventStopped, 5*time.Second); event.Stage != string(tt.wantStage) {
+				t.Errorf("stopped 事件的阶段 = %s, want %s", event.Stage, tt.wantStage)
+			}
+			// 手动停止不会触发自动重启
+			if status := pm.GetSupervisorStatus(); status.State != SupervisorIdle {
+				t.Errorf("守护器状态 = %s, want %s", status.State, SupervisorIdle)
+			}
+		})
+	}
+}
+
+func TestStopNotRunning(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	result, err := pm.Stop()
+	if err != nil || result.Stage != StopStageNone {
+		t.Errorf("Stop() = %+v, %v, want stage %s", result, err, StopStageNone)
+	}
+}
+
+func TestStartStopsRunningProcess(t *testing.T) {
+	pm := newFakeServer(t, "server")
+	events := recordEvents(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	// 再次启动时先按停止流程停止正在运行的进程
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !pm.IsRunning() {
+		t.Fatal("重新启动后进程未在运行")
+	}
+
+	events.wait(t, EventStopped, 5*time.Second)
+	want := []string{EventStarted, EventStopped, EventStarted}
+	deadline := time.Now().Add(5 * time.Second)
+	for !equalStrings(events.types(), want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !equalStrings(events.types(), want) {
+		t.Errorf("事件 = %v, want %v", events.types(), want)
+	}
+	if event := events.snapshot()[1]; event.Stage != string(StopStageCommand) {
+		t.Errorf("旧进程在 %s 阶段退出, want %s", event.Stage, StopStageCommand)
+	}
+}
@@ -0,0 +1,250 @@
+package v_manager
+
+import (
+	"fmt"
+	"time"
+
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// RestartMode 定义进程退出后的重启策略
+type RestartMode string
+
+const (
+	RestartNever     RestartMode = "never"      // 从不自动重启
+	RestartOnFailure RestartMode = "on-failure" // 仅在异常退出时重启
+	RestartAlways    RestartMode = "always"     // 只要不是手动停止就重启
+)
+
+// SupervisorState 描述守护器当前所处的阶段
+type SupervisorState string
+
+const (
+	SupervisorIdle      SupervisorState = "idle"       // 未启动或已手动停止
+	SupervisorRunning   SupervisorState = "running"    // 进程正在运行
+	SupervisorBackoff   SupervisorState = "backoff"    // 进程已退出，等待退避后重启
+	SupervisorExited    SupervisorState = "exited"     // 进程已退出，策略不要求重启
+	SupervisorCrashLoop SupervisorState = "crash-loop" // 时间窗口内重启次数过多，已放弃
+)
+
+// 重启策略的默认值
+const (
+	defaultRestartBackoff    = time.Second
+	defaultRestartMaxBackoff = time.Minute
+	defaultRestartMaxCount   = 5
+	defaultRestartWindow     = 5 * time.Minute
+)
+
+// RestartPolicy 进程的自动重启策略
+type RestartPolicy struct {
+	Mode         RestartMode `json:"mode"`
+	BackoffMs    int         `json:"backoffMs"`    // 首次重启前的等待时间，之后每次翻倍
+	MaxBackoffMs int         `json:"maxBackoffMs"` // 退避时间上限
+	MaxRestarts  int         `json:"maxRestarts"`  // 时间窗口内允许的最大重启次数
+	WindowSec    int         `json:"windowSec"`    // 统计重启次数的时间窗口
+}
+
+// SupervisorStatus 守护器的运行状况
+type SupervisorStatus struct {
+	State         SupervisorState `json:"state"`
+	Policy        RestartPolicy   `json:"policy"`
+	Restarts      int             `json:"restarts"`      // 当前时间窗口内的重启次数
+	LastExitError string          `json:"lastExitError"` // 最近一次退出的错误，正常退出为空
+	NextRestartAt *time.Time      `json:"nextRestartAt"` // 处于退避阶段时的下次重启时间
+}
+
+// normalize 校验策略并为未设置的字段填充默认值
+func (p RestartPolicy) normalize() (RestartPolicy, error) {
+	switch p.Mode {
+	case "":
+		p.Mode = RestartNever
+	case RestartNever, RestartOnFailure, RestartAlways:
+	default:
+		return p, fmt.Errorf("不支持的重启策略: %s", p.Mode)
+	}
+
+	if p.BackoffMs <= 0 {
+		p.BackoffMs = int(defaultRestartBackoff / time.Millisecond)
+	}
+	if p.MaxBackoffMs <= 0 {
+		p.MaxBackoffMs = int(defaultRestartMaxBackoff / time.Millisecond)
+	}
+	if p.MaxBackoffMs < p.BackoffMs {
+		p.MaxBackoffMs = p.BackoffMs
+	}
+	if p.MaxRestarts <= 0 {
+		p.MaxRestarts = defaultRestartMaxCount
+	}
+	if p.WindowSec <= 0 {
+		p.WindowSec = int(defaultRestartWindow / time.Second)
+	}
+	return p, nil
+}
+
+// shouldRestart 根据退出结果判断是否需要重启
+func (p RestartPolicy) shouldRestart(exitErr error) bool {
+	switch p.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitErr != nil
+	default:
+		return false
+	}
+}
+
+// backoff 计算第 n 次 (从 0 开始) 重启前的等待时间
+func (p RestartPolicy) backoff(n int) time.Duration {
+	d := time.Duration(p.BackoffMs) * time.Millisecond
+	limit := time.Duration(p.MaxBackoffMs) * time.Millisecond
+	for i := 0; i < n && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	return d
+}
+
+// SetRestartPolicy 设置进程的自动重启策略，对下一次退出生效
+func (pm *ProcessManager) SetRestartPolicy(policy RestartPolicy) error {
+	policy, err := policy.normalize()
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.restartPolicy = policy
+	// 策略改为不重启时取消待执行的重启
+	if policy.Mode == RestartNever && pm.supervisorState == SupervisorBackoff {
+		pm.cancelRestart()
+		pm.supervisorState = SupervisorExited
+	}
+	return nil
+}
+
+// GetRestartPolicy 获取进程的自动重启策略
+func (pm *ProcessManager) GetRestartPolicy() RestartPolicy {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.restartPolicy
+}
+
+// GetSupervisorStatus 获取守护器的运行状况
+func (pm *ProcessManager) GetSupervisorStatus() SupervisorStatus {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.pruneRestarts(time.Now())
+	status := SupervisorStatus{
+		State:         pm.supervisorState,
+		Policy:        pm.restartPolicy,
+		Restarts:      len(pm.restartHistory),
+		LastExitError: pm.lastExitError,
+	}
+	if pm.nextRestartAt != nil {
+		t := *pm.nextRestartAt
+		status.NextRestartAt = &t
+	}
+	return status
+}
+
+// handleExit 在进程退出后由底层回调触发，根据策略决定是否重启
+func (pm *ProcessManager) handleExit(proc IProcess, exitErr error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	// 已被替换的旧进程实例，忽略
+	if proc != pm.activeProcess {
+		return
+	}
+
+	pm.lastExitError = ""
+	if exitErr != nil {
+		pm.lastExitError = exitErr.Error()
+	}
+	pm.recordExit(proc, exitErr)
+
+	if pm.stopRequested {
+		pm.supervisorState = SupervisorIdle
+		return
+	}
+
+	if exitErr != nil {
+		vlogger.AppLogger.Warnf("进程 %s 异常退出: %v", pm.Path, exitErr)
+	} else {
+		vlogger.AppLogger.Infof("进程 %s 已退出", pm.Path)
+	}
+	pm.emitEvent(entity.ProcessEvent{Type: EventExited, Error: pm.lastExitError})
+
+	if !pm.restartPolicy.shouldRestart(exitErr) {
+		pm.supervisorState = SupervisorExited
+		return
+	}
+
+	now := time.Now()
+	pm.pruneRestarts(now)
+	if len(pm.restartHistory) >= pm.restartPolicy.MaxRestarts {
+		vlogger.AppLogger.Errorf("进程 %s 在 %d 秒内重启了 %d 次，判定为崩溃循环，停止自动重启",
+			pm.Path, pm.restartPolicy.WindowSec, len(pm.restartHistory))
+		pm.supervisorState = SupervisorCrashLoop
+		pm.emitEvent(entity.ProcessEvent{Type: EventCrashLoop, Error: pm.lastExitError})
+		return
+	}
+
+	delay := pm.restartPolicy.backoff(len(pm.restartHistory))
+	next := now.Add(delay)
+	pm.nextRestartAt = &next
+	pm.supervisorState = SupervisorBackoff
+	pm.emitEvent(entity.ProcessEvent{Type: EventRestarting})
+	vlogger.AppLogger.Infof("进程 %s 将在 %s 后自动重启", pm.Path, delay)
+
+	pm.restartTimer = time.AfterFunc(delay, func() { pm.restart(proc) })
+}
+
+// restart 在退避结束后重新启动进程
+func (pm *ProcessManager) restart(prev IProcess) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	// 等待期间被手动停止或重新启动
+	if prev != pm.activeProcess || pm.stopRequested || pm.supervisorState != SupervisorBackoff {
+		return
+	}
+
+	pm.restartTimer = nil
+	pm.nextRestartAt = nil
+	pm.restartHistory = append(pm.restartHistory, time.Now())
+
+	vlogger.AppLogger.Infof("正在自动重启进程 %s (窗口内第 %d 次)", pm.Path, len(pm.restartHistory))
+	if err := pm.startLocked(); err != nil {
+		vlogger.AppLogger.Errorf("自动重启进程失败: %v", err)
+		// 启动失败同样计入重启次数，按策略继续退避
+		pm.activeProcess = prev
+		go pm.handleExit(prev, err)
+	}
+}
+
+// pruneRestarts 移除时间窗口之外的重启记录，调用方需持有写锁
+func (pm *ProcessManager) pruneRestarts(now time.Time) {
+	window := time.Duration(pm.restartPolicy.WindowSec) * time.Second
+	kept := pm.restartHistory[:0]
+	for _, t := range pm.restartHistory {
+		if now.Sub(t) < window {
+			kept = append(kept, t)
+		}
+	}
+	pm.restartHistory = kept
+}
+
+// cancelRestart 取消待执行的自动重启，调用方需持有写锁
+func (pm *ProcessManager) cancelRestart() {
+	if pm.restartTimer != nil {
+		pm.restartTimer.Stop()
+		pm.restartTimer = nil
+	}
+	pm.nextRestartAt = nil
+}
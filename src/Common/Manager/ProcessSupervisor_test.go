@@ -0,0 +1,188 @@
+package v_manager
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRestartPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RestartPolicy
+		want   []time.Duration // 第 0、1、2... 次重启前的等待时间
+	}{
+		{"defaults", RestartPolicy{}, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}},
+		{"doubles up to limit", RestartPolicy{BackoffMs: 1000, MaxBackoffMs: 5000}, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"limit below backoff", RestartPolicy{BackoffMs: 3000, MaxBackoffMs: 1000}, []time.Duration{3 * time.Second, 3 * time.Second}},
+		{"many restarts", RestartPolicy{BackoffMs: 1, MaxBackoffMs: 60000}, []time.Duration{time.Millisecond, 2 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := tt.policy.normalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for n, want := range tt.want {
+				if got := policy.backoff(n); got != want {
+					t.Errorf("backoff(%d) = %s, want %s", n, got, want)
+				}
+			}
+			// 次数很大时不会溢出，始终停在上限
+			if got, limit := policy.backoff(1000), time.Duration(policy.MaxBackoffMs)*time.Millisecond; got != limit {
+				t.Errorf("backoff(1000) = %s, want %s", got, limit)
+			}
+		})
+	}
+}
+
+func TestRestartPolicyShouldRestart(t *testing.T) {
+	crash := errors.New("exit status 1")
+	tests := []struct {
+		mode    RestartMode
+		exitErr error
+		want    bool
+	}{
+		{RestartNever, nil, false},
+		{RestartNever, crash, false},
+		{RestartOnFailure, nil, false},
+		{RestartOnFailure, crash, true},
+		{RestartAlways, nil, true},
+		{RestartAlways, crash, true},
+	}
+	for _, tt := range tests {
+		policy, err := RestartPolicy{Mode: tt.mode}.normalize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := policy.shouldRestart(tt.exitErr); got != tt.want {
+			t.Errorf("%s.shouldRestart(%v) = %v, want %v", tt.mode, tt.exitErr, got, tt.want)
+		}
+	}
+
+	if _, err := (RestartPolicy{Mode: "sometimes"}).normalize(); err == nil {
+		t.Error("不支持的重启策略应返回错误")
+	}
+}
+
+func TestPruneRestarts(t *testing.T) {
+	now := time.Now()
+	pm := NewProcessManager(Ordinary, "server")
+	_ = pm.SetRestartPolicy(RestartPolicy{WindowSec: 60})
+	pm.restartHistory = []time.Time{
+		now.Add(-2 * time.Minute),
+		now.Add(-60 * time.Second), // 恰好在窗口边界上，视为窗口之外
+		now.Add(-59 * time.Second),
+		now.Add(-time.Second),
+	}
+
+	pm.pruneRestarts(now)
+	if len(pm.restartHistory) != 2 || !pm.restartHistory[0].Equal(now.Add(-59*time.Second)) {
+		t.Errorf("pruneRestarts 后剩余 %v", pm.restartHistory)
+	}
+}
+
+func TestSupervisorCrashLoop(t *testing.T) {
+	pm := newFakeServer(t, "crash")
+	if err := pm.SetRestartPolicy(RestartPolicy{Mode: RestartOnFailure, BackoffMs: 50, MaxRestarts: 2, WindowSec: 60}); err != nil {
+		t.Fatal(err)
+	}
+	events := recordEvents(t, pm)
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	events.wait(t, EventCrashLoop, 10*time.Second)
+	want := []string{
+		EventStarted, EventExited, EventRestarting,
+		EventStarted, EventExited, EventRestarting,
+		EventStarted, EventExited, EventCrashLoop,
+	}
+	got := events.snapshot()
+	if !equalStrings(events.types(), want) {
+		t.Fatalf("事件 = %v, want %v", events.types(), want)
+	}
+
+	// 两次重启之间的等待时间按 50、100 毫秒翻倍
+	for i, backoff := range []int64{50, 100} {
+		restarting, started := got[3*i+2], got[3*i+3]
+		if gap := started.Time - restarting.Time; gap < backoff {
+			t.Errorf("第 %d 次重启只等待了 %d 毫秒, want >= %d", i+1, gap, backoff)
+		}
+	}
+
+	status := pm.GetSupervisorStatus()
+	if status.State != SupervisorCrashLoop || status.Restarts != 2 || status.LastExitError == "" || status.NextRestartAt != nil {
+		t.Errorf("守护器状态 = %+v", status)
+	}
+
+	// 手动启动会清空重启记录，重新开始计数
+	if err := pm.Start(nil); err != nil {
+		t.Fatal(err)
+	}
+	if status := pm.GetSupervisorStatus(); status.State != SupervisorRunning || status.Restarts != 0 {
+		t.Errorf("重新启动后守护器状态 = %+v", status)
+	}
+}
+
+func TestSupervisorPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      RestartMode
+		exitCode  string
+		wantState SupervisorState
+	}{
+		{"never after crash", RestartNever, "1", SupervisorExited},
+		{"on-failure after clean exit", RestartOnFailure, "0", SupervisorExited},
+		{"on-failure after crash", RestartOnFailure, "1", SupervisorBackoff},
+		{"always after clean exit", RestartAlways, "0", SupervisorBackoff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newFakeServer(t, "server")
+			// 退避时间足够长，检查状态时还未重启
+			if err := pm.SetRestartPolicy(RestartPolicy{Mode: tt.mode, BackoffMs: 60000}); err != nil {
+				t.Fatal(err)
+			}
+			events := recordEvents(t, pm)
+			if err := pm.Start(nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := pm.SendCommand("exit " + tt.exitCode); err != nil {
+				t.Fatal(err)
+			}
+			events.wait(t, EventExited, 5*time.Second)
+
+			status := pm.GetSupervisorStatus()
+			if status.State != tt.wantState {
+				t.Fatalf("守护器状态 = %s, want %s", status.State, tt.wantState)
+			}
+			if tt.wantState != SupervisorBackoff {
+				return
+			}
+			if status.NextRestartAt == nil || time.Until(*status.NextRestartAt) < 50*time.Second {
+				t.Errorf("下次重启时间 = %v", status.NextRestartAt)
+			}
+
+			// 退避期间手动停止会取消重启
+			if _, err := pm.Stop(); err != nil {
+				t.Fatal(err)
+			}
+			if status := pm.GetSupervisorStatus(); status.State != SupervisorIdle || status.NextRestartAt != nil {
+				t.Errorf("停止后守护器状态 = %+v", status)
+			}
+		})
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,316 @@
+package v_manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	vdata "voxesis/src/Common/Data"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// 规则动作类型
+const (
+	RuleActionCommand = "command" // 向触发规则的进程发送命令
+	RuleActionEvent   = "event"   // 发出 rule-matched 事件
+	RuleActionWebhook = "webhook" // 以 POST 发送匹配结果
+	RuleActionStop    = "stop"    // 停止触发规则的进程
+	RuleActionRestart = "restart" // 重启触发规则的进程
+)
+
+// EventRuleMatched 规则的 event 动作发出的事件
+const EventRuleMatched = "rule-matched"
+
+// ruleWebhookTimeout webhook 请求的超时时间
+const ruleWebhookTimeout = 10 * time.Second
+
+// minActionCooldown 含命令、停止或重启动作的规则的最短冷却时间，
+// 避免命令的输出或重启时的输出再次匹配规则而无限循环
+const minActionCooldown = time.Second
+
+// RuleTarget 规则动作作用的进程
+type RuleTarget interface {
+	SendCommand(processId int, command string) error
+	StopProcess(processId int) error
+	RestartProcess(processId int) error
+	EmitEvent(processId int, event entity.ProcessEvent)
+}
+
+// compiledRule 编译好正则表达式的规则
+type compiledRule struct {
+	entity.Rule
+	pattern   *regexp.Regexp
+	processes map[int]bool  // 为 nil 表示所有进程
+	cooldown  time.Duration // 实际生效的冷却时间
+}
+
+// cooldownKey 冷却按规则与进程分别计算
+type cooldownKey struct {
+	ruleId    int
+	processId int
+}
+
+// RuleEngine 用规则匹配所有进程的输出，匹配后在后台依次执行规则的动作
+type RuleEngine struct {
+	store  vdata.RuleStore
+	target RuleTarget
+	client *http.Client
+
+	mu        sync.RWMutex
+	rules     map[int]*compiledRule
+	lastFired map[cooldownKey]time.Time
+	cooldown  sync.Mutex // 保护 lastFired
+}
+
+// NewRuleEngine 创建规则引擎并加载已保存的规则，无法编译的规则会被跳过
+func NewRuleEngine(store vdata.RuleStore, target RuleTarget) (*RuleEngine, error) {
+	rules, err := store.ListRules()
+	if err != nil {
+		return nil, fmt.Errorf("读取规则失败: %w", err)
+	}
+
+	engine := &RuleEngine{
+		store:     store,
+		target:    target,
+		client:    &http.Client{Timeout: ruleWebhookTimeout},
+		rules:     make(map[int]*compiledRule),
+		lastFired: make(map[cooldownKey]time.Time),
+	}
+	for _, rule := range rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			vlogger.AppLogger.Errorf("加载ID为 %d 的规则失败: %v", rule.Id, err)
+			continue
+		}
+		engine.rules[rule.Id] = compiled
+	}
+	return engine, nil
+}
+
+// compileRule 校验规则并编译正则表达式
+func compileRule(rule entity.Rule) (*compiledRule, error) {
+	pattern, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("无效的正则表达式: %w", err)
+	}
+	if rule.CooldownMs < 0 {
+		return nil, fmt.Errorf("冷却时间不能为负数")
+	}
+	if len(rule.Actions) == 0 {
+		return nil, fmt.Errorf("规则至少需要一个动作")
+	}
+	for i, action := range rule.Actions {
+		switch action.Type {
+		case RuleActionCommand:
+			if action.Command == "" {
+				return nil, fmt.Errorf("第 %d 个动作缺少命令", i+1)
+			}
+		case RuleActionEvent:
+			if action.Event == "" {
+				return nil, fmt.Errorf("第 %d 个动作缺少事件名", i+1)
+			}
+		case RuleActionWebhook:
+			parsed, err := url.Parse(action.Url)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return nil, fmt.Errorf("第 %d 个动作的 webhook 地址无效: %s", i+1, action.Url)
+			}
+		case RuleActionStop, RuleActionRestart:
+		default:
+			return nil, fmt.Errorf("第 %d 个动作的类型无效: %s", i+1, action.Type)
+		}
+	}
+
+	compiled := &compiledRule{Rule: rule, pattern: pattern, cooldown: time.Duration(rule.CooldownMs) * time.Millisecond}
+	for _, action := range rule.Actions {
+		if action.Type == RuleActionCommand || action.Type == RuleActionStop || action.Type == RuleActionRestart {
+			compiled.cooldown = max(compiled.cooldown, minActionCooldown)
+		}
+	}
+	if len(rule.ProcessIds) > 0 {
+		compiled.processes = make(map[int]bool, len(rule.ProcessIds))
+		for _, id := range rule.ProcessIds {
+			compiled.processes[id] = true
+		}
+	}
+	return compiled, nil
+}
+
+// ListRules 按ID顺序列出所有规则
+func (e *RuleEngine) ListRules() []entity.Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	rules := make([]entity.Rule, 0, len(e.rules))
+	for _, rule := range e.rules {
+		rules = append(rules, rule.Rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Id < rules[j].Id })
+	return rules
+}
+
+// GetRule 获取指定ID的规则
+func (e *RuleEngine) GetRule(id int) (entity.Rule, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	rule, ok := e.rules[id]
+	if !ok {
+		return entity.Rule{}, fmt.Errorf("ID为 %d 的规则不存在", id)
+	}
+	return rule.Rule, nil
+}
+
+// CreateRule 校验并保存新规则，返回分配了ID的规则
+func (e *RuleEngine) CreateRule(rule entity.Rule) (entity.Rule, error) {
+	compiled, err := compileRule(rule)
+	if err != nil {
+		return rule, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.store.CreateRule(&compiled.Rule); err != nil {
+		return rule, fmt.Errorf("保存规则失败: %w", err)
+	}
+	e.rules[compiled.Id] = compiled
+	return compiled.Rule, nil
+}
+
+// UpdateRule 校验并替换已有的规则，冷却状态保持不变
+func (e *RuleEngine) UpdateRule(rule entity.Rule) error {
+	compiled, err := compileRule(rule)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.rules[rule.Id]; !ok {
+		return fmt.Errorf("ID为 %d 的规则不存在", rule.Id)
+	}
+	if err := e.store.UpdateRule(&compiled.Rule); err != nil {
+		return fmt.Errorf("保存规则失败: %w", err)
+	}
+	e.rules[rule.Id] = compiled
+	return nil
+}
+
+// DeleteRule 删除指定ID的规则
+func (e *RuleEngine) DeleteRule(id int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.rules[id]; !ok {
+		return fmt.Errorf("ID为 %d 的规则不存在", id)
+	}
+	if err := e.store.DeleteRule(id); err != nil {
+		return fmt.Errorf("删除规则失败: %w", err)
+	}
+	delete(e.rules, id)
+
+	e.cooldown.Lock()
+	for key := range e.lastFired {
+		if key.ruleId == id {
+			delete(e.lastFired, key)
+		}
+	}
+	e.cooldown.Unlock()
+	return nil
+}
+
+// Observe 用所有启用的规则匹配进程的一行输出
+// 在输出回调中同步调用，动作在后台执行，以免停止或重启进程时等待输出回调自身
+func (e *RuleEngine) Observe(processId int, line entity.OutputLine) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, rule := range e.rules {
+		if !rule.Enabled || (rule.processes != nil && !rule.processes[processId]) {
+			continue
+		}
+		groups := rule.pattern.FindStringSubmatchIndex(line.Data)
+		if groups == nil || !e.acquire(rule, processId) {
+			continue
+		}
+		go e.run(rule, processId, line, groups)
+	}
+}
+
+// acquire 检查并更新冷却时间，返回规则是否可以触发
+func (e *RuleEngine) acquire(rule *compiledRule, processId int) bool {
+	e.cooldown.Lock()
+	defer e.cooldown.Unlock()
+
+	key := cooldownKey{ruleId: rule.Id, processId: processId}
+	now := time.Now()
+	if last, ok := e.lastFired[key]; ok && now.Sub(last) < rule.cooldown {
+		return false
+	}
+	e.lastFired[key] = now
+	return true
+}
+
+// run 依次执行规则的动作，某个动作失败时记录错误并继续执行后续动作
+func (e *RuleEngine) run(rule *compiledRule, processId int, line entity.OutputLine, groups []int) {
+	match := entity.RuleMatch{
+		RuleId:    rule.Id,
+		RuleName:  rule.Name,
+		ProcessId: processId,
+		Line:      line.Data,
+		Groups:    make([]string, 0, len(groups)/2),
+		Time:      time.Now().UnixMilli(),
+	}
+	for i := 0; i+1 < len(groups); i += 2 {
+		if groups[i] < 0 {
+			match.Groups = append(match.Groups, "")
+			continue
+		}
+		match.Groups = append(match.Groups, line.Data[groups[i]:groups[i+1]])
+	}
+	vlogger.AppLogger.Infof("进程 %d 的输出触发了规则 %d (%s)", processId, rule.Id, rule.Name)
+
+	for _, action := range rule.Actions {
+		var err error
+		switch action.Type {
+		case RuleActionCommand:
+			command := string(rule.pattern.ExpandString(nil, action.Command, line.Data, groups))
+			err = e.target.SendCommand(processId, command)
+		case RuleActionEvent:
+			event := match
+			event.Event = action.Event
+			e.target.EmitEvent(processId, entity.ProcessEvent{Type: EventRuleMatched, Time: match.Time, Rule: &event})
+		case RuleActionWebhook:
+			err = e.postWebhook(action.Url, match)
+		case RuleActionStop:
+			err = e.target.StopProcess(processId)
+		case RuleActionRestart:
+			err = e.target.RestartProcess(processId)
+		}
+		if err != nil {
+			vlogger.AppLogger.Errorf("规则 %d 的 %s 动作执行失败: %v", rule.Id, action.Type, err)
+		}
+	}
+}
+
+// postWebhook 以 JSON 发送匹配结果，非 2xx 响应视为失败
+func (e *RuleEngine) postWebhook(target string, match entity.RuleMatch) error {
+	body, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook 返回 %s", resp.Status)
+	}
+	return nil
+}
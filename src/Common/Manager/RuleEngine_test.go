@@ -0,0 +1,136 @@
+package v_manager
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+// memoryRuleStore 保存在内存中的规则
+type memoryRuleStore struct {
+	rules  []entity.Rule
+	nextId int
+}
+
+func (s *memoryRuleStore) ListRules() ([]entity.Rule, error) { return s.rules, nil }
+
+func (s *memoryRuleStore) CreateRule(rule *entity.Rule) error {
+	s.nextId++
+	rule.Id = s.nextId
+	return nil
+}
+
+func (s *memoryRuleStore) UpdateRule(rule *entity.Rule) error { return nil }
+
+func (s *memoryRuleStore) DeleteRule(id int) error { return nil }
+
+// recordingRuleTarget 记录规则执行的动作
+type recordingRuleTarget struct {
+	mu      sync.Mutex
+	actions []string
+}
+
+func (t *recordingRuleTarget) record(action string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.actions = append(t.actions, action)
+}
+
+func (t *recordingRuleTarget) SendCommand(processId int, command string) error {
+	t.record("command " + command)
+	return nil
+}
+
+func (t *recordingRuleTarget) StopProcess(processId int) error {
+	t.record("stop")
+	return nil
+}
+
+func (t *recordingRuleTarget) RestartProcess(processId int) error {
+	t.record("restart")
+	return nil
+}
+
+func (t *recordingRuleTarget) EmitEvent(processId int, event entity.ProcessEvent) {
+	t.record("event " + event.Rule.Event)
+}
+
+func (t *recordingRuleTarget) snapshot() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.actions...)
+}
+
+func TestCompileRuleCooldown(t *testing.T) {
+	tests := []struct {
+		name       string
+		cooldownMs int64
+		actions    []entity.RuleAction
+		want       time.Duration
+	}{
+		{"event without cooldown", 0, []entity.RuleAction{{Type: RuleActionEvent, Event: "joined"}}, 0},
+		{"command without cooldown", 0, []entity.RuleAction{{Type: RuleActionCommand, Command: "say hi"}}, minActionCooldown},
+		{"restart without cooldown", 0, []entity.RuleAction{{Type: RuleActionEvent, Event: "crash"}, {Type: RuleActionRestart}}, minActionCooldown},
+		{"stop with short cooldown", 100, []entity.RuleAction{{Type: RuleActionStop}}, minActionCooldown},
+		{"command with long cooldown", 5000, []entity.RuleAction{{Type: RuleActionCommand, Command: "say hi"}}, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, err := compileRule(entity.Rule{Pattern: "x", CooldownMs: tt.cooldownMs, Actions: tt.actions})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if compiled.cooldown != tt.want {
+				t.Errorf("冷却时间 = %s, want %s", compiled.cooldown, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuleEngineCommandLoop(t *testing.T) {
+	target := &recordingRuleTarget{}
+	engine, err := NewRuleEngine(&memoryRuleStore{}, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 命令的输出再次匹配规则自身
+	if _, err := engine.CreateRule(entity.Rule{
+		Name:    "echo",
+		Enabled: true,
+		Pattern: `hello`,
+		Actions: []entity.RuleAction{{Type: RuleActionCommand, Command: "say hello"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := engine.CreateRule(entity.Rule{
+		Name:    "notify",
+		Enabled: true,
+		Pattern: `hello`,
+		Actions: []entity.RuleAction{{Type: RuleActionEvent, Event: "greeted"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 3 {
+		engine.Observe(1, entity.OutputLine{Data: "[Server] hello"})
+	}
+	// 冷却按进程分别计算
+	engine.Observe(2, entity.OutputLine{Data: "[Server] hello"})
+
+	want := map[string]int{"command say hello": 2, "event greeted": 4}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && len(target.snapshot()) < 6 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	got := map[string]int{}
+	for _, action := range target.snapshot() {
+		got[action]++
+	}
+	if len(got) != len(want) || got["command say hello"] != want["command say hello"] || got["event greeted"] != want["event greeted"] {
+		t.Errorf("执行的动作 = %v, want %v", got, want)
+	}
+}
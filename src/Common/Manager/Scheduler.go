@@ -0,0 +1,456 @@
+package v_manager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	_ "time/tzdata" // Windows 上没有系统时区数据库，嵌入一份以支持按时区调度
+
+	vdata "voxesis/src/Common/Data"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vutils "voxesis/src/Common/Utils"
+)
+
+// 定时任务类型
+const (
+	JobTypeCommand = "command" // 向进程发送命令
+	JobTypeRestart = "restart" // 重启进程，可以先倒计时广播
+	JobTypeStart   = "start"   // 启动进程
+	JobTypeStop    = "stop"    // 停止进程
+	JobTypeBackup  = "backup"  // 备份进程的服务器目录
+)
+
+// 错过执行的处理策略，Voxesis 未运行或系统休眠期间到期的执行视为错过
+const (
+	MissedPolicySkip = "skip" // 不补执行，只记录一条 missed 记录
+	MissedPolicyOnce = "once" // 补执行一次，多次错过同样只执行一次
+)
+
+// 执行记录状态
+const (
+	JobRunRunning = "running"
+	JobRunSuccess = "success"
+	JobRunFailed  = "failed"
+	JobRunMissed  = "missed"
+)
+
+const (
+	// scheduleGrace 到期后超过该时间才被处理的执行视为错过
+	scheduleGrace = time.Minute
+	// scheduleMaxWait 调度循环的最长等待时间，系统时间被调整或休眠后也能及时发现到期的任务
+	scheduleMaxWait = time.Minute
+	// scheduleMissedScan 统计错过的执行次数时最多计算的次数
+	scheduleMissedScan = 100000
+	// jobRunHistoryLimit 每个任务保留的执行记录数
+	jobRunHistoryLimit = 200
+)
+
+// ScheduleTarget 定时任务作用的进程
+type ScheduleTarget interface {
+	SendCommand(processId int, command string) error
+	StartProcess(processId int) error
+	StopProcess(processId int) error
+	RestartProcess(processId int, delay time.Duration) error
+	Backup(processId int) error
+}
+
+// scheduledJob 解析好 cron 表达式与时区的定时任务
+type scheduledJob struct {
+	entity.ScheduledJob
+	schedule *vutils.CronSchedule
+	location *time.Location
+}
+
+// Scheduler 按 cron 表达式执行定时任务
+// 每次执行前先在存储中写入执行记录并推进下一次执行的时间，Voxesis 重启后不会重复执行同一计划时间
+type Scheduler struct {
+	store  vdata.ScheduleStore
+	target ScheduleTarget
+
+	mu      sync.Mutex
+	jobs    map[int]*scheduledJob
+	running map[int]bool // 正在执行的任务，同一任务不会并发执行
+
+	wake chan struct{}
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewScheduler 创建调度器并加载已保存的任务，无法解析的任务会被跳过
+// 上次退出时仍在执行的记录标记为失败，不会重新执行
+func NewScheduler(store vdata.ScheduleStore, target ScheduleTarget) (*Scheduler, error) {
+	if err := store.FailRunningRuns("Voxesis 在任务执行期间退出", time.Now().UnixMilli()); err != nil {
+		return nil, fmt.Errorf("更新中断的执行记录失败: %w", err)
+	}
+
+	jobs, err := store.ListJobs()
+	if err != nil {
+		return nil, fmt.Errorf("读取定时任务失败: %w", err)
+	}
+
+	s := &Scheduler{
+		store:   store,
+		target:  target,
+		jobs:    make(map[int]*scheduledJob),
+		running: make(map[int]bool),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+	}
+	for _, job := range jobs {
+		compiled, err := compileJob(job)
+		if err != nil {
+			vlogger.AppLogger.Errorf("加载ID为 %d 的定时任务失败: %v", job.Id, err)
+			continue
+		}
+		s.jobs[job.Id] = compiled
+	}
+	return s, nil
+}
+
+// compileJob 校验任务并解析 cron 表达式与时区
+func compileJob(job entity.ScheduledJob) (*scheduledJob, error) {
+	schedule, err := vutils.ParseCron(job.Cron)
+	if err != nil {
+		return nil, err
+	}
+	location := time.Local
+	if job.Timezone != "" {
+		if location, err = time.LoadLocation(job.Timezone); err != nil {
+			return nil, fmt.Errorf("无效的时区: %s", job.Timezone)
+		}
+	}
+
+	switch job.Type {
+	case JobTypeCommand:
+		if job.Command == "" {
+			return nil, fmt.Errorf("command 任务缺少命令")
+		}
+	case JobTypeRestart, JobTypeStart, JobTypeStop, JobTypeBackup:
+	default:
+		return nil, fmt.Errorf("无效的任务类型: %s", job.Type)
+	}
+	if job.ProcessId <= 0 {
+		return nil, fmt.Errorf("任务缺少进程ID")
+	}
+	if job.DelaySec < 0 {
+		return nil, fmt.Errorf("倒计时不能为负数")
+	}
+
+	switch job.MissedPolicy {
+	case "":
+		job.MissedPolicy = MissedPolicySkip
+	case MissedPolicySkip, MissedPolicyOnce:
+	default:
+		return nil, fmt.Errorf("无效的错过执行策略: %s", job.MissedPolicy)
+	}
+
+	return &scheduledJob{ScheduledJob: job, schedule: schedule, location: location}, nil
+}
+
+// nextAfter 返回任务在 t 之后的下一次执行时间，禁用或不会再触发时返回 nil
+func (j *scheduledJob) nextAfter(t time.Time) *int64 {
+	if !j.Enabled {
+		return nil
+	}
+	next := j.schedule.Next(t, j.location)
+	if next.IsZero() {
+		return nil
+	}
+	ms := next.UnixMilli()
+	return &ms
+}
+
+// Start 在后台开始调度，启动前错过的执行按各任务的策略处理
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Stop 停止调度，正在执行的任务不受影响，可重复调用
+func (s *Scheduler) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+}
+
+// ListJobs 按ID顺序列出所有任务
+func (s *Scheduler) ListJobs() []entity.ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]entity.ScheduledJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job.ScheduledJob)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+	return jobs
+}
+
+// GetJob 获取指定ID的任务
+func (s *Scheduler) GetJob(id int) (entity.ScheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return entity.ScheduledJob{}, fmt.Errorf("ID为 %d 的定时任务不存在", id)
+	}
+	return job.ScheduledJob, nil
+}
+
+// CreateJob 校验并保存新任务，返回分配了ID的任务
+func (s *Scheduler) CreateJob(job entity.ScheduledJob) (entity.ScheduledJob, error) {
+	job.LastRun = nil
+	compiled, err := compileJob(job)
+	if err != nil {
+		return job, err
+	}
+	compiled.NextRunAt = compiled.nextAfter(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.store.CreateJob(&compiled.ScheduledJob); err != nil {
+		return job, fmt.Errorf("保存定时任务失败: %w", err)
+	}
+	s.jobs[compiled.Id] = compiled
+	s.notify()
+	return compiled.ScheduledJob, nil
+}
+
+// UpdateJob 校验并替换已有的任务，下一次执行的时间从现在起重新计算
+func (s *Scheduler) UpdateJob(job entity.ScheduledJob) error {
+	compiled, err := compileJob(job)
+	if err != nil {
+		return err
+	}
+	compiled.NextRunAt = compiled.nextAfter(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.jobs[job.Id]
+	if !ok {
+		return fmt.Errorf("ID为 %d 的定时任务不存在", job.Id)
+	}
+	compiled.LastRun = existing.LastRun
+	if err := s.store.UpdateJob(&compiled.ScheduledJob); err != nil {
+		return fmt.Errorf("保存定时任务失败: %w", err)
+	}
+	s.jobs[job.Id] = compiled
+	s.notify()
+	return nil
+}
+
+// DeleteJob 删除指定ID的任务及其执行记录，正在进行的执行不受影响
+func (s *Scheduler) DeleteJob(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[id]; !ok {
+		return fmt.Errorf("ID为 %d 的定时任务不存在", id)
+	}
+	if err := s.store.DeleteJob(id); err != nil {
+		return fmt.Errorf("删除定时任务失败: %w", err)
+	}
+	delete(s.jobs, id)
+	return nil
+}
+
+// ListRuns 按计划时间倒序列出任务的执行记录，limit 为 0 时不限制
+func (s *Scheduler) ListRuns(id int, limit int) ([]entity.JobRun, error) {
+	if _, err := s.GetJob(id); err != nil {
+		return nil, err
+	}
+	return s.store.ListRuns(id, limit)
+}
+
+// RunJob 立即执行一次任务，不影响下一次计划执行的时间，禁用的任务同样可以执行
+func (s *Scheduler) RunJob(id int) (entity.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return entity.JobRun{}, fmt.Errorf("ID为 %d 的定时任务不存在", id)
+	}
+	if s.running[id] {
+		return entity.JobRun{}, fmt.Errorf("ID为 %d 的定时任务正在执行", id)
+	}
+	now := time.Now()
+	run, ok := s.fire(job, now, now, job.NextRunAt)
+	if !ok {
+		return entity.JobRun{}, fmt.Errorf("写入执行记录失败")
+	}
+	return run, nil
+}
+
+// notify 唤醒调度循环重新计算等待时间
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 调度循环
+func (s *Scheduler) run() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-timer.C:
+		}
+		timer.Reset(s.dispatch(time.Now()))
+	}
+}
+
+// dispatch 执行所有到期的任务，返回距离下一个任务到期的时间
+func (s *Scheduler) dispatch(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := scheduleMaxWait
+	for _, job := range s.jobs {
+		if job.NextRunAt == nil {
+			continue
+		}
+		if *job.NextRunAt <= now.UnixMilli() {
+			s.due(job, now)
+		}
+		if job.NextRunAt != nil {
+			if d := time.UnixMilli(*job.NextRunAt).Sub(now); d < wait {
+				wait = d
+			}
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// due 处理到期的任务，调用方需持有锁
+// 最近一次到期在宽限时间内时正常执行，更早的到期视为错过，按任务的策略补执行或只记录
+func (s *Scheduler) due(job *scheduledJob, now time.Time) {
+	first := time.UnixMilli(*job.NextRunAt)
+	last, count := first, 1
+	for count < scheduleMissedScan {
+		next := job.schedule.Next(last, job.location)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		last = next
+		count++
+	}
+	next := job.nextAfter(now)
+
+	fire := now.Sub(last) <= scheduleGrace || job.MissedPolicy == MissedPolicyOnce
+	missed := count
+	if fire {
+		missed--
+	}
+	if missed > 0 {
+		at := now.UnixMilli()
+		run := entity.JobRun{
+			JobId:       job.Id,
+			ScheduledAt: first.UnixMilli(),
+			StartedAt:   at,
+			FinishedAt:  &at,
+			Status:      JobRunMissed,
+			Error:       fmt.Sprintf("错过了 %d 次执行", missed),
+		}
+		if _, err := s.store.AddRun(&run, next); err != nil {
+			vlogger.AppLogger.Errorf("记录定时任务 %d 错过的执行失败: %v", job.Id, err)
+		}
+		vlogger.AppLogger.Warnf("定时任务 %d (%s) 错过了 %d 次执行", job.Id, job.Name, missed)
+		if !fire {
+			job.LastRun = &run
+		}
+	}
+
+	job.NextRunAt = next
+	if fire {
+		s.fire(job, last, now, next)
+	}
+}
+
+// fire 写入执行记录后在后台执行任务，调用方需持有锁
+// 同一计划时间已有记录时不执行，上一次执行尚未结束时记录为失败
+func (s *Scheduler) fire(job *scheduledJob, scheduledAt time.Time, now time.Time, next *int64) (entity.JobRun, bool) {
+	run := entity.JobRun{
+		JobId:       job.Id,
+		ScheduledAt: scheduledAt.UnixMilli(),
+		StartedAt:   now.UnixMilli(),
+		Status:      JobRunRunning,
+	}
+	if s.running[job.Id] {
+		at := now.UnixMilli()
+		run.Status, run.Error, run.FinishedAt = JobRunFailed, "上一次执行尚未结束", &at
+	}
+
+	claimed, err := s.store.AddRun(&run, next)
+	if err != nil {
+		vlogger.AppLogger.Errorf("写入定时任务 %d 的执行记录失败，本次不执行: %v", job.Id, err)
+		return run, false
+	}
+	if !claimed {
+		vlogger.AppLogger.Warnf("定时任务 %d 在 %s 的执行已有记录，跳过", job.Id, scheduledAt.Format(time.RFC3339))
+		return run, false
+	}
+	job.LastRun = &run
+	if run.Status != JobRunRunning {
+		return run, true
+	}
+
+	s.running[job.Id] = true
+	go s.execute(job.ScheduledJob, run)
+	return run, true
+}
+
+// execute 执行任务并记录结果
+func (s *Scheduler) execute(job entity.ScheduledJob, run entity.JobRun) {
+	vlogger.AppLogger.Infof("开始执行定时任务 %d (%s)", job.Id, job.Name)
+
+	var err error
+	switch job.Type {
+	case JobTypeCommand:
+		err = s.target.SendCommand(job.ProcessId, job.Command)
+	case JobTypeRestart:
+		err = s.target.RestartProcess(job.ProcessId, time.Duration(job.DelaySec)*time.Second)
+	case JobTypeStart:
+		err = s.target.StartProcess(job.ProcessId)
+	case JobTypeStop:
+		err = s.target.StopProcess(job.ProcessId)
+	case JobTypeBackup:
+		err = s.target.Backup(job.ProcessId)
+	}
+
+	finishedAt := time.Now().UnixMilli()
+	run.Status, run.FinishedAt = JobRunSuccess, &finishedAt
+	if err != nil {
+		run.Status, run.Error = JobRunFailed, err.Error()
+		vlogger.AppLogger.Errorf("定时任务 %d (%s) 执行失败: %v", job.Id, job.Name, err)
+	}
+	if err := s.store.FinishRun(run.Id, run.Status, run.Error, finishedAt); err != nil {
+		vlogger.AppLogger.Errorf("记录定时任务 %d 的执行结果失败: %v", job.Id, err)
+	}
+	if err := s.store.PruneRuns(job.Id, jobRunHistoryLimit); err != nil {
+		vlogger.AppLogger.Warnf("清理定时任务 %d 的执行记录失败: %v", job.Id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, job.Id)
+	if current, ok := s.jobs[job.Id]; ok && current.LastRun != nil && current.LastRun.Id == run.Id {
+		current.LastRun = &run
+	}
+}
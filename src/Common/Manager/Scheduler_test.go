@@ -0,0 +1,233 @@
+package v_manager
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"voxesis/src/Common/Entity"
+)
+
+// memoryScheduleStore 保存在内存中的任务与执行记录，与数据库一样按任务与计划时间去重
+type memoryScheduleStore struct {
+	mu        sync.Mutex
+	jobs      []entity.ScheduledJob
+	runs      []entity.JobRun
+	nextRunAt map[int]*int64
+}
+
+func newMemoryScheduleStore(jobs ...entity.ScheduledJob) *memoryScheduleStore {
+	return &memoryScheduleStore{jobs: jobs, nextRunAt: make(map[int]*int64)}
+}
+
+func (s *memoryScheduleStore) ListJobs() ([]entity.ScheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entity.ScheduledJob{}, s.jobs...), nil
+}
+
+func (s *memoryScheduleStore) CreateJob(job *entity.ScheduledJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.Id = len(s.jobs) + 1
+	s.jobs = append(s.jobs, *job)
+	return nil
+}
+
+func (s *memoryScheduleStore) UpdateJob(job *entity.ScheduledJob) error { return nil }
+
+func (s *memoryScheduleStore) DeleteJob(id int) error { return nil }
+
+func (s *memoryScheduleStore) AddRun(run *entity.JobRun, nextRunAt *int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.runs {
+		if existing.JobId == run.JobId && existing.ScheduledAt == run.ScheduledAt {
+			return false, nil
+		}
+	}
+	run.Id = int64(len(s.runs) + 1)
+	s.runs = append(s.runs, *run)
+	s.nextRunAt[run.JobId] = nextRunAt
+	return true, nil
+}
+
+func (s *memoryScheduleStore) FinishRun(id int64, status string, message string, finishedAt int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs[id-1].Status, s.runs[id-1].Error, s.runs[id-1].FinishedAt = status, message, &finishedAt
+	return nil
+}
+
+func (s *memoryScheduleStore) FailRunningRuns(message string, finishedAt int64) error { return nil }
+
+func (s *memoryScheduleStore) ListRuns(jobId int, limit int) ([]entity.JobRun, error) {
+	return nil, nil
+}
+
+func (s *memoryScheduleStore) PruneRuns(jobId int, keep int) error { return nil }
+
+func (s *memoryScheduleStore) snapshot() []entity.JobRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]entity.JobRun{}, s.runs...)
+}
+
+// recordingScheduleTarget 记录任务发送的命令
+type recordingScheduleTarget struct {
+	commands chan string
+}
+
+func (t *recordingScheduleTarget) SendCommand(processId int, command string) error {
+	t.commands <- command
+	return nil
+}
+
+func (t *recordingScheduleTarget) StartProcess(processId int) error { return nil }
+
+func (t *recordingScheduleTarget) StopProcess(processId int) error { return nil }
+
+func (t *recordingScheduleTarget) RestartProcess(processId int, delay time.Duration) error {
+	return nil
+}
+
+func (t *recordingScheduleTarget) Backup(processId int) error { return nil }
+
+// newTestScheduler 创建只有一个整点发送 save-all 的任务的调度器，任务的下一次执行时间为 nextRunAt
+func newTestScheduler(t *testing.T, policy string, nextRunAt time.Time) (*Scheduler, *memoryScheduleStore, *recordingScheduleTarget) {
+	t.Helper()
+	next := nextRunAt.UnixMilli()
+	store := newMemoryScheduleStore(entity.ScheduledJob{
+		Id: 1, Name: "save", Enabled: true, Cron: "0 * * * *", Timezone: "UTC",
+		Type: JobTypeCommand, ProcessId: 1, Command: "save-all", MissedPolicy: policy, NextRunAt: &next,
+	})
+	target := &recordingScheduleTarget{commands: make(chan string, 10)}
+	s, err := NewScheduler(store, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, store, target
+}
+
+// waitExecutions 等待任务执行 n 次，之后确认没有多余的执行
+func waitExecutions(t *testing.T, target *recordingScheduleTarget, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-target.commands:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("只执行了 %d 次, want %d", i, n)
+		}
+	}
+	select {
+	case command := <-target.commands:
+		t.Fatalf("多执行了一次 %q", command)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSchedulerDue(t *testing.T) {
+	hour := time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		policy      string
+		now         time.Time
+		wantFiredAt time.Time // 为零值表示不执行
+		wantMissed  string    // 错过记录的说明，为空表示没有错过记录
+	}{
+		{"on time", MissedPolicySkip, hour.Add(10 * time.Second), hour, ""},
+		{"within grace", MissedPolicySkip, hour.Add(scheduleGrace), hour, ""},
+		{"late skip", MissedPolicySkip, hour.Add(5 * time.Minute), time.Time{}, "错过了 1 次执行"},
+		{"late once", MissedPolicyOnce, hour.Add(5 * time.Minute), hour, ""},
+		{"many missed skip", MissedPolicySkip, hour.Add(3*time.Hour + 5*time.Minute), time.Time{}, "错过了 4 次执行"},
+		{"many missed once", MissedPolicyOnce, hour.Add(3*time.Hour + 5*time.Minute), hour.Add(3 * time.Hour), "错过了 3 次执行"},
+		{"latest within grace", MissedPolicySkip, hour.Add(2*time.Hour + 30*time.Second), hour.Add(2 * time.Hour), "错过了 2 次执行"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, store, target := newTestScheduler(t, tt.policy, hour)
+			s.mu.Lock()
+			job := s.jobs[1]
+			s.due(job, tt.now)
+			next := job.NextRunAt
+			s.mu.Unlock()
+
+			// 下一次执行时间总是在当前时间之后的第一个整点
+			wantNext := tt.now.Truncate(time.Hour).Add(time.Hour).UnixMilli()
+			if next == nil || *next != wantNext {
+				t.Errorf("NextRunAt = %v, want %d", next, wantNext)
+			}
+
+			var fired, missed []entity.JobRun
+			for _, run := range store.snapshot() {
+				if run.Status == JobRunMissed {
+					missed = append(missed, run)
+				} else {
+					fired = append(fired, run)
+				}
+			}
+			if tt.wantMissed == "" && len(missed) != 0 {
+				t.Errorf("错过记录 = %+v, want none", missed)
+			}
+			if tt.wantMissed != "" && (len(missed) != 1 || missed[0].Error != tt.wantMissed || missed[0].ScheduledAt != hour.UnixMilli()) {
+				t.Errorf("错过记录 = %+v, want %q", missed, tt.wantMissed)
+			}
+
+			if tt.wantFiredAt.IsZero() {
+				if len(fired) != 0 {
+					t.Errorf("执行记录 = %+v, want none", fired)
+				}
+				waitExecutions(t, target, 0)
+				return
+			}
+			if len(fired) != 1 || fired[0].ScheduledAt != tt.wantFiredAt.UnixMilli() {
+				t.Fatalf("执行记录 = %+v, want scheduled at %s", fired, tt.wantFiredAt)
+			}
+			waitExecutions(t, target, 1)
+		})
+	}
+}
+
+func TestSchedulerFireOnce(t *testing.T) {
+	hour := time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC)
+	s, store, target := newTestScheduler(t, MissedPolicyOnce, hour)
+
+	s.mu.Lock()
+	job := s.jobs[1]
+	if _, ok := s.fire(job, hour, hour, job.NextRunAt); !ok {
+		t.Fatal("第一次执行未写入记录")
+	}
+	s.mu.Unlock()
+	waitExecutions(t, target, 1)
+
+	// 模拟 Voxesis 重启后重新加载了同一计划时间的任务
+	restarted, err := NewScheduler(store, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted.mu.Lock()
+	restarted.due(restarted.jobs[1], hour.Add(time.Second))
+	restarted.mu.Unlock()
+	waitExecutions(t, target, 0)
+
+	if runs := store.snapshot(); len(runs) != 1 {
+		t.Errorf("执行记录 = %+v, want 1", runs)
+	}
+}
+
+func TestSchedulerFireWhileRunning(t *testing.T) {
+	hour := time.Date(2026, 1, 1, 4, 0, 0, 0, time.UTC)
+	s, store, _ := newTestScheduler(t, MissedPolicySkip, hour)
+
+	// 上一次执行尚未结束时不会并发执行，只记录失败
+	s.mu.Lock()
+	s.running[1] = true
+	run, ok := s.fire(s.jobs[1], hour, hour, nil)
+	s.mu.Unlock()
+	if !ok || run.Status != JobRunFailed || run.FinishedAt == nil {
+		t.Errorf("fire() = %+v, %v, want failed run", run, ok)
+	}
+	if runs := store.snapshot(); len(runs) != 1 || runs[0].Status != JobRunFailed {
+		t.Errorf("执行记录 = %+v", runs)
+	}
+}
@@ -0,0 +1,241 @@
+package v_utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// 归档格式
+const (
+	ArchiveZip    = "zip"
+	ArchiveTarZst = "tar.zst"
+)
+
+// archiveWriter 不同归档格式的统一写入接口
+type archiveWriter interface {
+	add(name string, info fs.FileInfo, file io.Reader) error
+	Close() error
+}
+
+// ArchiveFile 只写入前 Size 字节的文件，文件变短时以 0 补齐
+type ArchiveFile struct {
+	Path string // 完整路径
+	Size int64
+}
+
+// ArchiveSource 要写入归档的内容，归档中的路径相对于 Root
+type ArchiveSource struct {
+	Root    string
+	Paths   []string      // 完整写入的文件或目录，相对路径基于 Root
+	Exclude []string      // 遍历 Paths 时跳过的文件或目录及其中的内容，完整路径
+	Files   []ArchiveFile // 按指定长度写入的文件
+}
+
+// WriteArchive 将 source 写入归档，返回写入的文件数
+// 遍历期间被删除的文件会被跳过，服务器运行时可能在轮换日志等文件
+func WriteArchive(w io.Writer, format string, source ArchiveSource) (int, error) {
+	var archive archiveWriter
+	switch format {
+	case ArchiveZip:
+		archive = &zipArchive{zip.NewWriter(w)}
+	case ArchiveTarZst:
+		encoder, err := zstd.NewWriter(w)
+		if err != nil {
+			return 0, err
+		}
+		archive = &tarZstArchive{tar.NewWriter(encoder), encoder}
+	default:
+		return 0, fmt.Errorf("不支持的归档格式: %s", format)
+	}
+
+	files := 0
+	for _, path := range source.Paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(source.Root, path)
+		}
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) && file != path {
+					return nil
+				}
+				return err
+			}
+			if isExcluded(file, source.Exclude) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !entry.Type().IsRegular() && !entry.IsDir() {
+				return nil // 跳过符号链接、设备等特殊文件
+			}
+			info, err := entry.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			name := archiveName(source.Root, file)
+			if entry.IsDir() {
+				if name == "." {
+					return nil
+				}
+				return archive.add(name+"/", info, nil)
+			}
+			f, err := os.Open(file)
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := archive.add(name, info, f); err != nil {
+				return fmt.Errorf("写入 %s 失败: %w", file, err)
+			}
+			files++
+			return nil
+		})
+		if err != nil {
+			_ = archive.Close()
+			return files, err
+		}
+	}
+
+	for _, file := range source.Files {
+		if err := addTruncated(archive, source.Root, file); err != nil {
+			_ = archive.Close()
+			return files, fmt.Errorf("写入 %s 失败: %w", file.Path, err)
+		}
+		files++
+	}
+	return files, archive.Close()
+}
+
+// addTruncated 写入文件的前 file.Size 字节
+func addTruncated(archive archiveWriter, root string, file ArchiveFile) error {
+	f, err := os.Open(file.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	reader := io.LimitReader(io.MultiReader(f, zeroReader{}), file.Size)
+	return archive.add(archiveName(root, file.Path), sizedFileInfo{info, file.Size}, reader)
+}
+
+// sizedFileInfo 以指定大小代替文件实际大小
+type sizedFileInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i sizedFileInfo) Size() int64 {
+	return i.size
+}
+
+// IsWithinDir 判断 path 是否为 dir 或在 dir 中
+func IsWithinDir(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// isExcluded 判断文件是否在要跳过的路径中
+func isExcluded(file string, exclude []string) bool {
+	for _, path := range exclude {
+		if IsWithinDir(file, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveName 返回文件在归档中的路径，不在 root 下的文件以其所在目录名开头
+func archiveName(root string, file string) string {
+	if !IsWithinDir(file, root) {
+		return filepath.ToSlash(filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file)))
+	}
+	rel, _ := filepath.Rel(root, file)
+	return filepath.ToSlash(rel)
+}
+
+type zipArchive struct {
+	writer *zip.Writer
+}
+
+func (a *zipArchive) add(name string, info fs.FileInfo, file io.Reader) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if file != nil {
+		header.Method = zip.Deflate
+	}
+	w, err := a.writer.CreateHeader(header)
+	if err != nil || file == nil {
+		return err
+	}
+	_, err = io.Copy(w, file)
+	return err
+}
+
+func (a *zipArchive) Close() error {
+	return a.writer.Close()
+}
+
+type tarZstArchive struct {
+	writer  *tar.Writer
+	encoder *zstd.Encoder
+}
+
+// add 按打开时的大小写入文件，写入期间文件变长时截断，变短时以 0 补齐，保证 tar 结构完整
+func (a *tarZstArchive) add(name string, info fs.FileInfo, file io.Reader) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if err := a.writer.WriteHeader(header); err != nil || file == nil {
+		return err
+	}
+	n, err := io.Copy(a.writer, io.LimitReader(file, header.Size))
+	if err != nil {
+		return err
+	}
+	if n < header.Size {
+		_, err = io.CopyN(a.writer, zeroReader{}, header.Size-n)
+	}
+	return err
+}
+
+func (a *tarZstArchive) Close() error {
+	if err := a.writer.Close(); err != nil {
+		_ = a.encoder.Close()
+		return err
+	}
+	return a.encoder.Close()
+}
+
+// zeroReader 不断读出 0
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,188 @@
+package v_utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit 查找下一次触发时间时最多向后搜索的年数，超过视为永不触发 (如 2 月 30 日)
+const cronSearchLimit = 5
+
+// cronMacros 常用表达式的简写
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField 表达式中一个字段的取值范围与名称
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // 从 min 开始的取值名称
+}
+
+var (
+	cronMinute  = cronField{name: "分钟", min: 0, max: 59}
+	cronHour    = cronField{name: "小时", min: 0, max: 23}
+	cronDom     = cronField{name: "日", min: 1, max: 31}
+	cronMonth   = cronField{name: "月", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronWeekday = cronField{name: "星期", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// CronSchedule 解析后的 cron 表达式，字段依次为 分 时 日 月 星期
+// 日与星期都有限制时满足其一即可，与常见的 cron 实现一致
+type CronSchedule struct {
+	minute, hour, dom, month, weekday uint64 // 按位表示允许的取值
+	domAny, weekdayAny                bool
+}
+
+// ParseCron 解析标准的 5 字段 cron 表达式，支持 * , - / 、月份与星期的英文缩写以及 @daily 等简写
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron 表达式需要 5 个字段 (分 时 日 月 星期)，实际为 %d 个", len(fields))
+	}
+
+	schedule := &CronSchedule{}
+	var err error
+	if schedule.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = cronDom.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if schedule.weekday, err = cronWeekday.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// 7 与 0 都表示星期日
+	if schedule.weekday&(1<<7) != 0 {
+		schedule.weekday |= 1
+	}
+	schedule.domAny = fields[2] == "*" || fields[2] == "?"
+	schedule.weekdayAny = fields[4] == "*" || fields[4] == "?"
+	return schedule, nil
+}
+
+// parse 解析一个字段，返回按位表示的取值集合
+func (f cronField) parse(text string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(text, ",") {
+		rangeText, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s字段的步长无效: %s", f.name, part)
+			}
+			rangeText, step = part[:i], n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeText == "*" || rangeText == "?":
+		case strings.Contains(rangeText, "-"):
+			bounds := strings.SplitN(rangeText, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s字段的范围无效: %s", f.name, rangeText)
+			}
+		default:
+			value, err := f.value(rangeText)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			// 单个值带步长时表示从该值开始直到最大值，如 5/15
+			if strings.Contains(part, "/") {
+				high = f.max
+			} else {
+				high = value
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value 解析字段中的单个取值，可以是数字或英文缩写
+func (f cronField) value(text string) (int, error) {
+	lower := strings.ToLower(text)
+	for i, name := range f.names {
+		if lower == name {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s字段的取值无效: %s (范围 %d-%d)", f.name, text, f.min, f.max)
+	}
+	return n, nil
+}
+
+// Next 返回 after 之后 (不含) 在 loc 时区下的下一次触发时间，找不到时返回零值
+// 夏令时跳过的时刻不会触发，重复的时刻按实际经过的时间各匹配一次
+func (s *CronSchedule) Next(after time.Time, loc *time.Location) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute).In(loc)
+	limit := t.Year() + cronSearchLimit
+
+	for t.Year() <= limit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				next = t.Add(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches 检查日期是否满足日与星期字段
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	weekday := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.weekdayAny {
+		return dom && weekday
+	}
+	return dom || weekday
+}
@@ -0,0 +1,98 @@
+package v_utils
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"too few fields", "* * * *"},
+		{"too many fields", "* * * * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day zero", "0 0 0 * *"},
+		{"month out of range", "0 0 1 13 *"},
+		{"weekday out of range", "0 0 * * 8"},
+		{"zero step", "*/0 * * * *"},
+		{"bad step", "*/x * * * *"},
+		{"reversed range", "0 0 * * fri-mon"},
+		{"unknown name", "0 0 * foo *"},
+		{"unknown macro", "@never"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); err == nil {
+				t.Errorf("ParseCron(%q) 应返回错误", tt.expr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(year int, month time.Month, day, hour, min, sec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		expr  string
+		loc   *time.Location
+		after time.Time
+		want  time.Time // 零值表示永不触发
+	}{
+		{"every 15 minutes", "*/15 * * * *", time.UTC, utc(2026, 1, 1, 0, 0, 0), utc(2026, 1, 1, 0, 15, 0)},
+		{"after is exclusive", "@hourly", time.UTC, utc(2026, 1, 1, 1, 0, 0), utc(2026, 1, 1, 2, 0, 0)},
+		{"seconds are truncated", "@hourly", time.UTC, utc(2026, 1, 1, 0, 0, 30), utc(2026, 1, 1, 1, 0, 0)},
+		{"step from value", "5/20 * * * *", time.UTC, utc(2026, 1, 1, 0, 5, 0), utc(2026, 1, 1, 0, 25, 0)},
+		{"list", "0 6,18 * * *", time.UTC, utc(2026, 1, 1, 7, 0, 0), utc(2026, 1, 1, 18, 0, 0)},
+		{"weekday names", "0 9 * * mon-fri", time.UTC, utc(2026, 1, 2, 10, 0, 0), utc(2026, 1, 5, 9, 0, 0)},
+		{"sunday as 0", "0 0 * * 0", time.UTC, utc(2026, 1, 1, 0, 0, 0), utc(2026, 1, 4, 0, 0, 0)},
+		{"sunday as 7", "0 0 * * 7", time.UTC, utc(2026, 1, 1, 0, 0, 0), utc(2026, 1, 4, 0, 0, 0)},
+		{"day or weekday", "0 0 13 * fri", time.UTC, utc(2026, 1, 1, 0, 0, 0), utc(2026, 1, 2, 0, 0, 0)},
+		{"day or weekday by day", "0 0 13 * fri", time.UTC, utc(2026, 1, 10, 0, 0, 0), utc(2026, 1, 13, 0, 0, 0)},
+		{"skips short months", "0 0 31 * *", time.UTC, utc(2026, 2, 1, 0, 0, 0), utc(2026, 3, 31, 0, 0, 0)},
+		{"month name", "0 0 1 jan *", time.UTC, utc(2026, 1, 1, 0, 0, 0), utc(2027, 1, 1, 0, 0, 0)},
+		{"year rollover", "@monthly", time.UTC, utc(2026, 12, 15, 0, 0, 0), utc(2027, 1, 1, 0, 0, 0)},
+		{"leap day", "0 0 29 2 *", time.UTC, utc(2026, 1, 1, 0, 0, 0), utc(2028, 2, 29, 0, 0, 0)},
+		{"never", "0 0 30 2 *", time.UTC, utc(2026, 1, 1, 0, 0, 0), time.Time{}},
+
+		{"timezone", "0 9 * * *", shanghai, utc(2026, 1, 1, 0, 0, 0), utc(2026, 1, 1, 1, 0, 0)},
+		{"timezone next day", "0 9 * * *", shanghai, utc(2026, 1, 1, 1, 0, 0), utc(2026, 1, 2, 1, 0, 0)},
+		// 2026-03-08 02:00 EST 跳到 03:00 EDT
+		{"dst skipped time", "30 2 * * *", newYork, utc(2026, 3, 8, 5, 0, 0), utc(2026, 3, 9, 6, 30, 0)},
+		{"dst hourly across gap", "0 * * * *", newYork, utc(2026, 3, 8, 6, 30, 0), utc(2026, 3, 8, 7, 0, 0)},
+		// 2026-11-01 02:00 EDT 回到 01:00 EST，01:30 出现两次
+		{"dst repeated first", "30 1 * * *", newYork, utc(2026, 11, 1, 4, 0, 0), utc(2026, 11, 1, 5, 30, 0)},
+		{"dst repeated second", "30 1 * * *", newYork, utc(2026, 11, 1, 5, 30, 0), utc(2026, 11, 1, 6, 30, 0)},
+		{"dst after repeat", "30 1 * * *", newYork, utc(2026, 11, 1, 6, 30, 0), utc(2026, 11, 2, 6, 30, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			got := schedule.Next(tt.after, tt.loc)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.after, got.UTC(), tt.want)
+			}
+			if !got.IsZero() && got.Location() != tt.loc {
+				t.Errorf("Next 返回的时区为 %s, want %s", got.Location(), tt.loc)
+			}
+		})
+	}
+}
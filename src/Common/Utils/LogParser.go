@@ -0,0 +1,96 @@
+package v_utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	entity "voxesis/src/Common/Entity"
+)
+
+var (
+	// bedrockLogPattern 基岩版: [2024-01-01 12:00:00:000 INFO] 消息，旧版本没有毫秒
+	bedrockLogPattern = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})(?::(\d{1,3}))? ([A-Za-z]+)\] ?(.*)$`)
+	// javaLogPattern Java 版: [12:00:00] [Server thread/INFO]: 消息
+	javaLogPattern = regexp.MustCompile(`^\[(\d{2}:\d{2}:\d{2})\] \[(.*)/([A-Za-z]+)\]: ?(.*)$`)
+	// ansiPattern 终端颜色等控制序列
+	ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+)
+
+// 普通进程输出行的前缀
+var streamPrefixes = map[string]string{
+	"[STDOUT]": "stdout",
+	"[STDERR]": "stderr",
+}
+
+// CleanOutputLine 去除输出行中的终端控制序列与普通进程的输出前缀
+func CleanOutputLine(data string) string {
+	text, _ := splitOutputLine(data)
+	return text
+}
+
+// splitOutputLine 去除终端控制序列与普通进程的输出前缀，返回文本与输出流
+func splitOutputLine(data string) (string, string) {
+	text := strings.TrimRight(ansiPattern.ReplaceAllString(data, ""), "\r\n")
+	stream := ""
+	for prefix, name := range streamPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			text, stream = text[len(prefix):], name
+			break
+		}
+	}
+	return strings.TrimLeft(text, " "), stream
+}
+
+// ParseLogLine 识别基岩版与 Java 版服务器的日志格式，无法识别时返回 nil
+// at 为该行的输出时间 (Unix 毫秒)，Java 版日志只有时分秒，日期取自输出时间
+func ParseLogLine(data string, at int64) *entity.LogRecord {
+	text, stream := splitOutputLine(data)
+
+	if match := bedrockLogPattern.FindStringSubmatch(text); match != nil {
+		logTime, err := time.ParseInLocation("2006-01-02 15:04:05", match[1], time.Local)
+		if err != nil {
+			return nil
+		}
+		if match[2] != "" {
+			millis, _ := strconv.Atoi(match[2])
+			logTime = logTime.Add(time.Duration(millis) * time.Millisecond)
+		}
+		return &entity.LogRecord{
+			Format:  "bedrock",
+			Stream:  stream,
+			Time:    logTime.UnixMilli(),
+			Level:   strings.ToUpper(match[3]),
+			Message: match[4],
+		}
+	}
+
+	if match := javaLogPattern.FindStringSubmatch(text); match != nil {
+		clock, err := time.Parse("15:04:05", match[1])
+		if err != nil {
+			return nil
+		}
+		return &entity.LogRecord{
+			Format:  "java",
+			Stream:  stream,
+			Time:    javaLogTime(clock, at),
+			Level:   strings.ToUpper(match[3]),
+			Source:  match[2],
+			Message: match[4],
+		}
+	}
+	return nil
+}
+
+// javaLogTime 将只有时分秒的日志时间补全为输出当天的时间
+// 日志在午夜前写出、午夜后才被读取时，补全结果会晚于输出时间，此时改为前一天
+func javaLogTime(clock time.Time, at int64) int64 {
+	output := time.UnixMilli(at)
+	year, month, day := output.Date()
+	logTime := time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), 0, output.Location())
+	if logTime.Sub(output) > time.Hour {
+		logTime = logTime.AddDate(0, 0, -1)
+	}
+	return logTime.UnixMilli()
+}
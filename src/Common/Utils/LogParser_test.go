@@ -0,0 +1,98 @@
+package v_utils
+
+import (
+	"testing"
+	"time"
+
+	entity "voxesis/src/Common/Entity"
+)
+
+func TestParseLogLine(t *testing.T) {
+	local := func(year int, month time.Month, day, hour, min, sec, ms int) int64 {
+		return time.Date(year, month, day, hour, min, sec, ms*int(time.Millisecond), time.Local).UnixMilli()
+	}
+	noon := local(2026, 1, 2, 12, 0, 5, 0)
+
+	tests := []struct {
+		name string
+		data string
+		at   int64
+		want *entity.LogRecord
+	}{
+		{
+			name: "bedrock",
+			data: "[2026-01-02 12:00:00:123 INFO] Server started.",
+			at:   noon,
+			want: &entity.LogRecord{Format: "bedrock", Time: local(2026, 1, 2, 12, 0, 0, 123), Level: "INFO", Message: "Server started."},
+		},
+		{
+			name: "bedrock without millis",
+			data: "[2026-01-02 12:00:00 WARN] Low memory",
+			at:   noon,
+			want: &entity.LogRecord{Format: "bedrock", Time: local(2026, 1, 2, 12, 0, 0, 0), Level: "WARN", Message: "Low memory"},
+		},
+		{
+			name: "bedrock lowercase level and crlf",
+			data: "[2026-01-02 12:00:00:5 error] Crash\r\n",
+			at:   noon,
+			want: &entity.LogRecord{Format: "bedrock", Time: local(2026, 1, 2, 12, 0, 0, 5), Level: "ERROR", Message: "Crash"},
+		},
+		{
+			name: "bedrock with ansi colors",
+			data: "\x1b[32m[2026-01-02 12:00:00:000 INFO] Player connected: Steve\x1b[0m",
+			at:   noon,
+			want: &entity.LogRecord{Format: "bedrock", Time: local(2026, 1, 2, 12, 0, 0, 0), Level: "INFO", Message: "Player connected: Steve"},
+		},
+		{
+			name: "bedrock stdout prefix",
+			data: "[STDOUT] [2026-01-02 12:00:00:000 INFO] Saving...",
+			at:   noon,
+			want: &entity.LogRecord{Format: "bedrock", Stream: "stdout", Time: local(2026, 1, 2, 12, 0, 0, 0), Level: "INFO", Message: "Saving..."},
+		},
+		{
+			name: "java",
+			data: "[12:00:03] [Server thread/INFO]: Done (3.2s)! For help, type \"help\"",
+			at:   noon,
+			want: &entity.LogRecord{Format: "java", Time: local(2026, 1, 2, 12, 0, 3, 0), Level: "INFO", Source: "Server thread", Message: "Done (3.2s)! For help, type \"help\""},
+		},
+		{
+			name: "java stderr prefix",
+			data: "[STDERR][12:00:03] [Worker-Main-1/WARN]: Slow chunk",
+			at:   noon,
+			want: &entity.LogRecord{Format: "java", Stream: "stderr", Time: local(2026, 1, 2, 12, 0, 3, 0), Level: "WARN", Source: "Worker-Main-1", Message: "Slow chunk"},
+		},
+		{
+			name: "java written before midnight",
+			data: "[23:59:58] [Server thread/INFO]: Saved the game",
+			at:   local(2026, 1, 2, 0, 0, 1, 0),
+			want: &entity.LogRecord{Format: "java", Time: local(2026, 1, 1, 23, 59, 58, 0), Level: "INFO", Source: "Server thread", Message: "Saved the game"},
+		},
+		{
+			name: "java slightly ahead of output",
+			data: "[00:00:02] [Server thread/INFO]: Tick",
+			at:   local(2026, 1, 2, 0, 0, 1, 0),
+			want: &entity.LogRecord{Format: "java", Time: local(2026, 1, 2, 0, 0, 2, 0), Level: "INFO", Source: "Server thread", Message: "Tick"},
+		},
+		{name: "plain text", data: "Bedrock level/db/000005.ldb:1234", at: noon, want: nil},
+		{name: "stdout plain text", data: "[STDOUT] hello", at: noon, want: nil},
+		{name: "invalid date", data: "[2026-13-45 12:00:00:000 INFO] bad", at: noon, want: nil},
+		{name: "empty", data: "", at: noon, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseLogLine(tt.data, tt.at)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseLogLine(%q) = %+v, want nil", tt.data, *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("ParseLogLine(%q) = nil, want %+v", tt.data, *tt.want)
+			}
+			if *got != *tt.want {
+				t.Errorf("ParseLogLine(%q) = %+v, want %+v", tt.data, *got, *tt.want)
+			}
+		})
+	}
+}
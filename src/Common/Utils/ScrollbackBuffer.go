@@ -0,0 +1,126 @@
+package v_utils
+
+import (
+	"sync"
+	"time"
+
+	entity "voxesis/src/Common/Entity"
+)
+
+// ScrollbackBuffer 有界的控制台回滚缓冲区
+// 同时按行数和字节数限制容量，超出时丢弃最旧的行；每一行都会分配单调递增的序号
+type ScrollbackBuffer struct {
+	mu       sync.RWMutex
+	lines    []entity.OutputLine // 环形数组
+	head     int                 // 最旧一行的下标
+	count    int
+	bytes    int
+	maxLines int
+	maxBytes int
+	lastSeq  uint64
+}
+
+// NewScrollbackBuffer 创建一个新的回滚缓冲区
+// maxLines: 最多保留的行数
+// maxBytes: 最多保留的字节数
+func NewScrollbackBuffer(maxLines, maxBytes int) *ScrollbackBuffer {
+	return &ScrollbackBuffer{
+		lines:    make([]entity.OutputLine, maxLines),
+		maxLines: maxLines,
+		maxBytes: maxBytes,
+	}
+}
+
+// Append 追加一行输出并返回分配了序号的行
+func (sb *ScrollbackBuffer) Append(data string) entity.OutputLine {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.lastSeq++
+	line := entity.OutputLine{
+		Seq:  sb.lastSeq,
+		Time: time.Now().UnixMilli(),
+		Data: data,
+	}
+
+	// 单行超过字节上限时不保留，但序号照常递增
+	if len(data) > sb.maxBytes {
+		sb.clear()
+		return line
+	}
+
+	for sb.count > 0 && (sb.count >= sb.maxLines || sb.bytes+len(data) > sb.maxBytes) {
+		sb.dropOldest()
+	}
+	sb.lines[(sb.head+sb.count)%len(sb.lines)] = line
+	sb.count++
+	sb.bytes += len(data)
+	return line
+}
+
+// Since 返回序号大于 seq 的所有行，以及同一时刻最新一行的序号
+// 第三个返回值表示 seq 之后是否有行已被丢弃，即调用方看到的输出存在缺口
+func (sb *ScrollbackBuffer) Since(seq uint64) ([]entity.OutputLine, uint64, bool) {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+
+	if seq >= sb.lastSeq {
+		return []entity.OutputLine{}, sb.lastSeq, false
+	}
+
+	// 缓冲区中最旧一行的序号，缓冲区为空时为下一个将要分配的序号
+	oldest := sb.lastSeq - uint64(sb.count) + 1
+	truncated := seq+1 < oldest
+
+	start := 0
+	if !truncated {
+		start = int(seq + 1 - oldest)
+	}
+	result := make([]entity.OutputLine, 0, sb.count-start)
+	for i := start; i < sb.count; i++ {
+		result = append(result, sb.lines[(sb.head+i)%len(sb.lines)])
+	}
+	return result, sb.lastSeq, truncated
+}
+
+// LastSeq 返回最近一行的序号，尚无输出时为 0
+func (sb *ScrollbackBuffer) LastSeq() uint64 {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+	return sb.lastSeq
+}
+
+// SetLimits 修改容量上限，保留最新的行，序号不受影响
+func (sb *ScrollbackBuffer) SetLimits(maxLines, maxBytes int) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	for sb.count > 0 && (sb.count > maxLines || sb.bytes > maxBytes) {
+		sb.dropOldest()
+	}
+
+	lines := make([]entity.OutputLine, maxLines)
+	for i := 0; i < sb.count; i++ {
+		lines[i] = sb.lines[(sb.head+i)%len(sb.lines)]
+	}
+	sb.lines = lines
+	sb.head = 0
+	sb.maxLines = maxLines
+	sb.maxBytes = maxBytes
+}
+
+// dropOldest 丢弃最旧的一行，调用方需持有写锁
+func (sb *ScrollbackBuffer) dropOldest() {
+	sb.bytes -= len(sb.lines[sb.head].Data)
+	sb.lines[sb.head] = entity.OutputLine{}
+	sb.head = (sb.head + 1) % len(sb.lines)
+	sb.count--
+}
+
+// clear 丢弃所有行，调用方需持有写锁
+func (sb *ScrollbackBuffer) clear() {
+	for sb.count > 0 {
+		sb.dropOldest()
+	}
+	sb.head = 0
+}
@@ -0,0 +1,123 @@
+package v_utils
+
+import (
+	"strings"
+	"testing"
+)
+
+// seqs 返回行的序号，便于比较
+func seqs(sb *ScrollbackBuffer, since uint64) ([]uint64, uint64, bool) {
+	lines, lastSeq, truncated := sb.Since(since)
+	result := []uint64{}
+	for _, line := range lines {
+		result = append(result, line.Seq)
+	}
+	return result, lastSeq, truncated
+}
+
+func equalSeqs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScrollbackBuffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxLines      int
+		maxBytes      int
+		lines         []string
+		since         uint64
+		wantSeqs      []uint64
+		wantLast      uint64
+		wantTruncated bool
+	}{
+		{"empty", 3, 100, nil, 0, []uint64{}, 0, false},
+		{"all lines", 3, 100, []string{"a", "b"}, 0, []uint64{1, 2}, 2, false},
+		{"since middle", 3, 100, []string{"a", "b", "c"}, 1, []uint64{2, 3}, 3, false},
+		{"since latest", 3, 100, []string{"a", "b", "c"}, 3, []uint64{}, 3, false},
+		{"since future", 3, 100, []string{"a"}, 9, []uint64{}, 1, false},
+		{"wraparound by lines", 3, 100, []string{"a", "b", "c", "d", "e"}, 0, []uint64{3, 4, 5}, 5, true},
+		{"wraparound no gap", 3, 100, []string{"a", "b", "c", "d", "e"}, 2, []uint64{3, 4, 5}, 5, false},
+		{"wraparound gap", 3, 100, []string{"a", "b", "c", "d", "e"}, 1, []uint64{3, 4, 5}, 5, true},
+		{"wraparound twice", 2, 100, []string{"a", "b", "c", "d", "e", "f", "g"}, 5, []uint64{6, 7}, 7, false},
+		{"byte limit", 10, 5, []string{"aa", "bb", "cc"}, 0, []uint64{2, 3}, 3, true},
+		{"oversized line dropped", 10, 5, []string{"aa", "toolong", "bb"}, 0, []uint64{3}, 3, true},
+		{"oversized line last", 10, 5, []string{"aa", "toolong"}, 1, []uint64{}, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := NewScrollbackBuffer(tt.maxLines, tt.maxBytes)
+			for i, data := range tt.lines {
+				if line := sb.Append(data); line.Seq != uint64(i+1) || line.Data != data {
+					t.Fatalf("Append(%q) = %+v", data, line)
+				}
+			}
+			got, last, truncated := seqs(sb, tt.since)
+			if !equalSeqs(got, tt.wantSeqs) || last != tt.wantLast || truncated != tt.wantTruncated {
+				t.Errorf("Since(%d) = %v, %d, %v, want %v, %d, %v",
+					tt.since, got, last, truncated, tt.wantSeqs, tt.wantLast, tt.wantTruncated)
+			}
+			if last != sb.LastSeq() {
+				t.Errorf("LastSeq() = %d, want %d", sb.LastSeq(), last)
+			}
+		})
+	}
+}
+
+func TestScrollbackBufferSetLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLines  int
+		maxBytes  int
+		wantSeqs  []uint64 // 修改容量后
+		wantAfter []uint64 // 再追加一行后
+	}{
+		{"shrink lines", 2, 100, []uint64{4, 5}, []uint64{5, 6}},
+		{"shrink bytes", 10, 3, []uint64{3, 4, 5}, []uint64{4, 5, 6}},
+		{"grow", 10, 100, []uint64{3, 4, 5}, []uint64{3, 4, 5, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 追加 5 行使环形数组回绕，head 不在下标 0
+			sb := NewScrollbackBuffer(3, 100)
+			for _, data := range []string{"a", "b", "c", "d", "e"} {
+				sb.Append(data)
+			}
+
+			sb.SetLimits(tt.maxLines, tt.maxBytes)
+			got, last, _ := seqs(sb, 0)
+			if !equalSeqs(got, tt.wantSeqs) || last != 5 {
+				t.Fatalf("SetLimits 后 Since(0) = %v, %d, want %v, 5", got, last, tt.wantSeqs)
+			}
+
+			// 修改容量后序号保持连续，并按新的容量丢弃
+			sb.Append("f")
+			got, last, _ = seqs(sb, 0)
+			if !equalSeqs(got, tt.wantAfter) || last != 6 {
+				t.Errorf("追加后 Since(0) = %v, %d, want %v, 6", got, last, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestScrollbackBufferData(t *testing.T) {
+	sb := NewScrollbackBuffer(2, 100)
+	for _, data := range []string{"one", "two", "three"} {
+		sb.Append(data)
+	}
+	lines, _, _ := sb.Since(0)
+	var got []string
+	for _, line := range lines {
+		got = append(got, line.Data)
+	}
+	if strings.Join(got, ",") != "two,three" {
+		t.Errorf("Since(0) 的内容 = %v, want [two three]", got)
+	}
+}
@@ -0,0 +1,137 @@
+package inter_http
+
+import (
+	"strconv"
+	entity "voxesis/src/Common/Entity"
+	communication "voxesis/src/Communication"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Backups struct {
+}
+
+// ListBackups 列出进程的备份
+// 查询参数: processId 进程ID
+func (b *Backups) ListBackups(context *gin.Context) {
+	processId, err := strconv.Atoi(context.Query("processId"))
+	if err != nil {
+		context.JSON(400, []interface{}{nil, "invalid processId type"})
+		return
+	}
+
+	backups, e := communication.BackupIpc.ListBackups(processId)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{backups, nil})
+}
+
+// CreateBackup 立即备份进程，备份完成后返回
+func (b *Backups) CreateBackup(context *gin.Context) {
+	var data struct {
+		ProcessId *int `json:"processId"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if data.ProcessId == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	backup, e := communication.BackupIpc.CreateBackup(*data.ProcessId)
+	if e != nil {
+		context.JSON(400, *e)
+		return
+	}
+	context.JSON(200, *backup)
+}
+
+// DeleteBackup 删除备份及其归档文件
+func (b *Backups) DeleteBackup(context *gin.Context) {
+	var data struct {
+		Id *int `json:"id"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if data.Id == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	if err := communication.BackupIpc.DeleteBackup(*data.Id); err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+// DownloadBackup 下载备份的归档文件，响应头 X-Checksum-Sha256 为文件的 SHA-256
+// 查询参数: id 备份ID
+func (b *Backups) DownloadBackup(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(400, "invalid id type")
+		return
+	}
+
+	backup, file, err := communication.BackupIpc.Manager.OpenBackup(id)
+	if err != nil {
+		context.JSON(404, err.Error())
+		return
+	}
+	defer file.Close()
+
+	context.Header("X-Checksum-Sha256", backup.Sha256)
+	context.Header("Content-Disposition", "attachment; filename=\""+backup.FileName+"\"")
+	context.DataFromReader(200, backup.SizeBytes, "application/octet-stream", file, nil)
+}
+
+// GetPolicy 获取进程的备份设置
+// 查询参数: processId 进程ID
+func (b *Backups) GetPolicy(context *gin.Context) {
+	processId, err := strconv.Atoi(context.Query("processId"))
+	if err != nil {
+		context.JSON(400, []interface{}{nil, "invalid processId type"})
+		return
+	}
+
+	policy, e := communication.BackupIpc.GetBackupPolicy(processId)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{*policy, nil})
+}
+
+// SetPolicy 设置进程的备份设置
+func (b *Backups) SetPolicy(context *gin.Context) {
+	var data struct {
+		ProcessId *int                `json:"processId"`
+		Policy    entity.BackupPolicy `json:"policy"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if data.ProcessId == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	if err := communication.BackupIpc.SetBackupPolicy(*data.ProcessId, data.Policy); err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
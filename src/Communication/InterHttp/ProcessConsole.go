@@ -0,0 +1,135 @@
+package inter_http
+
+import (
+	"encoding/json"
+	entity "voxesis/src/Common/Entity"
+	communication "voxesis/src/Communication"
+)
+
+// 进程控制台 websocket 协议
+//
+// 客户端发送 (id 可选，原样出现在对应的 ack 中):
+//
+//	{"type": "subscribe", "id": 1, "uuids": [1, 2]}         追加订阅的进程，空数组表示全部
+//	{"type": "unsubscribe", "id": 2, "uuids": [2]}          取消订阅，空数组表示取消全部
+//	{"type": "replay", "id": 3, "uuid": 1, "since": 100}    补发序号大于 since 的输出
+//	{"type": "command", "id": 4, "uuid": 1, "command": "list"}  向进程发送控制台命令
+//	{"type": "command", "id": 5, "uuid": 1, "command": "list", "queue": true, "ttlMs": 60000}  进程未就绪时排队发送
+//
+// 服务端推送:
+//
+//	{"type": "output", "uuid": 1, "seq": 101, "time": 0, "data": "...", "record": {"format": "java", "level": "INFO", ...}}
+//	{"type": "replay", "uuid": 1, "lines": [...], "lastSeq": 120, "truncated": false}
+//	{"type": "event", "uuid": 1, "event": {"type": "state-changed", "time": 0, "lifecycle": {...}}}
+//	{"type": "ack", "id": 4, "request": "command", "uuid": 1, "error": null}
+
+// consoleRequest 客户端发来的请求
+type consoleRequest struct {
+	Type    string          `json:"type"`
+	Id      json.RawMessage `json:"id"`
+	Uuid    *int            `json:"uuid"`
+	Uuids   []int           `json:"uuids"`
+	Since   uint64          `json:"since"`
+	Command string          `json:"command"`
+	Queue   bool            `json:"queue"`
+	TtlMs   int             `json:"ttlMs"`
+}
+
+// outputMessage 一行实时输出
+type outputMessage struct {
+	Type   string            `json:"type"`
+	Uuid   int               `json:"uuid"`
+	Seq    uint64            `json:"seq"`
+	Time   int64             `json:"time"`
+	Data   string            `json:"data"`
+	Record *entity.LogRecord `json:"record,omitempty"` // 识别出服务器日志格式时的结构化记录
+}
+
+// replayMessage 补发的历史输出
+type replayMessage struct {
+	Type      string              `json:"type"`
+	Uuid      int                 `json:"uuid"`
+	Lines     []entity.OutputLine `json:"lines"`
+	LastSeq   uint64              `json:"lastSeq"`
+	Truncated bool                `json:"truncated"`
+}
+
+// eventMessage 进程生命周期事件
+type eventMessage struct {
+	Type  string              `json:"type"`
+	Uuid  int                 `json:"uuid"`
+	Event entity.ProcessEvent `json:"event"`
+}
+
+// ackMessage 对客户端请求的确认，error 为 null 表示成功
+type ackMessage struct {
+	Type    string          `json:"type"`
+	Id      json.RawMessage `json:"id,omitempty"`
+	Request string          `json:"request"`
+	Uuid    *int            `json:"uuid,omitempty"`
+	Error   *string         `json:"error"`
+}
+
+// handleConsoleMessage 处理控制台客户端发来的请求，每个请求都会回复一条 ack
+func handleConsoleMessage(client *WsClient, message []byte) {
+	var request consoleRequest
+	if err := json.Unmarshal(message, &request); err != nil {
+		e := "invalid message: " + err.Error()
+		client.Send(ackMessage{Type: "ack", Error: &e})
+		return
+	}
+
+	ack := ackMessage{Type: "ack", Id: request.Id, Request: request.Type, Uuid: request.Uuid}
+	switch request.Type {
+	case "subscribe":
+		client.Subscribe(request.Uuids)
+	case "unsubscribe":
+		client.Unsubscribe(request.Uuids)
+	case "replay":
+		if request.Uuid == nil {
+			ack.Error = missingUuid()
+			break
+		}
+		ack.Error = replayOutput(client, *request.Uuid, request.Since)
+	case "command":
+		if request.Uuid == nil {
+			ack.Error = missingUuid()
+			break
+		}
+		if request.Queue {
+			_, ack.Error = communication.ProcessIpc.QueueCommand(*request.Uuid, request.Command, request.TtlMs)
+		} else {
+			ack.Error = communication.ProcessIpc.SendCommand(*request.Uuid, request.Command)
+		}
+	default:
+		e := "unknown message type: " + request.Type
+		ack.Error = &e
+	}
+
+	client.Send(ack)
+}
+
+// replayOutput 向客户端补发指定进程中序号大于 since 的输出
+func replayOutput(client *WsClient, uuid int, since uint64) *string {
+	var e *string
+	client.Replay(uuid, since, func() (interface{}, uint64, bool) {
+		replay, err := communication.ProcessIpc.GetOutputSince(uuid, since)
+		if err != nil {
+			e = err
+			return nil, 0, false
+		}
+		return replayMessage{
+			Type:      "replay",
+			Uuid:      uuid,
+			Lines:     replay.Lines,
+			LastSeq:   replay.LastSeq,
+			Truncated: replay.Truncated,
+		}, replay.LastSeq, true
+	})
+	return e
+}
+
+func missingUuid() *string {
+	e := "missing uuid"
+	return &e
+}
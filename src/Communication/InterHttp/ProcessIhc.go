@@ -1,7 +1,12 @@
 package inter_http
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	entity "voxesis/src/Common/Entity"
 	vlogger "voxesis/src/Common/Logger"
 	vmanager "voxesis/src/Common/Manager"
 	communication "voxesis/src/Communication"
@@ -11,7 +16,8 @@ import (
 )
 
 type Process struct {
-	ws *websocket.Conn
+	hub     *WsHub
+	hubOnce sync.Once
 }
 
 func (p *Process) NewProcess(context *gin.Context) {
@@ -60,9 +66,100 @@ func (p *Process) NewProcess(context *gin.Context) {
 		}
 	}
 
-	uuid := communication.ProcessIpc.NewProcess(vmanager.ProcessType(processType), abs, relPath, args...)
+	var options vmanager.ProcessOptions
+	if optionsData, exists := data["options"]; exists && optionsData != nil {
+		raw, err := json.Marshal(optionsData)
+		if err == nil {
+			err = json.Unmarshal(raw, &options)
+		}
+		if err != nil {
+			context.JSON(400, []interface{}{nil, "invalid options type"})
+			return
+		}
+	}
+
+	uuid, err := communication.ProcessIpc.NewProcessWithOptions(vmanager.ProcessType(processType), abs, relPath, args, options)
+	if err != nil {
+		context.JSON(400, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, *uuid)
+}
+
+func (p *Process) ListProcesses(context *gin.Context) {
+	defs := communication.ProcessIpc.ListProcesses()
+
+	context.JSON(200, defs)
+}
+
+func (p *Process) GetProcess(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(200, []interface{}{nil, "invalid uuid type"})
+		return
+	}
+
+	def, err := communication.ProcessIpc.GetProcess(int(uuid))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*def, nil})
+}
+
+func (p *Process) UpdateProcess(context *gin.Context) {
+	var data struct {
+		Uuid       *int                      `json:"uuid"`
+		Definition *entity.ProcessDefinition `json:"definition"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil || data.Definition == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.UpdateProcess(*data.Uuid, *data.Definition)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) DeleteProcess(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(400, "invalid uuid type")
+		return
+	}
 
-	context.JSON(200, uuid)
+	err := communication.ProcessIpc.DeleteProcess(int(uuid))
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
 }
 
 func (p *Process) Start(context *gin.Context) {
@@ -87,6 +184,99 @@ func (p *Process) Start(context *gin.Context) {
 	context.JSON(200, nil)
 }
 
+func (p *Process) Attach(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(400, "invalid uuid type")
+		return
+	}
+
+	pid, err := communication.ProcessIpc.Attach(int(uuid))
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, *pid)
+}
+
+func (p *Process) StartWithProfile(context *gin.Context) {
+	var data struct {
+		Uuid    *int   `json:"uuid"`
+		Profile string `json:"profile"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.StartWithProfile(*data.Uuid, data.Profile)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) ListProfiles(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(200, []interface{}{nil, "invalid uuid type"})
+		return
+	}
+
+	profiles, err := communication.ProcessIpc.ListProfiles(int(uuid))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*profiles, nil})
+}
+
+func (p *Process) SetProfiles(context *gin.Context) {
+	var data struct {
+		Uuid     *int                     `json:"uuid"`
+		Profiles []vmanager.LaunchProfile `json:"profiles"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetProfiles(*data.Uuid, data.Profiles)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
 func (p *Process) Stop(context *gin.Context) {
 	var data map[string]interface{}
 
@@ -101,7 +291,31 @@ func (p *Process) Stop(context *gin.Context) {
 		return
 	}
 
-	err := communication.ProcessIpc.Stop(int(uuid))
+	result, err := communication.ProcessIpc.Stop(int(uuid))
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, *result)
+}
+
+func (p *Process) SetStopSequence(context *gin.Context) {
+	var data struct {
+		Uuid     *int                  `json:"uuid"`
+		Sequence vmanager.StopSequence `json:"sequence"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetStopSequence(*data.Uuid, data.Sequence)
 	if err != nil {
 		context.JSON(400, *err)
 		return
@@ -131,7 +345,58 @@ func (p *Process) SendCommand(context *gin.Context) {
 	context.JSON(200, nil)
 }
 
-func (p *Process) GetProcessStatus(context *gin.Context) {
+func (p *Process) QueueCommand(context *gin.Context) {
+	var data struct {
+		Uuid    *int   `json:"uuid"`
+		Command string `json:"command"`
+		TtlMs   int    `json:"ttlMs"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(200, []interface{}{nil, "missing required fields"})
+		return
+	}
+
+	queued, err := communication.ProcessIpc.QueueCommand(*data.Uuid, data.Command, data.TtlMs)
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{queued, nil})
+}
+
+func (p *Process) ExecuteCommand(context *gin.Context) {
+	var data struct {
+		Uuid *int `json:"uuid"`
+		vmanager.CommandRequest
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(200, []interface{}{nil, "missing required fields"})
+		return
+	}
+
+	response, err := communication.ProcessIpc.ExecuteCommand(*data.Uuid, data.CommandRequest)
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*response, nil})
+}
+
+func (p *Process) ListQueuedCommands(context *gin.Context) {
 	var data map[string]interface{}
 
 	if err := context.ShouldBindJSON(&data); err != nil {
@@ -145,54 +410,523 @@ func (p *Process) GetProcessStatus(context *gin.Context) {
 		return
 	}
 
-	state, err := communication.ProcessIpc.GetProcessStatus(int(uuid))
-
+	commands, err := communication.ProcessIpc.ListQueuedCommands(int(uuid))
 	if err != nil {
 		context.JSON(200, []interface{}{nil, *err})
 		return
 	}
 
-	context.JSON(200, []interface{}{*state, nil})
+	context.JSON(200, []interface{}{commands, nil})
 }
 
-func (p *Process) GetProcessOutput(context *gin.Context) {
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin: func(r *http.Request) bool {
-			return true
-		},
+func (p *Process) CancelQueuedCommand(context *gin.Context) {
+	var data struct {
+		Uuid      *int `json:"uuid"`
+		CommandId int  `json:"commandId"`
 	}
 
-	var err error
-	p.ws, err = upgrader.Upgrade(context.Writer, context.Request, nil)
-	if err != nil {
-		vlogger.AppLogger.Error(err.Error())
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
 		return
 	}
 
-	go func() {
-		defer p.ws.Close()
-		for {
-			if _, _, err := p.ws.ReadMessage(); err != nil {
-				p.ws = nil
-				return
-			}
-		}
-	}()
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.CancelQueuedCommand(*data.Uuid, data.CommandId)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
 }
 
-func (p *Process) WriteProcessOutput(uuid int, output string) {
-	if p.ws == nil {
+func (p *Process) Resize(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(400, "invalid uuid type")
+		return
+	}
+
+	cols, ok := data["cols"].(float64)
+	if !ok {
+		context.JSON(400, "invalid cols type")
+		return
+	}
+
+	rows, ok := data["rows"].(float64)
+	if !ok {
+		context.JSON(400, "invalid rows type")
+		return
+	}
+
+	err := communication.ProcessIpc.Resize(int(uuid), uint16(cols), uint16(rows))
+	if err != nil {
+		context.JSON(400, *err)
 		return
 	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) GetProcessStatus(context *gin.Context) {
+	var data map[string]interface{}
 
-	message := map[string]interface{}{
-		"uuid": uuid,
-		"data": output,
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
 	}
 
-	if err := p.ws.WriteJSON(message); err != nil {
-		p.ws = nil
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(200, []interface{}{nil, "invalid uuid type"})
+		return
 	}
+
+	state, err := communication.ProcessIpc.GetProcessStatus(int(uuid))
+
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*state, nil})
+}
+
+func (p *Process) GetLifecycle(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(200, []interface{}{nil, "invalid uuid type"})
+		return
+	}
+
+	lifecycle, err := communication.ProcessIpc.GetLifecycle(int(uuid))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*lifecycle, nil})
+}
+
+func (p *Process) SetRestartPolicy(context *gin.Context) {
+	var data struct {
+		Uuid   *int                   `json:"uuid"`
+		Policy vmanager.RestartPolicy `json:"policy"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetRestartPolicy(*data.Uuid, data.Policy)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) GetRestartStatus(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(200, []interface{}{nil, "invalid uuid type"})
+		return
+	}
+
+	status, err := communication.ProcessIpc.GetRestartStatus(int(uuid))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*status, nil})
+}
+
+func (p *Process) PlanRestart(context *gin.Context) {
+	var data struct {
+		Uuid     *int   `json:"uuid"`
+		DelaySec int    `json:"delaySec"`
+		Reason   string `json:"reason"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	progress, err := communication.ProcessIpc.PlanRestart(*data.Uuid, data.DelaySec, data.Reason)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, *progress)
+}
+
+func (p *Process) AbortRestart(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(400, "invalid uuid type")
+		return
+	}
+
+	err := communication.ProcessIpc.AbortRestart(int(uuid))
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) GetPlannedRestart(context *gin.Context) {
+	var data map[string]interface{}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	uuid, ok := data["uuid"].(float64)
+	if !ok {
+		context.JSON(200, []interface{}{nil, "invalid uuid type"})
+		return
+	}
+
+	progress, err := communication.ProcessIpc.GetPlannedRestart(int(uuid))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{progress, nil})
+}
+
+func (p *Process) SetRestartNotice(context *gin.Context) {
+	var data struct {
+		Uuid   *int                   `json:"uuid"`
+		Notice vmanager.RestartNotice `json:"notice"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetRestartNotice(*data.Uuid, data.Notice)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) SetReadinessProbe(context *gin.Context) {
+	var data struct {
+		Uuid  *int                    `json:"uuid"`
+		Probe vmanager.ReadinessProbe `json:"probe"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetReadinessProbe(*data.Uuid, data.Probe)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) SetResourceLimits(context *gin.Context) {
+	var data struct {
+		Uuid   *int                  `json:"uuid"`
+		Limits entity.ResourceLimits `json:"limits"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetResourceLimits(*data.Uuid, data.Limits)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) SetScrollbackConfig(context *gin.Context) {
+	var data struct {
+		Uuid   *int                      `json:"uuid"`
+		Config vmanager.ScrollbackConfig `json:"config"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	err := communication.ProcessIpc.SetScrollbackConfig(*data.Uuid, data.Config)
+	if err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+func (p *Process) GetOutputSince(context *gin.Context) {
+	var data struct {
+		Uuid  *int   `json:"uuid"`
+		Since uint64 `json:"since"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(200, []interface{}{nil, err.Error()})
+		return
+	}
+
+	if data.Uuid == nil {
+		context.JSON(200, []interface{}{nil, "missing required fields"})
+		return
+	}
+
+	replay, err := communication.ProcessIpc.GetOutputSince(*data.Uuid, data.Since)
+	if err != nil {
+		context.JSON(200, []interface{}{nil, *err})
+		return
+	}
+
+	context.JSON(200, []interface{}{*replay, nil})
+}
+
+// GetMetrics 查询进程的资源使用历史
+// 查询参数: id 进程ID；from、to 为 Unix 毫秒，默认为最近一小时；step 为聚合间隔，如 1m 或秒数，默认按范围自动选择
+func (p *Process) GetMetrics(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, "invalid id type"})
+		return
+	}
+
+	to := time.Now().UnixMilli()
+	if raw := context.Query("to"); raw != "" {
+		if to, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			context.JSON(200, []interface{}{nil, "invalid to type"})
+			return
+		}
+	}
+	from := to - time.Hour.Milliseconds()
+	if raw := context.Query("from"); raw != "" {
+		if from, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			context.JSON(200, []interface{}{nil, "invalid from type"})
+			return
+		}
+	}
+
+	var step time.Duration
+	if raw := context.Query("step"); raw != "" {
+		if seconds, err := strconv.Atoi(raw); err == nil {
+			step = time.Duration(seconds) * time.Second
+		} else if step, err = time.ParseDuration(raw); err != nil {
+			context.JSON(200, []interface{}{nil, "invalid step type"})
+			return
+		}
+	}
+
+	samples, e := communication.ProcessIpc.GetMetrics(id, from, to, step)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{samples, nil})
+}
+
+// GetOnlinePlayers 获取进程中当前在线的玩家
+// 查询参数: id 进程ID
+func (p *Process) GetOnlinePlayers(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, "invalid id type"})
+		return
+	}
+
+	players, e := communication.ProcessIpc.GetOnlinePlayers(id)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{players, nil})
+}
+
+// GetPlaytime 获取进程中每个玩家的累计游戏时长
+// 查询参数: id 进程ID
+func (p *Process) GetPlaytime(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, "invalid id type"})
+		return
+	}
+
+	playtimes, e := communication.ProcessIpc.GetPlaytime(id)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{playtimes, nil})
+}
+
+// GetPlayerSessions 获取玩家在进程中的会话历史
+// 查询参数: id 进程ID；name 玩家名；limit 最多返回的会话数，默认不限制
+func (p *Process) GetPlayerSessions(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(200, []interface{}{nil, "invalid id type"})
+		return
+	}
+	name := context.Query("name")
+	if name == "" {
+		context.JSON(200, []interface{}{nil, "missing name"})
+		return
+	}
+	limit := 0
+	if raw := context.Query("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil {
+			context.JSON(200, []interface{}{nil, "invalid limit type"})
+			return
+		}
+	}
+
+	sessions, e := communication.ProcessIpc.GetPlayerSessions(id, name, limit)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{sessions, nil})
+}
+
+// GetProcessOutput 建立进程控制台的 websocket 连接，支持多个客户端同时订阅
+// 查询参数 uuid 可重复出现，用于只订阅指定进程；同时带有 since 时在连接后补发该进程的历史输出
+// 连接后的消息格式见 ProcessConsole.go
+func (p *Process) GetProcessOutput(context *gin.Context) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+
+	var topics []int
+	for _, raw := range context.QueryArray("uuid") {
+		uuid, err := strconv.Atoi(raw)
+		if err != nil {
+			context.JSON(400, "invalid uuid type")
+			return
+		}
+		topics = append(topics, uuid)
+	}
+
+	ws, err := upgrader.Upgrade(context.Writer, context.Request, nil)
+	if err != nil {
+		vlogger.AppLogger.Error(err.Error())
+		return
+	}
+
+	client := p.getHub().Register(ws, topics)
+	if since, err := strconv.ParseUint(context.Query("since"), 10, 64); err == nil && len(topics) == 1 {
+		replayOutput(client, topics[0], since)
+	}
+}
+
+func (p *Process) WriteProcessOutput(uuid int, line entity.OutputLine) {
+	p.getHub().Broadcast(uuid, line.Seq, outputMessage{
+		Type:   "output",
+		Uuid:   uuid,
+		Seq:    line.Seq,
+		Time:   line.Time,
+		Data:   line.Data,
+		Record: line.Record,
+	})
+}
+
+func (p *Process) WriteProcessEvent(uuid int, event entity.ProcessEvent) {
+	p.getHub().Broadcast(uuid, 0, eventMessage{
+		Type:  "event",
+		Uuid:  uuid,
+		Event: event,
+	})
+}
+
+func (p *Process) getHub() *WsHub {
+	p.hubOnce.Do(func() {
+		p.hub = NewWsHub(handleConsoleMessage)
+	})
+	return p.hub
 }
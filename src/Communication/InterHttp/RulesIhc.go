@@ -0,0 +1,95 @@
+package inter_http
+
+import (
+	"strconv"
+	entity "voxesis/src/Common/Entity"
+	communication "voxesis/src/Communication"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Rules struct {
+}
+
+// ListRules 列出所有触发规则
+func (r *Rules) ListRules(context *gin.Context) {
+	context.JSON(200, []interface{}{communication.RulesIpc.ListRules(), nil})
+}
+
+// GetRule 获取触发规则
+// 查询参数: id 规则ID
+func (r *Rules) GetRule(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(400, []interface{}{nil, "invalid id type"})
+		return
+	}
+
+	rule, e := communication.RulesIpc.GetRule(id)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{*rule, nil})
+}
+
+// CreateRule 新增触发规则，请求体为规则本身
+func (r *Rules) CreateRule(context *gin.Context) {
+	var rule entity.Rule
+
+	if err := context.ShouldBindJSON(&rule); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	created, e := communication.RulesIpc.CreateRule(rule)
+	if e != nil {
+		context.JSON(400, *e)
+		return
+	}
+
+	context.JSON(200, *created)
+}
+
+// UpdateRule 更新触发规则，请求体为规则本身，id 字段指定要更新的规则
+func (r *Rules) UpdateRule(context *gin.Context) {
+	var rule entity.Rule
+
+	if err := context.ShouldBindJSON(&rule); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if rule.Id <= 0 {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	if err := communication.RulesIpc.UpdateRule(rule.Id, rule); err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+// DeleteRule 删除触发规则
+func (r *Rules) DeleteRule(context *gin.Context) {
+	var data struct {
+		Id *int `json:"id"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if data.Id == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	if err := communication.RulesIpc.DeleteRule(*data.Id); err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
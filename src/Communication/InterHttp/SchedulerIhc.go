@@ -0,0 +1,141 @@
+package inter_http
+
+import (
+	"strconv"
+	entity "voxesis/src/Common/Entity"
+	communication "voxesis/src/Communication"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Scheduler struct {
+}
+
+// ListJobs 列出所有定时任务
+func (s *Scheduler) ListJobs(context *gin.Context) {
+	context.JSON(200, []interface{}{communication.SchedulerIpc.ListJobs(), nil})
+}
+
+// GetJob 获取定时任务
+// 查询参数: id 任务ID
+func (s *Scheduler) GetJob(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(400, []interface{}{nil, "invalid id type"})
+		return
+	}
+
+	job, e := communication.SchedulerIpc.GetJob(id)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{*job, nil})
+}
+
+// CreateJob 新增定时任务，请求体为任务本身
+func (s *Scheduler) CreateJob(context *gin.Context) {
+	var job entity.ScheduledJob
+
+	if err := context.ShouldBindJSON(&job); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+
+	created, e := communication.SchedulerIpc.CreateJob(job)
+	if e != nil {
+		context.JSON(400, *e)
+		return
+	}
+
+	context.JSON(200, *created)
+}
+
+// UpdateJob 更新定时任务，请求体为任务本身，id 字段指定要更新的任务
+func (s *Scheduler) UpdateJob(context *gin.Context) {
+	var job entity.ScheduledJob
+
+	if err := context.ShouldBindJSON(&job); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if job.Id <= 0 {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	if err := communication.SchedulerIpc.UpdateJob(job.Id, job); err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+// DeleteJob 删除定时任务
+func (s *Scheduler) DeleteJob(context *gin.Context) {
+	var data struct {
+		Id *int `json:"id"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if data.Id == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	if err := communication.SchedulerIpc.DeleteJob(*data.Id); err != nil {
+		context.JSON(400, *err)
+		return
+	}
+	context.JSON(200, nil)
+}
+
+// RunJob 立即执行一次定时任务
+func (s *Scheduler) RunJob(context *gin.Context) {
+	var data struct {
+		Id *int `json:"id"`
+	}
+
+	if err := context.ShouldBindJSON(&data); err != nil {
+		context.JSON(400, err.Error())
+		return
+	}
+	if data.Id == nil {
+		context.JSON(400, "missing required fields")
+		return
+	}
+
+	run, e := communication.SchedulerIpc.RunJob(*data.Id)
+	if e != nil {
+		context.JSON(400, *e)
+		return
+	}
+	context.JSON(200, *run)
+}
+
+// ListRuns 查询定时任务的执行记录
+// 查询参数: id 任务ID, limit 最多返回的条数 (可选，默认不限制)
+func (s *Scheduler) ListRuns(context *gin.Context) {
+	id, err := strconv.Atoi(context.Query("id"))
+	if err != nil {
+		context.JSON(400, []interface{}{nil, "invalid id type"})
+		return
+	}
+	limit, err := strconv.Atoi(context.DefaultQuery("limit", "0"))
+	if err != nil {
+		context.JSON(400, []interface{}{nil, "invalid limit"})
+		return
+	}
+
+	runs, e := communication.SchedulerIpc.ListRuns(id, limit)
+	if e != nil {
+		context.JSON(200, []interface{}{nil, *e})
+		return
+	}
+
+	context.JSON(200, []interface{}{runs, nil})
+}
@@ -0,0 +1,243 @@
+package inter_http
+
+import (
+	"sync"
+	"time"
+	vlogger "voxesis/src/Common/Logger"
+
+	"github.com/gorilla/websocket"
+)
+
+// websocket 连接的默认参数
+const (
+	wsSendQueueSize = 256              // 每个客户端待发送消息队列的长度
+	wsWriteTimeout  = 10 * time.Second // 单条消息的写超时
+	wsPongTimeout   = 60 * time.Second // 超过该时长未收到 pong 即断开
+	wsPingInterval  = wsPongTimeout * 9 / 10
+	wsMaxMessage    = 64 * 1024 // 客户端消息的最大字节数
+)
+
+// WsHub 将进程输出广播给所有订阅的 websocket 客户端
+// 每个客户端拥有独立的写 goroutine 与有界队列，队列写满的慢速客户端会被断开，不会拖慢其他客户端
+type WsHub struct {
+	mu        sync.RWMutex
+	clients   map[*WsClient]struct{}
+	onMessage func(client *WsClient, message []byte)
+}
+
+// WsClient 一个已连接的 websocket 客户端
+type WsClient struct {
+	hub    *WsHub
+	conn   *websocket.Conn
+	send   chan interface{}
+	done   chan struct{}
+	closed sync.Once
+
+	mu      sync.Mutex
+	all     bool             // 是否订阅全部进程
+	topics  map[int]struct{} // 未订阅全部时所订阅的进程ID
+	sent    map[int]uint64   // 每个进程已入队的最新序号，用于去重
+	evicted bool             // 已因消费过慢被断开
+}
+
+// NewWsHub 创建一个新的广播中心
+// onMessage: 处理客户端发来的消息，可为 nil
+func NewWsHub(onMessage func(client *WsClient, message []byte)) *WsHub {
+	return &WsHub{
+		clients:   make(map[*WsClient]struct{}),
+		onMessage: onMessage,
+	}
+}
+
+// Register 注册一个新的连接并启动其读写 goroutine
+// topics: 初始订阅的进程ID，为空表示订阅全部
+func (h *WsHub) Register(conn *websocket.Conn, topics []int) *WsClient {
+	client := &WsClient{
+		hub:    h,
+		conn:   conn,
+		send:   make(chan interface{}, wsSendQueueSize),
+		done:   make(chan struct{}),
+		topics: make(map[int]struct{}),
+		sent:   make(map[int]uint64),
+	}
+	client.Subscribe(topics)
+
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+
+	go client.writePump()
+	go client.readPump()
+	return client
+}
+
+// Broadcast 将进程 topic 的一条序号为 seq 的消息发送给所有订阅了该进程的客户端
+// seq 为 0 表示消息不参与序号去重，例如生命周期事件
+func (h *WsHub) Broadcast(topic int, seq uint64, message interface{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.clients {
+		client.deliver(topic, seq, message)
+	}
+}
+
+// Count 返回当前连接的客户端数量
+func (h *WsHub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.clients)
+}
+
+// unregister 移除客户端
+func (h *WsHub) unregister(client *WsClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, client)
+}
+
+// Subscribe 追加订阅的进程ID，为空表示订阅全部
+func (c *WsClient) Subscribe(topics []int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(topics) == 0 {
+		c.all = true
+		return
+	}
+	for _, topic := range topics {
+		c.topics[topic] = struct{}{}
+	}
+}
+
+// Unsubscribe 取消订阅指定的进程ID，为空表示取消全部订阅
+// 处于订阅全部的状态时，只有取消全部订阅才会生效
+func (c *WsClient) Unsubscribe(topics []int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(topics) == 0 {
+		c.all = false
+		c.topics = make(map[int]struct{})
+		return
+	}
+	for _, topic := range topics {
+		delete(c.topics, topic)
+	}
+}
+
+// Replay 向客户端补发进程 topic 中序号大于 since 的历史消息
+// fetch 在客户端锁内调用，返回补发的消息与其中最新的序号；补发期间到达的实时消息若已包含在补发中会被跳过
+func (c *WsClient) Replay(topic int, since uint64, fetch func() (interface{}, uint64, bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	message, lastSeq, ok := fetch()
+	if !ok {
+		return
+	}
+	c.sent[topic] = since
+	if c.enqueueLocked(message) && lastSeq > since {
+		c.sent[topic] = lastSeq
+	}
+}
+
+// Send 向客户端发送一条与序号无关的消息，例如请求的响应
+func (c *WsClient) Send(message interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enqueueLocked(message)
+}
+
+// Close 断开客户端连接
+func (c *WsClient) Close() {
+	c.closed.Do(func() {
+		close(c.done)
+		c.hub.unregister(c)
+		_ = c.conn.Close()
+	})
+}
+
+// deliver 按订阅与去重规则将实时消息放入发送队列
+func (c *WsClient) deliver(topic int, seq uint64, message interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.topics[topic]; !ok && !c.all {
+		return
+	}
+	if seq == 0 {
+		c.enqueueLocked(message)
+		return
+	}
+	if seq <= c.sent[topic] {
+		return
+	}
+	if c.enqueueLocked(message) {
+		c.sent[topic] = seq
+	}
+}
+
+// enqueueLocked 将消息放入发送队列，队列已满时断开该客户端，调用方需持有 c.mu
+func (c *WsClient) enqueueLocked(message interface{}) bool {
+	if c.evicted {
+		return false
+	}
+	select {
+	case <-c.done:
+		return false
+	case c.send <- message:
+		return true
+	default:
+		// 可能正持有 hub 的读锁，异步断开以免死锁
+		c.evicted = true
+		vlogger.AppLogger.Warnf("websocket 客户端 %s 消费过慢，已断开", c.conn.RemoteAddr())
+		go c.Close()
+		return false
+	}
+}
+
+// writePump 依次写出队列中的消息，并定时发送 ping 保活
+func (c *WsClient) writePump() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	defer c.Close()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			deadline := time.Now().Add(wsWriteTimeout)
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPump 读取客户端消息，并通过 pong 延长读超时
+func (c *WsClient) readPump() {
+	defer c.Close()
+
+	c.conn.SetReadLimit(wsMaxMessage)
+	_ = c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if c.hub.onMessage != nil {
+			c.hub.onMessage(c, message)
+		}
+	}
+}
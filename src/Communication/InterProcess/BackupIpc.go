@@ -0,0 +1,129 @@
+package inter_process
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vmanager "voxesis/src/Common/Manager"
+)
+
+type BackupIpc struct {
+	Manager *vmanager.BackupManager
+}
+
+// NewBackupIpc 创建备份管理器，备份 processes 管理的进程，未设置备份目录时备份存放在 rootDir/<进程ID>
+func NewBackupIpc(store vdata.BackupStore, processes *ProcessIpc, rootDir string) *BackupIpc {
+	return &BackupIpc{
+		Manager: vmanager.NewBackupManager(store, &processBackupSource{processes: processes}, rootDir),
+	}
+}
+
+// ListBackups 按创建时间倒序列出指定ID进程的备份。
+func (b *BackupIpc) ListBackups(processId int) ([]entity.Backup, *string) {
+	backups, err := b.Manager.ListBackups(processId)
+	if err != nil {
+		e := fmt.Sprintf("读取ID为 %d 的进程的备份失败: %v", processId, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return backups, nil
+}
+
+// CreateBackup 立即备份指定ID的进程，备份完成后返回。
+// 返回 (备份, 错误信息字符串)
+func (b *BackupIpc) CreateBackup(processId int) (*entity.Backup, *string) {
+	backup, err := b.Manager.CreateBackup(processId, vmanager.BackupTriggerManual)
+	if err != nil {
+		e := fmt.Sprintf("备份ID为 %d 的进程失败: %v", processId, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return &backup, nil
+}
+
+// DeleteBackup 删除指定ID的备份及其归档文件。
+func (b *BackupIpc) DeleteBackup(id int) *string {
+	if err := b.Manager.DeleteBackup(id); err != nil {
+		e := fmt.Sprintf("删除ID为 %d 的备份失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// DownloadBackup 将指定ID的备份复制到 destPath，destPath 为目录时使用备份的文件名。
+func (b *BackupIpc) DownloadBackup(id int, destPath string) *string {
+	backup, src, err := b.Manager.OpenBackup(id)
+	if err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	defer src.Close()
+
+	if info, err := os.Stat(destPath); err == nil && info.IsDir() {
+		destPath = filepath.Join(destPath, backup.FileName)
+	}
+	dst, err := os.Create(destPath)
+	if err == nil {
+		_, err = io.Copy(dst, src)
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		e := fmt.Sprintf("复制备份到 %s 失败: %v", destPath, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// GetBackupPolicy 获取指定ID进程的备份设置。
+func (b *BackupIpc) GetBackupPolicy(processId int) (*entity.BackupPolicy, *string) {
+	policy, err := b.Manager.GetPolicy(processId)
+	if err != nil {
+		e := fmt.Sprintf("读取ID为 %d 的进程的备份设置失败: %v", processId, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return &policy, nil
+}
+
+// SetBackupPolicy 设置指定ID进程的备份路径、格式与保留规则。
+func (b *BackupIpc) SetBackupPolicy(processId int, policy entity.BackupPolicy) *string {
+	if err := b.Manager.SetPolicy(processId, policy); err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程的备份设置失败: %v", processId, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// processBackupSource 从 ProcessIpc 管理的进程中获取服务器目录，并暂停其保存
+type processBackupSource struct {
+	processes *ProcessIpc
+}
+
+func (s *processBackupSource) ServerDir(processId int) (string, error) {
+	proc, err := s.processes.getProcess(processId)
+	if err != nil {
+		return "", err
+	}
+	return proc.precessManager.ServerDir(), nil
+}
+
+func (s *processBackupSource) HoldSave(processId int, edition string) (*vmanager.SaveHold, error) {
+	proc, err := s.processes.getProcess(processId)
+	if err != nil {
+		return nil, err
+	}
+	if !proc.precessManager.IsRunning() {
+		return nil, nil
+	}
+	return proc.precessManager.HoldSave(edition, 0)
+}
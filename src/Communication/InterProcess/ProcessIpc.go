@@ -1,11 +1,15 @@
 package inter_process
 
 import (
+	"encoding/json"
 	"fmt"
 	"path"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 	vcommon "voxesis/src/Common"
+	vdata "voxesis/src/Common/Data"
 	entity "voxesis/src/Common/Entity"
 	vlogger "voxesis/src/Common/Logger"
 	vmanager "voxesis/src/Common/Manager"
@@ -15,18 +19,130 @@ import (
 type Process struct {
 	logBuffer      *vutils.RateLimitBuffer
 	precessManager *vmanager.ProcessManager
+	players        *vmanager.PlayerTracker
+	definition     entity.ProcessDefinition
 }
 
 type ProcessIpc struct {
-	ProcessMap map[int]Process
-	NextID     int
-	mu         sync.RWMutex
+	ProcessMap   map[int]Process
+	NextID       int
+	Store        vdata.ProcessStore
+	MetricsStore vdata.MetricsStore
+	PlayerStore  vdata.PlayerStore
+	Rules        *vmanager.RuleEngine // 为 nil 时不匹配触发规则
+	collector    *vmanager.MetricsCollector
+	mu           sync.RWMutex
+}
+
+// LoadProcesses 从持久化存储中恢复所有进程定义，ID与上次运行时保持一致。
+func (p *ProcessIpc) LoadProcesses() error {
+	defs, err := p.Store.ListProcesses()
+	if err != nil {
+		return fmt.Errorf("读取进程定义失败: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, def := range defs {
+		// 数据库可能来自其他平台，终端类进程改用当前平台的实现
+		if resolved := int(vmanager.ResolveProcessType(vmanager.ProcessType(def.ProcessType))); resolved != def.ProcessType {
+			def.ProcessType = resolved
+			if err := p.Store.UpdateProcess(&def); err != nil {
+				vlogger.AppLogger.Warnf("更新ID为 %d 的进程类型失败: %v", def.Id, err)
+			}
+		}
+
+		proc, err := p.newProcess(def)
+		if err != nil {
+			vlogger.AppLogger.Errorf("恢复ID为 %d 的进程失败: %v", def.Id, err)
+			continue
+		}
+		p.ProcessMap[def.Id] = proc
+		if def.Id >= p.NextID {
+			p.NextID = def.Id + 1
+		}
+	}
+
+	return nil
+}
+
+// StartMetrics 开始定期采样所有运行中进程的资源使用情况。
+func (p *ProcessIpc) StartMetrics() {
+	p.collector = vmanager.NewMetricsCollector(p.MetricsStore, p.managers)
+	p.collector.Start()
+}
+
+// managers 返回进程ID到进程管理器的映射。
+func (p *ProcessIpc) managers() map[int]*vmanager.ProcessManager {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	managers := make(map[int]*vmanager.ProcessManager, len(p.ProcessMap))
+	for id, proc := range p.ProcessMap {
+		managers[id] = proc.precessManager
+	}
+	return managers
+}
+
+// newProcess 根据进程定义创建进程实例
+func (p *ProcessIpc) newProcess(def entity.ProcessDefinition) (Process, error) {
+	var options vmanager.ProcessOptions
+	if len(def.Options) > 0 {
+		if err := json.Unmarshal(def.Options, &options); err != nil {
+			return Process{}, fmt.Errorf("解析进程配置失败: %w", err)
+		}
+	}
+
+	manager := vmanager.NewProcessManager(vmanager.ProcessType(def.ProcessType), def.Path, def.Args...)
+	manager.SetLaunchConfig(def.WorkingDir, def.Env)
+	manager.SetPidFile(filepath.Join(vcommon.AppDir, "run", fmt.Sprintf("process-%d.pid", def.Id)))
+	if err := manager.ApplyOptions(options); err != nil {
+		return Process{}, err
+	}
+
+	id := def.Id
+	players := vmanager.NewPlayerTracker(p.PlayerStore, id, func(event entity.ProcessEvent) {
+		emitProcessEvent(id, event)
+	})
+	manager.WatchEvents(func(event entity.ProcessEvent) {
+		players.HandleEvent(event)
+		emitProcessEvent(id, event)
+	})
+
+	return Process{
+		logBuffer: vutils.NewRateLimitBuffer(10*time.Millisecond, func(data interface{}) {
+			vcommon.App.EmitEvent(fmt.Sprintf("process-%d-output", id), data)
+		}),
+		precessManager: manager,
+		players:        players,
+		definition:     def,
+	}, nil
+}
+
+// emitProcessEvent 将进程事件转发给 Web 端与前端
+func emitProcessEvent(id int, event entity.ProcessEvent) {
+	vcommon.App.EmitEvent(fmt.Sprintf("process-%d-event", id), event)
+	vcommon.ProcessCtrl.WriteProcessEvent(id, event)
 }
 
 func (p *ProcessIpc) NewProcess(processType vmanager.ProcessType, abs bool, relPath string, args ...string) int {
+	id, err := p.NewProcessWithOptions(processType, abs, relPath, args, vmanager.ProcessOptions{})
+	if id == nil {
+		vlogger.AppLogger.Error(*err)
+		return -1
+	}
+	return *id
+}
+
+// NewProcessWithOptions 创建进程并应用重启策略、停止流程等可选配置，进程定义会被持久化。
+// 若相同路径与类型的进程已存在，则更新其配置并返回已有的ID。
+func (p *ProcessIpc) NewProcessWithOptions(processType vmanager.ProcessType, abs bool, relPath string, args []string, options vmanager.ProcessOptions) (*int, *string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	id := p.NextID
+
+	// 终端类进程在非 Windows 平台上自动使用伪终端实现
+	processType = vmanager.ResolveProcessType(processType)
 
 	if !abs {
 		relPath = path.Join(vcommon.AppDir, relPath)
@@ -35,18 +151,173 @@ func (p *ProcessIpc) NewProcess(processType vmanager.ProcessType, abs bool, relP
 	// 检查该路径是否已经存在
 	for id, manager := range p.ProcessMap {
 		if manager.precessManager.Path == relPath && manager.precessManager.ProcessType == processType {
-			return id
+			if err := manager.precessManager.ApplyOptions(options); err != nil {
+				e := err.Error()
+				return &id, &e
+			}
+			if err := p.persistOptions(id, manager.precessManager); err != nil {
+				e := err.Error()
+				return &id, &e
+			}
+			return &id, nil
 		}
 	}
 
-	p.ProcessMap[id] = Process{
-		logBuffer: vutils.NewRateLimitBuffer(10*time.Millisecond, func(data interface{}) {
-			vcommon.App.EmitEvent(fmt.Sprintf("process-%d-output", id), data)
-		}),
-		precessManager: vmanager.NewProcessManager(processType, relPath, args...),
+	// 先校验配置，避免写入无效的进程定义
+	validator := vmanager.NewProcessManager(processType, relPath)
+	if err := validator.ApplyOptions(options); err != nil {
+		e := err.Error()
+		return nil, &e
 	}
-	p.NextID++
-	return id
+	rawOptions, err := json.Marshal(validator.GetOptions())
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	def := entity.ProcessDefinition{
+		ProcessType: int(processType),
+		Path:        relPath,
+		Args:        args,
+		Options:     rawOptions,
+	}
+	if err := p.Store.CreateProcess(&def); err != nil {
+		e := fmt.Sprintf("保存进程定义失败: %v", err)
+		return nil, &e
+	}
+
+	proc, err := p.newProcess(def)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	p.ProcessMap[def.Id] = proc
+	if def.Id >= p.NextID {
+		p.NextID = def.Id + 1
+	}
+	return &def.Id, nil
+}
+
+// ListProcesses 按ID顺序列出所有进程定义。
+func (p *ProcessIpc) ListProcesses() []entity.ProcessDefinition {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	defs := make([]entity.ProcessDefinition, 0, len(p.ProcessMap))
+	for _, proc := range p.ProcessMap {
+		defs = append(defs, proc.definition)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Id < defs[j].Id })
+
+	return defs
+}
+
+// GetProcess 获取指定ID的进程定义。
+func (p *ProcessIpc) GetProcess(id int) (*entity.ProcessDefinition, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	def := proc.definition
+	return &def, nil
+}
+
+// UpdateProcess 更新指定ID的进程定义，进程必须处于停止状态。
+func (p *ProcessIpc) UpdateProcess(id int, def entity.ProcessDefinition) *string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proc, ok := p.ProcessMap[id]
+	if !ok {
+		e := fmt.Sprintf("ID为 %d 的进程未找到", id)
+		return &e
+	}
+	if proc.precessManager.IsRunning() {
+		e := fmt.Sprintf("ID为 %d 的进程正在运行，请先停止后再修改", id)
+		return &e
+	}
+
+	def.Id = id
+	def.ProcessType = int(vmanager.ResolveProcessType(vmanager.ProcessType(def.ProcessType)))
+	if len(def.Options) == 0 {
+		def.Options = proc.definition.Options
+	}
+
+	updated, err := p.newProcess(def)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+	if err := p.Store.UpdateProcess(&def); err != nil {
+		e := fmt.Sprintf("保存进程定义失败: %v", err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	// 沿用原有的输出缓冲与回滚记录，保持事件通道不变、序号连续
+	updated.logBuffer = proc.logBuffer
+	updated.precessManager.InheritOutput(proc.precessManager)
+	p.ProcessMap[id] = updated
+	return nil
+}
+
+// DeleteProcess 删除指定ID的进程定义，进程必须处于停止状态。
+func (p *ProcessIpc) DeleteProcess(id int) *string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proc, ok := p.ProcessMap[id]
+	if !ok {
+		e := fmt.Sprintf("ID为 %d 的进程未找到", id)
+		return &e
+	}
+	if proc.precessManager.IsRunning() {
+		e := fmt.Sprintf("ID为 %d 的进程正在运行，请先停止后再删除", id)
+		return &e
+	}
+
+	if err := p.Store.DeleteProcess(id); err != nil {
+		e := fmt.Sprintf("删除进程定义失败: %v", err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	delete(p.ProcessMap, id)
+	if err := p.MetricsStore.DeleteMetrics(id); err != nil {
+		vlogger.AppLogger.Warnf("删除ID为 %d 的进程指标失败: %v", id, err)
+	}
+	if err := p.PlayerStore.DeleteSessions(id); err != nil {
+		vlogger.AppLogger.Warnf("删除ID为 %d 的进程的玩家会话失败: %v", id, err)
+	}
+	return nil
+}
+
+// persistOptions 将进程当前的可选配置写回持久化存储，调用方需持有写锁
+// manager 为修改了配置的进程管理器，进程在此期间被删除或替换时返回错误
+func (p *ProcessIpc) persistOptions(id int, manager *vmanager.ProcessManager) error {
+	proc, ok := p.ProcessMap[id]
+	if !ok {
+		return fmt.Errorf("ID为 %d 的进程未找到", id)
+	}
+	if proc.precessManager != manager {
+		return fmt.Errorf("ID为 %d 的进程定义已被修改，请重试", id)
+	}
+
+	rawOptions, err := json.Marshal(proc.precessManager.GetOptions())
+	if err != nil {
+		return err
+	}
+
+	proc.definition.Options = rawOptions
+	if err := p.Store.UpdateProcess(&proc.definition); err != nil {
+		return fmt.Errorf("保存进程定义失败: %w", err)
+	}
+
+	p.ProcessMap[id] = proc
+	return nil
 }
 
 func (p *ProcessIpc) getProcess(id int) (Process, error) {
@@ -69,11 +340,7 @@ func (p *ProcessIpc) Start(id int) *string {
 		return &e
 	}
 
-	err = proc.precessManager.Start(func(log string) {
-		vcommon.ProcessCtrl.WriteProcessOutput(id, log)
-		proc.logBuffer.Add(log)
-	})
-
+	err = proc.precessManager.Start(p.outputCallback(id, proc))
 	if err != nil {
 		e := fmt.Sprintf("启动ID为 %d 的进程失败: %v", id, err)
 		vlogger.AppLogger.Error(e)
@@ -83,18 +350,336 @@ func (p *ProcessIpc) Start(id int) *string {
 	return nil
 }
 
-// Stop 停止指定ID的进程。
-func (p *ProcessIpc) Stop(id int) *string {
+// outputCallback 解析服务器日志格式、交给玩家追踪与触发规则后，将进程输出转发给 Web 端与前端
+func (p *ProcessIpc) outputCallback(id int, proc Process) func(line entity.OutputLine) {
+	return func(line entity.OutputLine) {
+		line.Record = vutils.ParseLogLine(line.Data, line.Time)
+		proc.players.Observe(line)
+		if p.Rules != nil {
+			p.Rules.Observe(id, line)
+		}
+		vcommon.ProcessCtrl.WriteProcessOutput(id, line)
+		proc.logBuffer.Add(line)
+	}
+}
+
+// Attach 接管指定ID进程对应的、仍在运行但不是由当前 Voxesis 实例启动的服务器。
+// 优先使用启动时写入的 PID 文件，未找到时按可执行文件路径与工作目录查找。
+// 返回 (被接管进程的 PID, 错误信息字符串)
+func (p *ProcessIpc) Attach(id int) (*int32, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	pid, err := proc.precessManager.Attach(p.outputCallback(id, proc), true)
+	if err != nil {
+		e := fmt.Sprintf("接管ID为 %d 的进程失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return &pid, nil
+}
+
+// AttachRunning 根据 PID 文件接管上次运行时启动、至今仍在运行的所有服务器，应在界面与 Web 服务初始化后调用。
+func (p *ProcessIpc) AttachRunning() {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for id, proc := range p.ProcessMap {
+		// 没有 PID 文件或进程已退出时不做任何处理
+		_, _ = proc.precessManager.Attach(p.outputCallback(id, proc), false)
+	}
+}
+
+// StartWithProfile 选择启动配置后启动指定ID的进程，所选配置在之后的启动与自动重启中继续使用。
+// profile 为空表示不使用启动配置。
+func (p *ProcessIpc) StartWithProfile(id int, profile string) *string {
 	proc, err := p.getProcess(id)
 	if err != nil {
 		e := err.Error()
 		return &e
 	}
 
-	err = proc.precessManager.Stop()
+	if err := proc.precessManager.SelectProfile(profile); err != nil {
+		e := fmt.Sprintf("选择ID为 %d 的进程启动配置失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	err = p.persistOptions(id, proc.precessManager)
+	p.mu.Unlock()
+	if err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return p.Start(id)
+}
+
+// ListProfiles 获取指定ID进程的启动配置列表与当前选择的启动配置。
+func (p *ProcessIpc) ListProfiles(id int) (*vmanager.ProfileList, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	return &vmanager.ProfileList{
+		Profiles: proc.precessManager.ListProfiles(),
+		Selected: proc.precessManager.SelectedProfile(),
+	}, nil
+}
+
+// SetProfiles 设置指定ID进程的启动配置列表，下次启动时生效。
+func (p *ProcessIpc) SetProfiles(id int, profiles []vmanager.LaunchProfile) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetProfiles(profiles)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程启动配置失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// Stop 按停止流程停止指定ID的进程。
+// 返回 (停止结果, 错误信息字符串)，停止结果说明进程在哪个阶段退出。
+func (p *ProcessIpc) Stop(id int) (*vmanager.StopResult, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	result, err := proc.precessManager.Stop()
 	if err != nil {
 		e := fmt.Sprintf("停止ID为 %d 的进程失败: %v", id, err)
 		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return &result, nil
+}
+
+// Restart 按停止流程停止指定ID的进程后重新启动，进程未运行时直接启动。
+func (p *ProcessIpc) Restart(id int) *string {
+	if _, e := p.Stop(id); e != nil {
+		return e
+	}
+	return p.Start(id)
+}
+
+// PlanRestart 在 delaySec 秒后重启指定ID的进程，期间按倒计时广播设置通知玩家。
+// 返回 (计划重启的进度, 错误信息字符串)，之后的进度通过 restart-* 事件通知
+func (p *ProcessIpc) PlanRestart(id int, delaySec int, reason string) (*entity.RestartProgress, *string) {
+	progress, _, err := p.planRestart(id, time.Duration(delaySec)*time.Second, reason)
+	if err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return &progress, nil
+}
+
+// planRestart 发起计划重启，返回的通道在重启结束后收到结果
+func (p *ProcessIpc) planRestart(id int, delay time.Duration, reason string) (entity.RestartProgress, <-chan error, error) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		return entity.RestartProgress{}, nil, err
+	}
+
+	progress, done, err := proc.precessManager.PlanRestart(delay, reason)
+	if err != nil {
+		return progress, nil, fmt.Errorf("计划重启ID为 %d 的进程失败: %w", id, err)
+	}
+	return progress, done, nil
+}
+
+// AbortRestart 取消指定ID进程正在倒计时的计划重启。
+func (p *ProcessIpc) AbortRestart(id int) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	if err := proc.precessManager.AbortRestart(); err != nil {
+		e := fmt.Sprintf("取消ID为 %d 的进程的计划重启失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// GetPlannedRestart 获取指定ID进程正在进行的计划重启，没有时返回 nil。
+func (p *ProcessIpc) GetPlannedRestart(id int) (*entity.RestartProgress, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	return proc.precessManager.GetPlannedRestart(), nil
+}
+
+// SetRestartNotice 设置指定ID进程计划重启时的倒计时广播。
+func (p *ProcessIpc) SetRestartNotice(id int, notice vmanager.RestartNotice) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetRestartNotice(notice)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程重启广播失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// SetReadinessProbe 设置指定ID进程的就绪检测，下次启动时生效。
+func (p *ProcessIpc) SetReadinessProbe(id int, probe vmanager.ReadinessProbe) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetReadinessProbe(probe)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程就绪检测失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// SetResourceLimits 设置指定ID进程的资源限制，下次启动时生效，仅 Linux 上的普通进程支持。
+func (p *ProcessIpc) SetResourceLimits(id int, limits entity.ResourceLimits) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetResourceLimits(limits)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程资源限制失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// SetStopSequence 设置指定ID进程的优雅停止流程。
+func (p *ProcessIpc) SetStopSequence(id int, sequence vmanager.StopSequence) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetStopSequence(sequence)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程停止流程失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// GetOutputSince 获取指定ID进程中序号大于 since 的控制台输出，用于重连后补齐历史。
+func (p *ProcessIpc) GetOutputSince(id int, since uint64) (*vmanager.OutputReplay, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	replay := proc.precessManager.OutputSince(since)
+	for i := range replay.Lines {
+		replay.Lines[i].Record = vutils.ParseLogLine(replay.Lines[i].Data, replay.Lines[i].Time)
+	}
+	return &replay, nil
+}
+
+// SetScrollbackConfig 设置指定ID进程的控制台回滚缓冲区容量。
+func (p *ProcessIpc) SetScrollbackConfig(id int, config vmanager.ScrollbackConfig) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetScrollbackConfig(config)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程回滚缓冲区失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
 		return &e
 	}
 
@@ -119,6 +704,76 @@ func (p *ProcessIpc) SendCommand(id int, command string) *string {
 	return nil
 }
 
+// QueueCommand 向指定ID的进程发送命令，进程尚未就绪时排队等待，就绪后按顺序发送。
+// ttlMs 为命令在队列中的有效期 (毫秒)，不大于 0 时使用默认值。
+// 返回 (排队的命令, 错误信息字符串)，命令被立即发送时排队的命令为 nil。
+func (p *ProcessIpc) QueueCommand(id int, command string, ttlMs int) (*vmanager.QueuedCommand, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	queued, err := proc.precessManager.QueueCommand(command, time.Duration(ttlMs)*time.Millisecond)
+	if err != nil {
+		e := fmt.Sprintf("向ID为 %d 的进程发送命令失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return queued, nil
+}
+
+// ExecuteCommand 向指定ID的进程发送命令并收集其响应输出。
+// 返回 (响应, 错误信息字符串)，同一进程上的请求依次执行，输出不会混杂。
+func (p *ProcessIpc) ExecuteCommand(id int, request vmanager.CommandRequest) (*vmanager.CommandResponse, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	response, err := proc.precessManager.ExecuteCommand(request)
+	if err != nil {
+		e := fmt.Sprintf("在ID为 %d 的进程上执行命令失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return &response, nil
+}
+
+// ListQueuedCommands 列出指定ID进程中等待发送的命令。
+func (p *ProcessIpc) ListQueuedCommands(id int) ([]vmanager.QueuedCommand, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	return proc.precessManager.ListQueuedCommands(), nil
+}
+
+// CancelQueuedCommand 取消指定ID进程中的一条排队命令，commandId 为 0 时清空整个队列。
+func (p *ProcessIpc) CancelQueuedCommand(id int, commandId int) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	if commandId == 0 {
+		proc.precessManager.ClearCommandQueue()
+		return nil
+	}
+	if err := proc.precessManager.CancelQueuedCommand(commandId); err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	return nil
+}
+
 // GetProcessStatus 获取指定ID进程的状态。
 // 返回 (状态指针, 错误信息字符串)
 func (p *ProcessIpc) GetProcessStatus(id int) (*entity.ProcessState, *string) {
@@ -137,3 +792,135 @@ func (p *ProcessIpc) GetProcessStatus(id int) (*entity.ProcessState, *string) {
 
 	return &status, nil
 }
+
+// GetMetrics 获取指定ID进程在 [from, to) 内按 step 聚合的资源使用历史。
+// from、to 为 Unix 毫秒，step 为 0 时按查询范围自动选择。
+func (p *ProcessIpc) GetMetrics(id int, from int64, to int64, step time.Duration) ([]entity.MetricSample, *string) {
+	if _, err := p.getProcess(id); err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	samples, err := p.MetricsStore.QueryMetrics(id, time.UnixMilli(from), time.UnixMilli(to), step)
+	if err != nil {
+		e := fmt.Sprintf("查询ID为 %d 的进程指标失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return samples, nil
+}
+
+// GetOnlinePlayers 获取指定ID进程中当前在线的玩家，按加入时间排序。
+func (p *ProcessIpc) GetOnlinePlayers(id int) ([]entity.OnlinePlayer, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	return proc.players.Online(), nil
+}
+
+// GetPlaytime 获取指定ID进程中每个玩家的累计游戏时长，按时长降序排列。
+func (p *ProcessIpc) GetPlaytime(id int) ([]entity.PlayerPlaytime, *string) {
+	if _, err := p.getProcess(id); err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	playtimes, err := p.PlayerStore.QueryPlaytime(id)
+	if err != nil {
+		e := fmt.Sprintf("查询ID为 %d 的进程的玩家游戏时长失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return playtimes, nil
+}
+
+// GetPlayerSessions 获取玩家在指定ID进程中的会话历史，按加入时间倒序排列，limit 为 0 时不限制。
+func (p *ProcessIpc) GetPlayerSessions(id int, name string, limit int) ([]entity.PlayerSession, *string) {
+	if _, err := p.getProcess(id); err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	sessions, err := p.PlayerStore.QuerySessions(id, name, limit)
+	if err != nil {
+		e := fmt.Sprintf("查询玩家 %s 的会话历史失败: %v", name, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+
+	return sessions, nil
+}
+
+// GetLifecycle 获取指定ID进程的生命周期状态，进程未运行时同样可用。
+// 返回 (生命周期指针, 错误信息字符串)
+func (p *ProcessIpc) GetLifecycle(id int) (*entity.ProcessLifecycle, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	lifecycle := proc.precessManager.GetLifecycle()
+	return &lifecycle, nil
+}
+
+// Resize 调整指定ID进程的终端尺寸。
+func (p *ProcessIpc) Resize(id int, cols uint16, rows uint16) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.Resize(cols, rows)
+	if err != nil {
+		e := fmt.Sprintf("调整ID为 %d 的进程终端尺寸失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// SetRestartPolicy 设置指定ID进程的自动重启策略。
+func (p *ProcessIpc) SetRestartPolicy(id int, policy vmanager.RestartPolicy) *string {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return &e
+	}
+
+	err = proc.precessManager.SetRestartPolicy(policy)
+	if err != nil {
+		e := fmt.Sprintf("设置ID为 %d 的进程重启策略失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.persistOptions(id, proc.precessManager); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+
+	return nil
+}
+
+// GetRestartStatus 获取指定ID进程的守护器状态，包含重启策略与崩溃循环信息。
+func (p *ProcessIpc) GetRestartStatus(id int) (*vmanager.SupervisorStatus, *string) {
+	proc, err := p.getProcess(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+
+	status := proc.precessManager.GetSupervisorStatus()
+	return &status, nil
+}
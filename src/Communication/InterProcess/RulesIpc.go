@@ -0,0 +1,102 @@
+package inter_process
+
+import (
+	"errors"
+	"fmt"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vmanager "voxesis/src/Common/Manager"
+)
+
+type RulesIpc struct {
+	Engine *vmanager.RuleEngine
+}
+
+// NewRulesIpc 加载已保存的规则，并将规则引擎接入 processes 的输出
+func NewRulesIpc(store vdata.RuleStore, processes *ProcessIpc) (*RulesIpc, error) {
+	engine, err := vmanager.NewRuleEngine(store, &processRuleTarget{processes: processes})
+	if err != nil {
+		return nil, err
+	}
+	processes.Rules = engine
+	return &RulesIpc{Engine: engine}, nil
+}
+
+// ListRules 按ID顺序列出所有触发规则。
+func (r *RulesIpc) ListRules() []entity.Rule {
+	return r.Engine.ListRules()
+}
+
+// GetRule 获取指定ID的触发规则。
+func (r *RulesIpc) GetRule(id int) (*entity.Rule, *string) {
+	rule, err := r.Engine.GetRule(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+	return &rule, nil
+}
+
+// CreateRule 新增触发规则，规则立即生效。
+// 返回 (分配了ID的规则, 错误信息字符串)
+func (r *RulesIpc) CreateRule(rule entity.Rule) (*entity.Rule, *string) {
+	created, err := r.Engine.CreateRule(rule)
+	if err != nil {
+		e := fmt.Sprintf("创建规则失败: %v", err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return &created, nil
+}
+
+// UpdateRule 更新指定ID的触发规则，规则立即生效。
+func (r *RulesIpc) UpdateRule(id int, rule entity.Rule) *string {
+	rule.Id = id
+	if err := r.Engine.UpdateRule(rule); err != nil {
+		e := fmt.Sprintf("更新ID为 %d 的规则失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// DeleteRule 删除指定ID的触发规则。
+func (r *RulesIpc) DeleteRule(id int) *string {
+	if err := r.Engine.DeleteRule(id); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// processRuleTarget 让规则动作作用于 ProcessIpc 管理的进程
+type processRuleTarget struct {
+	processes *ProcessIpc
+}
+
+func (t *processRuleTarget) SendCommand(processId int, command string) error {
+	return ipcError(t.processes.SendCommand(processId, command))
+}
+
+func (t *processRuleTarget) StopProcess(processId int) error {
+	_, e := t.processes.Stop(processId)
+	return ipcError(e)
+}
+
+func (t *processRuleTarget) RestartProcess(processId int) error {
+	return ipcError(t.processes.Restart(processId))
+}
+
+func (t *processRuleTarget) EmitEvent(processId int, event entity.ProcessEvent) {
+	emitProcessEvent(processId, event)
+}
+
+// ipcError 将 IPC 方法返回的错误信息转换为 error
+func ipcError(e *string) error {
+	if e == nil {
+		return nil
+	}
+	return errors.New(*e)
+}
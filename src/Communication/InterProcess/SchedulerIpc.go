@@ -0,0 +1,131 @@
+package inter_process
+
+import (
+	"fmt"
+	"time"
+	vdata "voxesis/src/Common/Data"
+	entity "voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vmanager "voxesis/src/Common/Manager"
+)
+
+type SchedulerIpc struct {
+	Scheduler *vmanager.Scheduler
+}
+
+// NewSchedulerIpc 加载已保存的定时任务，任务作用于 processes 管理的进程，backup 任务由 backups 执行
+// 任务可能推送进程事件，需在界面与 Web 端初始化后调用 Scheduler.Start 开始调度
+func NewSchedulerIpc(store vdata.ScheduleStore, processes *ProcessIpc, backups *BackupIpc) (*SchedulerIpc, error) {
+	scheduler, err := vmanager.NewScheduler(store, &processScheduleTarget{processes: processes, backups: backups})
+	if err != nil {
+		return nil, err
+	}
+	return &SchedulerIpc{Scheduler: scheduler}, nil
+}
+
+// ListJobs 按ID顺序列出所有定时任务。
+func (s *SchedulerIpc) ListJobs() []entity.ScheduledJob {
+	return s.Scheduler.ListJobs()
+}
+
+// GetJob 获取指定ID的定时任务。
+func (s *SchedulerIpc) GetJob(id int) (*entity.ScheduledJob, *string) {
+	job, err := s.Scheduler.GetJob(id)
+	if err != nil {
+		e := err.Error()
+		return nil, &e
+	}
+	return &job, nil
+}
+
+// CreateJob 新增定时任务，任务立即生效。
+// 返回 (分配了ID的任务, 错误信息字符串)
+func (s *SchedulerIpc) CreateJob(job entity.ScheduledJob) (*entity.ScheduledJob, *string) {
+	created, err := s.Scheduler.CreateJob(job)
+	if err != nil {
+		e := fmt.Sprintf("创建定时任务失败: %v", err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return &created, nil
+}
+
+// UpdateJob 更新指定ID的定时任务，下一次执行的时间从现在起重新计算。
+func (s *SchedulerIpc) UpdateJob(id int, job entity.ScheduledJob) *string {
+	job.Id = id
+	if err := s.Scheduler.UpdateJob(job); err != nil {
+		e := fmt.Sprintf("更新ID为 %d 的定时任务失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// DeleteJob 删除指定ID的定时任务及其执行记录。
+func (s *SchedulerIpc) DeleteJob(id int) *string {
+	if err := s.Scheduler.DeleteJob(id); err != nil {
+		e := err.Error()
+		vlogger.AppLogger.Error(e)
+		return &e
+	}
+	return nil
+}
+
+// RunJob 立即执行一次定时任务，不影响下一次计划执行的时间。
+// 返回 (执行记录, 错误信息字符串)，任务在后台执行，结果通过 ListRuns 查询
+func (s *SchedulerIpc) RunJob(id int) (*entity.JobRun, *string) {
+	run, err := s.Scheduler.RunJob(id)
+	if err != nil {
+		e := fmt.Sprintf("执行ID为 %d 的定时任务失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return &run, nil
+}
+
+// ListRuns 按计划时间倒序列出定时任务的执行记录，limit 为 0 时不限制。
+func (s *SchedulerIpc) ListRuns(id int, limit int) ([]entity.JobRun, *string) {
+	runs, err := s.Scheduler.ListRuns(id, limit)
+	if err != nil {
+		e := fmt.Sprintf("读取ID为 %d 的定时任务的执行记录失败: %v", id, err)
+		vlogger.AppLogger.Error(e)
+		return nil, &e
+	}
+	return runs, nil
+}
+
+// processScheduleTarget 让定时任务作用于 ProcessIpc 管理的进程
+type processScheduleTarget struct {
+	processes *ProcessIpc
+	backups   *BackupIpc
+}
+
+func (t *processScheduleTarget) SendCommand(processId int, command string) error {
+	return ipcError(t.processes.SendCommand(processId, command))
+}
+
+func (t *processScheduleTarget) StartProcess(processId int) error {
+	return ipcError(t.processes.Start(processId))
+}
+
+func (t *processScheduleTarget) StopProcess(processId int) error {
+	_, e := t.processes.Stop(processId)
+	return ipcError(e)
+}
+
+// RestartProcess 有倒计时时等待计划重启结束，定时任务的结果即为重启的结果
+func (t *processScheduleTarget) RestartProcess(processId int, delay time.Duration) error {
+	if delay <= 0 {
+		return ipcError(t.processes.Restart(processId))
+	}
+	_, done, err := t.processes.planRestart(processId, delay, "定时任务")
+	if err != nil {
+		return err
+	}
+	return <-done
+}
+
+func (t *processScheduleTarget) Backup(processId int) error {
+	_, err := t.backups.Manager.CreateBackup(processId, vmanager.BackupTriggerSchedule)
+	return err
+}
@@ -1,6 +1,10 @@
 package communication
 
 import (
+	"log"
+	"path/filepath"
+	vcommon "voxesis/src/Common"
+	vdataimpl "voxesis/src/Common/Data/impl"
 	vlogger "voxesis/src/Common/Logger"
 	vmanager "voxesis/src/Common/Manager"
 	interprocess "voxesis/src/Communication/InterProcess"
@@ -12,6 +16,9 @@ var (
 	ConfigIpc       *interprocess.ConfigIpc
 	PluginIpc       *interprocess.PluginIpc
 	ProcessIpc      *interprocess.ProcessIpc
+	RulesIpc        *interprocess.RulesIpc
+	SchedulerIpc    *interprocess.SchedulerIpc
+	BackupIpc       *interprocess.BackupIpc
 	SystemDialogIpc *interprocess.SystemDialogIpc
 )
 
@@ -21,6 +28,9 @@ func Init() {
 	ConfigIpc = initConfigIpc()
 	PluginIpc = initPluginIpc()
 	ProcessIpc = initProcessIpc()
+	RulesIpc = initRulesIpc(ProcessIpc)
+	BackupIpc = initBackupIpc(ProcessIpc)
+	SchedulerIpc = initSchedulerIpc(ProcessIpc, BackupIpc)
 	SystemDialogIpc = &interprocess.SystemDialogIpc{}
 }
 
@@ -43,8 +53,70 @@ func initConfigIpc() *interprocess.ConfigIpc {
 }
 
 func initProcessIpc() *interprocess.ProcessIpc {
-	return &interprocess.ProcessIpc{
-		ProcessMap: make(map[int]interprocess.Process),
-		NextID:     1,
+	store, err := vdataimpl.NewProcessStoreImpl(vdataimpl.DB)
+	if err != nil {
+		log.Fatalf("进程存储初始化失败: %v\n", err)
 	}
+
+	metricsStore, err := vdataimpl.NewMetricsStoreImpl(vdataimpl.DB)
+	if err != nil {
+		log.Fatalf("进程指标存储初始化失败: %v\n", err)
+	}
+
+	playerStore, err := vdataimpl.NewPlayerStoreImpl(vdataimpl.DB)
+	if err != nil {
+		log.Fatalf("玩家会话存储初始化失败: %v\n", err)
+	}
+
+	processIpc := &interprocess.ProcessIpc{
+		ProcessMap:   make(map[int]interprocess.Process),
+		NextID:       1,
+		Store:        store,
+		MetricsStore: metricsStore,
+		PlayerStore:  playerStore,
+	}
+
+	if err := processIpc.LoadProcesses(); err != nil {
+		vlogger.AppLogger.Error(err.Error())
+	}
+	processIpc.StartMetrics()
+
+	return processIpc
+}
+
+func initRulesIpc(processIpc *interprocess.ProcessIpc) *interprocess.RulesIpc {
+	store, err := vdataimpl.NewRuleStoreImpl(vdataimpl.DB)
+	if err != nil {
+		log.Fatalf("规则存储初始化失败: %v\n", err)
+	}
+
+	rulesIpc, err := interprocess.NewRulesIpc(store, processIpc)
+	if err != nil {
+		log.Fatalf("规则引擎初始化失败: %v\n", err)
+	}
+
+	return rulesIpc
+}
+
+func initBackupIpc(processIpc *interprocess.ProcessIpc) *interprocess.BackupIpc {
+	store, err := vdataimpl.NewBackupStoreImpl(vdataimpl.DB)
+	if err != nil {
+		log.Fatalf("备份存储初始化失败: %v\n", err)
+	}
+
+	return interprocess.NewBackupIpc(store, processIpc, filepath.Join(vcommon.AppDir, "backups"))
+}
+
+func initSchedulerIpc(processIpc *interprocess.ProcessIpc, backupIpc *interprocess.BackupIpc) *interprocess.SchedulerIpc {
+	store, err := vdataimpl.NewScheduleStoreImpl(vdataimpl.DB)
+	if err != nil {
+		log.Fatalf("定时任务存储初始化失败: %v\n", err)
+	}
+
+	schedulerIpc, err := interprocess.NewSchedulerIpc(store, processIpc, backupIpc)
+	if err != nil {
+		log.Fatalf("调度器初始化失败: %v\n", err)
+	}
+
+	return schedulerIpc
 }
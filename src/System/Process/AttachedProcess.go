@@ -0,0 +1,106 @@
+package process
+
+import (
+	"fmt"
+	"time"
+	"voxesis/src/Common/Entity"
+	BaseProcess "voxesis/src/System/Process/Base"
+)
+
+// AttachedProcess 接管一个仍在运行、但不是由当前 Voxesis 实例启动的服务器。
+type AttachedProcess struct {
+	manager *BaseProcess.AttachedProcessManager
+	pid     int32
+
+	createTime   int64
+	stdinFifo    string
+	exitCallback func(err error)
+	stopTimeout  time.Duration
+}
+
+// NewAttachedProcess 创建接管 PID 为 pid 的服务器的进程实例，Start 时才真正接管。
+// createTime 不为 0 时校验进程的创建时间，stdinFifo 为服务器的标准输入命名管道，为空表示无法发送命令。
+func NewAttachedProcess(pid int32, createTime int64, stdinFifo string) *AttachedProcess {
+	return &AttachedProcess{
+		pid:        pid,
+		createTime: createTime,
+		stdinFifo:  stdinFifo,
+	}
+}
+
+// SetExitCallback 设置服务器退出时的回调，被接管的服务器无法获取退出码，参数总是 nil。
+func (m *AttachedProcess) SetExitCallback(callback func(err error)) {
+	m.exitCallback = callback
+}
+
+// SetWorkingDir 被接管的服务器已在运行，忽略。
+func (m *AttachedProcess) SetWorkingDir(dir string) {}
+
+// SetEnv 被接管的服务器已在运行，忽略。
+func (m *AttachedProcess) SetEnv(env []string) {}
+
+// SetStopTimeout 设置停止时等待服务器自行退出的时长，超时后强制终止。
+func (m *AttachedProcess) SetStopTimeout(timeout time.Duration) {
+	m.stopTimeout = timeout
+	if m.manager != nil {
+		m.manager.SetStopTimeout(timeout)
+	}
+}
+
+// Start 接管服务器并开始监控，被接管的服务器无法获取输出，logCallback 与 args 均被忽略。
+func (m *AttachedProcess) Start(logCallback func(log string), args []string) error {
+	if m.manager != nil && m.manager.IsRunning() {
+		return fmt.Errorf("已接管 PID 为 %d 的服务器", m.pid)
+	}
+
+	var err error
+	if m.manager, err = BaseProcess.NewAttachedProcessManager(m.pid, m.createTime, m.stdinFifo); err != nil {
+		return err
+	}
+
+	if m.exitCallback != nil {
+		m.manager.SetExitCallback(m.exitCallback)
+	}
+	if m.stopTimeout > 0 {
+		m.manager.SetStopTimeout(m.stopTimeout)
+	}
+	return m.manager.Attach()
+}
+
+func (m *AttachedProcess) Stop() error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return nil // 未运行，视为成功停止
+	}
+	return m.manager.Stop()
+}
+
+func (m *AttachedProcess) SendCommand(command string) error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.SendCommand(command)
+}
+
+// GetLifecycle 获取服务器的生命周期记录，从未接管时为 created 状态。
+func (m *AttachedProcess) GetLifecycle() entity.ProcessLifecycle {
+	if m.manager == nil {
+		return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+	}
+	return m.manager.GetLifecycle()
+}
+
+// IsRunning 检查服务器是否在运行。
+func (m *AttachedProcess) IsRunning() bool {
+	if m.manager == nil {
+		return false
+	}
+	return m.manager.IsRunning()
+}
+
+// GetStatus 获取服务器进程的状态。
+func (m *AttachedProcess) GetStatus() (entity.ProcessState, error) {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return entity.ProcessState{}, fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.GetProcessStatus()
+}
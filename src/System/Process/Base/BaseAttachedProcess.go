@@ -0,0 +1,340 @@
+package BaseProcess
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// attachPollInterval 检测被接管进程是否退出的周期
+const attachPollInterval = 500 * time.Millisecond
+
+// AttachedProcessManager 管理一个并非由当前 Voxesis 实例启动、但仍在运行的进程。
+// 无法获取其输出与退出码，只能监控资源、通过信号停止，以及在标准输入为 Voxesis 创建的命名管道时发送命令。
+// 此结构体的所有方法都是并发安全的。
+type AttachedProcessManager struct {
+	mu           sync.RWMutex
+	pid          int32
+	createTime   int64  // 进程创建时间 (Unix 毫秒)，用于识别 PID 复用
+	pgid         int    // 进程是进程组组长时为其进程组ID，否则为 0
+	stdinFifo    string // 标准输入命名管道的路径，为空表示无法发送命令
+	stdin        *os.File
+	exitCallback func(err error)
+	exited       chan struct{} // 进程退出后关闭，未接管时为 nil
+	stopTimeout  time.Duration // 发送 SIGTERM 后等待退出的时长
+	lifecycle    lifecycleRecorder
+	stopping     bool // Stop 正在终止进程树
+
+	monitor *treeMonitor // 进程树资源监控，进程运行时不为 nil
+}
+
+// NewAttachedProcessManager 为 PID 为 pid 的进程创建接管管理器。
+// createTime 不为 0 时校验进程的创建时间，防止接管复用了相同 PID 的其他进程。
+func NewAttachedProcessManager(pid int32, createTime int64, stdinFifo string) (*AttachedProcessManager, error) {
+	created, err := ProcessCreateTime(pid)
+	if err != nil {
+		return nil, err
+	}
+	if createTime != 0 && created != createTime {
+		return nil, fmt.Errorf("PID %d 已被其他进程复用", pid)
+	}
+	return &AttachedProcessManager{
+		pid:         pid,
+		createTime:  created,
+		stdinFifo:   stdinFifo,
+		stopTimeout: DefaultStopTimeout,
+	}, nil
+}
+
+// ProcessCreateTime 返回进程的创建时间 (Unix 毫秒)，进程不存在时返回错误。
+func ProcessCreateTime(pid int32) (int64, error) {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return 0, fmt.Errorf("PID 为 %d 的进程不存在: %w", pid, err)
+	}
+	created, err := proc.CreateTime()
+	if err != nil {
+		return 0, fmt.Errorf("读取进程 %d 的创建时间失败: %w", pid, err)
+	}
+	return created, nil
+}
+
+// FindProcessByPath 查找可执行文件 (或由解释器运行的脚本) 为 path、工作目录为 workingDir 的进程。
+// 存在多个匹配时返回最早创建的一个，即匹配进程树的根进程；未找到时返回 0。
+func FindProcessByPath(path, workingDir string) (int32, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return 0, fmt.Errorf("列出系统进程失败: %w", err)
+	}
+
+	var (
+		found    int32
+		earliest int64
+		self     = int32(os.Getpid())
+	)
+	for _, proc := range procs {
+		if proc.Pid == self || !matchExecutable(proc, path) {
+			continue
+		}
+		// Windows 下无法读取其他进程的工作目录，只按可执行文件匹配
+		if cwd, err := proc.Cwd(); err == nil {
+			if !samePath(cwd, workingDir) {
+				continue
+			}
+		} else if runtime.GOOS != "windows" {
+			continue
+		}
+
+		created, err := proc.CreateTime()
+		if err != nil {
+			continue
+		}
+		if found == 0 || created < earliest {
+			found, earliest = proc.Pid, created
+		}
+	}
+	return found, nil
+}
+
+// matchExecutable 判断进程的可执行文件或前两个命令行参数是否为 path，后者用于匹配由 sh、java 等运行的脚本
+func matchExecutable(proc *process.Process, path string) bool {
+	if exe, err := proc.Exe(); err == nil && samePath(exe, path) {
+		return true
+	}
+	args, err := proc.CmdlineSlice()
+	if err != nil {
+		return false
+	}
+	for i := 0; i < len(args) && i < 2; i++ {
+		if samePath(args[i], path) {
+			return true
+		}
+	}
+	return false
+}
+
+// samePath 比较两个路径是否指向同一文件，Windows 下不区分大小写
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	a, b = filepath.Clean(a), filepath.Clean(b)
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// SetExitCallback 设置进程退出时的回调，被接管的进程无法获取退出码，参数总是 nil。
+func (pm *AttachedProcessManager) SetExitCallback(callback func(err error)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.exitCallback = callback
+}
+
+// SetStopTimeout 设置 Stop 发送 SIGTERM 后等待进程退出的时长。
+func (pm *AttachedProcessManager) SetStopTimeout(timeout time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	pm.stopTimeout = timeout
+}
+
+// Attach 开始监控进程并等待其退出，标准输入命名管道无法打开时只记录警告。
+func (pm *AttachedProcessManager) Attach() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.exited != nil {
+		return fmt.Errorf("此管理器已接管 PID 为 %d 的进程", pm.pid)
+	}
+	root := pm.rootSnapshot()
+	if !root.alive() {
+		return fmt.Errorf("PID 为 %d 的进程已退出", pm.pid)
+	}
+
+	if pm.stdinFifo != "" {
+		stdin, err := openStdinFifo(pm.stdinFifo)
+		if err != nil {
+			vlogger.AppLogger.Warnf("PID %d 的标准输入不可用，将无法发送命令: %v", pm.pid, err)
+		}
+		pm.stdin = stdin
+	}
+
+	pm.pgid = leaderGroup(int(pm.pid))
+	pm.lifecycle.attached(time.UnixMilli(pm.createTime))
+	pm.monitor = newTreeMonitor(pm.pid)
+
+	exited := make(chan struct{})
+	pm.exited = exited
+	go pm.watch(root, exited)
+
+	vlogger.AppLogger.Infof("已接管进程, PID: %d", pm.pid)
+	return nil
+}
+
+// watch 定期检查进程是否仍在运行，退出后清理残留的后代进程并上报退出
+func (pm *AttachedProcessManager) watch(root treeSnapshot, exited chan struct{}) {
+	ticker := time.NewTicker(attachPollInterval)
+	defer ticker.Stop()
+	for root.alive() {
+		<-ticker.C
+	}
+
+	pm.lifecycle.exitedUnknown()
+	close(exited)
+
+	pm.mu.Lock()
+	tree := treeSnapshot{pgid: pm.pgid}
+	if pm.monitor != nil {
+		tree = pm.monitor.snapshot(pm.pgid)
+	}
+	pm.release()
+	callback, timeout, stopping := pm.exitCallback, pm.stopTimeout, pm.stopping
+	pm.mu.Unlock()
+
+	// 由 Stop 触发的退出由 Stop 负责清理
+	if !stopping {
+		reapOrphans(tree, timeout)
+	}
+
+	if callback != nil {
+		callback(nil)
+	}
+}
+
+// release 停止监控并关闭标准输入，调用方需持有写锁
+func (pm *AttachedProcessManager) release() {
+	if pm.monitor != nil {
+		pm.monitor.close()
+		pm.monitor = nil
+	}
+	if pm.stdin != nil {
+		_ = pm.stdin.Close()
+		pm.stdin = nil
+	}
+	if pm.stdinFifo != "" {
+		_ = os.Remove(pm.stdinFifo)
+	}
+}
+
+// rootSnapshot 返回只包含被接管进程本身的快照，用于判断它是否仍在运行
+func (pm *AttachedProcessManager) rootSnapshot() treeSnapshot {
+	return treeSnapshot{procs: map[int32]int64{pm.pid: pm.createTime}}
+}
+
+// Stop 终止整个进程树。
+// 先发送 SIGTERM，超过 stopTimeout 仍未退出则强制杀死并返回 ErrForceKilled。
+// Windows 下无法向其他控制台进程发送 SIGTERM，只能等待超时后强制终止。
+func (pm *AttachedProcessManager) Stop() error {
+	pm.mu.Lock()
+	exited, timeout := pm.exited, pm.stopTimeout
+	if exited == nil || !pm.running() {
+		pm.mu.Unlock()
+		return nil
+	}
+	tree := snapshotTree(pm.pid, pm.pgid)
+	pm.stopping = true
+	pm.mu.Unlock()
+
+	deadline := time.Now().Add(timeout)
+	pm.lifecycle.stopping()
+	signalTree(tree, syscall.SIGTERM)
+
+	forced := false
+	select {
+	case <-exited:
+	case <-time.After(timeout):
+		signalTree(tree, syscall.SIGKILL)
+		select {
+		case <-exited:
+		case <-time.After(treeKillTimeout):
+		}
+		forced = true
+	}
+
+	// 主进程退出后确认整个进程树都已退出，避免残留进程继续占用端口
+	err := reapTree(tree, time.Until(deadline))
+	if forced {
+		if err != nil {
+			vlogger.AppLogger.Warnf("%v", err)
+		}
+		return ErrForceKilled
+	}
+	return err
+}
+
+// GetProcessStatus 返回进程树的当前资源使用情况，从最近一次采样读取。
+func (pm *AttachedProcessManager) GetProcessStatus() (entity.ProcessState, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	state := entity.ProcessState{}
+	if !pm.running() {
+		return state, nil
+	}
+
+	state.Pid = fmt.Sprintf("%d", pm.pid)
+	pm.monitor.fill(&state)
+	state.RunTime = time.Since(time.UnixMilli(pm.createTime)).Round(time.Second).String()
+	return state, nil
+}
+
+// GetLifecycle 返回进程的生命周期记录，启动时间为进程的创建时间，退出码总是未知。
+func (pm *AttachedProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	return pm.lifecycle.snapshot()
+}
+
+// IsRunning 检查被接管的进程是否仍在运行。
+func (pm *AttachedProcessManager) IsRunning() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.running()
+}
+
+// running 调用方需持有读锁
+func (pm *AttachedProcessManager) running() bool {
+	if pm.exited == nil {
+		return false
+	}
+	select {
+	case <-pm.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// SendCommand 通过标准输入命名管道向进程发送命令。
+func (pm *AttachedProcessManager) SendCommand(command string) error {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if pm.stdin == nil {
+		return fmt.Errorf("被接管的进程没有可用的标准输入，无法发送命令")
+	}
+	if len(command) == 0 || command[len(command)-1] != '\n' {
+		command += "\n"
+	}
+	if _, err := io.WriteString(pm.stdin, command); err != nil {
+		return fmt.Errorf("向进程标准输入写入失败: %w", err)
+	}
+	return nil
+}
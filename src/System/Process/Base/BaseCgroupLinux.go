@@ -0,0 +1,213 @@
+//go:build linux
+
+package BaseProcess
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"voxesis/src/Common/Entity"
+)
+
+// cgroupRoot cgroup v2 统一层级的挂载点
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cgroupCpuPeriod cpu.max 使用的调度周期，微秒
+const cgroupCpuPeriod = 100000
+
+// cgroupControllers 需要委派给子 cgroup 的控制器
+var cgroupControllers = []string{"memory", "cpu", "pids"}
+
+var (
+	cgroupParentOnce sync.Once
+	cgroupParent     string
+	cgroupParentErr  error
+)
+
+// cgroup 一个托管进程独占的 cgroup
+type cgroup struct {
+	path string
+}
+
+// newCgroup 在 Voxesis 所在的 cgroup 下创建名为 name 的子 cgroup 并写入资源限制
+func newCgroup(name string, limits entity.ResourceLimits) (*cgroup, error) {
+	parent, err := cgroupParentDir()
+	if err != nil {
+		return nil, err
+	}
+
+	cg := &cgroup{path: filepath.Join(parent, name)}
+	if err := os.Mkdir(cg.path, 0755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("创建 cgroup 失败: %w", err)
+	}
+
+	memoryMax, cpuMax, pidsMax := "max", fmt.Sprintf("max %d", cgroupCpuPeriod), "max"
+	if limits.MemoryMaxMB > 0 {
+		memoryMax = strconv.FormatInt(limits.MemoryMaxMB*1024*1024, 10)
+	}
+	if limits.CpuPercent > 0 {
+		quota := int64(limits.CpuPercent / 100 * cgroupCpuPeriod)
+		if quota < 1000 {
+			quota = 1000 // 内核要求的最小配额
+		}
+		cpuMax = fmt.Sprintf("%d %d", quota, cgroupCpuPeriod)
+	}
+	if limits.PidsMax > 0 {
+		pidsMax = strconv.FormatInt(limits.PidsMax, 10)
+	}
+
+	for file, value := range map[string]string{"memory.max": memoryMax, "cpu.max": cpuMax, "pids.max": pidsMax} {
+		if err := cg.write(file, value); err != nil {
+			_ = os.Remove(cg.path)
+			return nil, err
+		}
+	}
+	return cg, nil
+}
+
+// spawnInto 设置 attr，使进程通过 clone3 直接在 cgroup 中创建，返回的函数在进程启动后关闭 cgroup 目录
+func (c *cgroup) spawnInto(attr *syscall.SysProcAttr) (func(), error) {
+	dir, err := os.Open(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("打开 cgroup 失败: %w", err)
+	}
+	attr.UseCgroupFD = true
+	attr.CgroupFD = int(dir.Fd())
+	return func() { _ = dir.Close() }, nil
+}
+
+// stats 读取内存用量、CPU 限流与 OOM 计数
+func (c *cgroup) stats() entity.CgroupState {
+	state := entity.CgroupState{Enabled: true}
+
+	if data, err := os.ReadFile(filepath.Join(c.path, "memory.current")); err == nil {
+		if current, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			state.MemoryMB = float64(current) / 1024 / 1024
+		}
+	}
+
+	cpu := readFlatKeyed(filepath.Join(c.path, "cpu.stat"))
+	state.ThrottledCount = cpu["nr_throttled"]
+	state.ThrottledUsec = cpu["throttled_usec"]
+
+	memory := readFlatKeyed(filepath.Join(c.path, "memory.events"))
+	state.OomCount = memory["oom"]
+	state.OomKillCount = memory["oom_kill"]
+	return state
+}
+
+// remove 删除 cgroup，仍有进程残留时会失败
+func (c *cgroup) remove() error {
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除 cgroup %s 失败: %w", c.path, err)
+	}
+	return nil
+}
+
+// write 写入 cgroup 接口文件
+func (c *cgroup) write(file, value string) error {
+	if err := os.WriteFile(filepath.Join(c.path, file), []byte(value), 0644); err != nil {
+		return fmt.Errorf("写入 %s 失败: %w", file, err)
+	}
+	return nil
+}
+
+// cgroupParentDir 返回用于创建子 cgroup 的父目录，只在第一次调用时检测与准备
+func cgroupParentDir() (string, error) {
+	cgroupParentOnce.Do(func() {
+		cgroupParent, cgroupParentErr = prepareCgroupParent()
+	})
+	return cgroupParent, cgroupParentErr
+}
+
+// prepareCgroupParent 检查 Voxesis 所在的 cgroup 是否可用，并为子 cgroup 启用所需的控制器
+func prepareCgroupParent() (string, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return "", fmt.Errorf("未检测到 cgroup v2 统一层级 (%s)", cgroupRoot)
+	}
+
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", fmt.Errorf("读取当前进程的 cgroup 失败: %w", err)
+	}
+	var own string
+	for _, line := range strings.Split(string(data), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			own = filepath.Join(cgroupRoot, path)
+			break
+		}
+	}
+	if own == "" {
+		return "", fmt.Errorf("未能确定当前进程所在的 cgroup")
+	}
+
+	available, err := os.ReadFile(filepath.Join(own, "cgroup.controllers"))
+	if err != nil {
+		return "", fmt.Errorf("读取 %s 的可用控制器失败: %w", own, err)
+	}
+	fields := strings.Fields(string(available))
+	for _, controller := range cgroupControllers {
+		if !slices.Contains(fields, controller) {
+			return "", fmt.Errorf("cgroup %s 未委派 %s 控制器，请以 systemd Delegate=yes 等方式运行 Voxesis", own, controller)
+		}
+	}
+
+	err = enableControllers(own)
+	if errors.Is(err, syscall.EBUSY) {
+		// cgroup v2 不允许有进程的 cgroup 向子 cgroup 委派控制器，将 Voxesis 自身移入一个叶子 cgroup
+		// 同一 cgroup 中的其他进程不属于 Voxesis，不会移动它们
+		if err = moveSelf(filepath.Join(own, "voxesis")); err != nil {
+			return "", err
+		}
+		err = enableControllers(own)
+		if errors.Is(err, syscall.EBUSY) {
+			return "", fmt.Errorf("cgroup %s 中还有其他进程，无法为子 cgroup 启用控制器，请为 Voxesis 单独委派一个 cgroup (如 systemd Delegate=yes)", own)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("无权为 cgroup %s 启用控制器，请确认 cgroup 已委派给当前用户: %w", own, err)
+	}
+	return own, nil
+}
+
+// enableControllers 为 dir 的子 cgroup 启用所需的控制器
+func enableControllers(dir string) error {
+	value := "+" + strings.Join(cgroupControllers, " +")
+	return os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte(value), 0644)
+}
+
+// moveSelf 将 Voxesis 进程移入叶子 cgroup to
+func moveSelf(to string) error {
+	if err := os.Mkdir(to, 0755); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("创建 cgroup %s 失败: %w", to, err)
+	}
+	if err := os.WriteFile(filepath.Join(to, "cgroup.procs"), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return fmt.Errorf("将 Voxesis 移入 cgroup %s 失败: %w", to, err)
+	}
+	return nil
+}
+
+// readFlatKeyed 读取 "key value" 每行一项的 cgroup 统计文件，读取失败时返回空表
+func readFlatKeyed(path string) map[string]uint64 {
+	values := make(map[string]uint64)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return values
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			values[key] = n
+		}
+	}
+	return values
+}
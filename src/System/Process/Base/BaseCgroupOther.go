@@ -0,0 +1,29 @@
+//go:build !linux
+
+package BaseProcess
+
+import (
+	"fmt"
+	"syscall"
+	"voxesis/src/Common/Entity"
+)
+
+// cgroup 在非 Linux 平台上的占位实现，资源限制不会生效
+type cgroup struct{}
+
+// newCgroup 非 Linux 平台不支持 cgroup，总是返回错误
+func newCgroup(name string, limits entity.ResourceLimits) (*cgroup, error) {
+	return nil, fmt.Errorf("cgroup 资源限制仅支持 Linux")
+}
+
+func (c *cgroup) spawnInto(attr *syscall.SysProcAttr) (func(), error) {
+	return nil, fmt.Errorf("cgroup 资源限制仅支持 Linux")
+}
+
+func (c *cgroup) stats() entity.CgroupState {
+	return entity.CgroupState{}
+}
+
+func (c *cgroup) remove() error {
+	return nil
+}
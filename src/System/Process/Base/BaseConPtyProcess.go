@@ -1,3 +1,5 @@
+//go:build windows
+
 package BaseProcess
 
 import (
@@ -5,11 +7,10 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"os/exec"
-	"runtime"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 	"voxesis/src/Common/Entity"
 	vlogger "voxesis/src/Common/Logger"
@@ -27,10 +28,14 @@ type ConPtyProcessManager struct {
 	cpty           *conpty.ConPty // 使用 conpty 对象管理进程和 I/O
 	proc           *process.Process
 	outputCallback func(log string)
-
-	// 用于准确监控 CPU 的字段
-	cpuPercentCache float64
-	stopMonitorChan chan struct{}
+	exitCallback   func(err error)
+	exited         chan struct{} // 进程退出后关闭
+	stopTimeout    time.Duration // 发送 Ctrl+C 后等待退出的时长
+	env            []string      // 为 nil 时继承当前进程的环境变量
+	lifecycle      lifecycleRecorder
+	stopping       bool // Stop 正在终止当前进程树
+
+	monitor *treeMonitor // 进程树资源监控，进程运行时不为 nil
 }
 
 // NewConPtyProcessManager 为给定的可执行文件路径创建一个新的 ConPTY 进程管理器。
@@ -39,7 +44,7 @@ func NewConPtyProcessManager(path string) (*ConPtyProcessManager, error) {
 	if err != nil {
 		return nil, fmt.Errorf("未能找到可执行文件 '%s': %w", path, err)
 	}
-	return &ConPtyProcessManager{binary: binaryPath}, nil
+	return &ConPtyProcessManager{binary: binaryPath, stopTimeout: DefaultStopTimeout}, nil
 }
 
 // SetOutputCallback 设置一个回调函数来处理进程的合并输出。
@@ -49,8 +54,32 @@ func (pm *ConPtyProcessManager) SetOutputCallback(callback func(log string)) {
 	pm.outputCallback = callback
 }
 
+// SetExitCallback 设置进程退出时的回调，退出码非 0 时参数为非 nil 的错误。
+func (pm *ConPtyProcessManager) SetExitCallback(callback func(err error)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.exitCallback = callback
+}
+
+// SetEnv 设置子进程的完整环境变量，格式为 "KEY=VALUE"。
+func (pm *ConPtyProcessManager) SetEnv(env []string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.env = env
+}
+
+// SetStopTimeout 设置 Stop 发送 Ctrl+C 后等待进程退出的时长。
+func (pm *ConPtyProcessManager) SetStopTimeout(timeout time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	pm.stopTimeout = timeout
+}
+
 // Start 使用给定的命令行参数和工作目录来执行进程，并启动后台监控。
-func (pm *ConPtyProcessManager) Start(workingDir string, options ...string) error {
+func (pm *ConPtyProcessManager) Start(workingDir string, options ...string) (err error) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
@@ -58,10 +87,12 @@ func (pm *ConPtyProcessManager) Start(workingDir string, options ...string) erro
 		return fmt.Errorf("此管理器已在运行一个进程")
 	}
 
-	// ConPTY 方案仅在 Windows 上有效
-	if runtime.GOOS != "windows" {
-		return fmt.Errorf("ConPTY 方案仅支持 Windows 平台")
-	}
+	pm.lifecycle.starting()
+	defer func() {
+		if err != nil {
+			pm.lifecycle.failed(err)
+		}
+	}()
 
 	// 构造完整的命令行。conpty.Start 需要一个单一的字符串。
 	// 我们用引号包裹主程序路径，以安全地处理路径中的空格。
@@ -69,39 +100,54 @@ func (pm *ConPtyProcessManager) Start(workingDir string, options ...string) erro
 	cmdParts = append(cmdParts, options...)
 	fullCommand := strings.Join(cmdParts, " ")
 
-	// conpty.Start 没有直接设置工作目录的选项，
-	// 所以我们使用一个标准技巧：先切换目录，启动后再切回来。
-	originalDir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("无法获取当前工作目录: %w", err)
-	}
-	if err := os.Chdir(workingDir); err != nil {
-		return fmt.Errorf("无法切换到工作目录 '%s': %w", workingDir, err)
-	}
-	// 确保在函数返回时切回原始目录
-	defer func(dir string) {
-		_ = os.Chdir(dir)
-	}(originalDir)
-
 	// 【核心实现】使用 conpty.Start 启动进程。
 	// 我们在这里直接设置一个超宽的终端尺寸，从源头上解决所有日志换行问题。
-	cpty, err := conpty.Start(fullCommand, conpty.ConPtyDimensions(8192, 100))
+	ptyOptions := []conpty.ConPtyOption{
+		conpty.ConPtyDimensions(8192, 100),
+		conpty.ConPtyWorkDir(workingDir),
+	}
+	if pm.env != nil {
+		ptyOptions = append(ptyOptions, conpty.ConPtyEnv(pm.env))
+	}
+	cpty, err := conpty.Start(fullCommand, ptyOptions...)
 	if err != nil {
 		return fmt.Errorf("启动 ConPTY 进程失败: %w", err)
 	}
 	pm.cpty = cpty
+	pm.stopping = false
+	pm.lifecycle.running()
+	exited := make(chan struct{})
+	pm.exited = exited
 
 	// 异步等待进程结束，并在结束后自动清理状态
 	go func() {
-		pm.mu.Lock()
-		cpty := pm.cpty
-		pm.mu.Unlock()
+		var exitErr error
+		exitCode, err := cpty.Wait(context.Background()) // 阻塞直到进程退出
+		vlogger.AppLogger.Info("ConPTY 进程 (PID: ", cpty.Pid(), " ) 已退出。")
+		if err != nil {
+			exitErr = err
+		} else if exitCode != 0 {
+			exitErr = &ExitCodeError{Code: int(exitCode)}
+		}
+		pm.lifecycle.exited(exitErr)
+		close(exited)
+
+		pm.mu.RLock()
+		tree := treeSnapshot{}
+		if pm.cpty == cpty && pm.monitor != nil {
+			tree = pm.monitor.snapshot(0)
+		}
+		callback, timeout, stopping := pm.exitCallback, pm.stopTimeout, pm.stopping
+		pm.mu.RUnlock()
+		pm.release(cpty) // 进行状态清理
 
-		if cpty != nil {
-			_, _ = cpty.Wait(context.Background()) // 阻塞直到进程退出
-			vlogger.AppLogger.Info("ConPTY 进程 (PID: ", cpty.Pid(), " ) 已退出。")
+		// 主进程自行退出时同样清理残留的后代进程，完成后再上报退出，由 Stop 触发的退出由 Stop 负责清理
+		if !stopping {
+			reapOrphans(tree, timeout)
+		}
+		if callback != nil {
+			callback(exitErr)
 		}
-		_ = pm.Stop() // 调用 Stop 来进行状态清理
 	}()
 
 	// 创建 gopsutil 进程对象用于监控
@@ -116,57 +162,72 @@ func (pm *ConPtyProcessManager) Start(workingDir string, options ...string) erro
 		go pm.readPipe(pm.cpty, "") // cpty 对象本身就是 io.Reader
 	}
 
-	pm.stopMonitorChan = make(chan struct{})
-	go pm.monitorCPU()
+	pm.monitor = newTreeMonitor(pm.proc.Pid)
 
 	vlogger.AppLogger.Info("进程已通过 ConPTY 启动, PID: ", pm.cpty.Pid(), ".")
 	return nil
 }
 
-// monitorCPU 的逻辑与之前完全相同
-func (pm *ConPtyProcessManager) monitorCPU() {
-	_, _ = pm.proc.CPUPercent()
-
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-pm.stopMonitorChan:
-			vlogger.AppLogger.Info("PID ", pm.proc.Pid, " 的 CPU 监控已停止。")
-			return
-		case <-ticker.C:
-			percent, err := pm.proc.CPUPercent()
-			if err != nil {
-				return
-			}
-			pm.mu.Lock()
-			pm.cpuPercentCache = percent
-			pm.mu.Unlock()
+// Stop 优雅地终止进程，并停止后台监控。
+// 先向控制台发送 Ctrl+C，超过 stopTimeout 仍未退出则关闭 PTY 强制终止并返回 ErrForceKilled。
+// 主进程退出后仍有后代进程存活时强制杀死它们，依然无法清理时返回 ErrTreeSurvived。
+func (pm *ConPtyProcessManager) Stop() error {
+	pm.mu.Lock()
+	cpty, exited, timeout := pm.cpty, pm.exited, pm.stopTimeout
+	var tree treeSnapshot
+	if cpty != nil {
+		tree = snapshotTree(int32(cpty.Pid()), 0)
+		pm.stopping = true
+	}
+	pm.mu.Unlock()
+
+	if cpty == nil {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	pm.lifecycle.stopping()
+	_, _ = cpty.Write([]byte{0x03})
+
+	forced := false
+	select {
+	case <-exited:
+	case <-time.After(timeout):
+		pm.release(cpty) // Close 会终止进程并释放所有资源
+		signalTree(tree, syscall.SIGKILL)
+		forced = true
+	}
+
+	// 主进程退出后确认整个进程树都已退出，避免残留进程继续占用端口
+	err := reapTree(tree, time.Until(deadline))
+	if forced {
+		if err != nil {
+			vlogger.AppLogger.Warnf("%v", err)
 		}
+		return ErrForceKilled
 	}
+	return err
 }
 
-// Stop 优雅地终止进程，并停止后台监控。
-func (pm *ConPtyProcessManager) Stop() error {
+// release 停止监控、关闭 PTY 并清理进程状态
+func (pm *ConPtyProcessManager) release(cpty *conpty.ConPty) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	if pm.stopMonitorChan != nil {
-		close(pm.stopMonitorChan)
-		pm.stopMonitorChan = nil
+	if pm.cpty != cpty {
+		return
 	}
 
-	if pm.cpty == nil {
-		return nil
+	if pm.monitor != nil {
+		pm.monitor.close()
+		pm.monitor = nil
 	}
 
-	err := pm.cpty.Close() // Close 会终止进程并释放所有资源
+	_ = cpty.Close()
 	pm.resetState()
-	return err
 }
 
-// GetProcessStatus 的逻辑与之前完全相同
+// GetProcessStatus 返回进程树的当前资源使用情况，从最近一次采样读取。
 func (pm *ConPtyProcessManager) GetProcessStatus() (entity.ProcessState, error) {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
@@ -180,24 +241,8 @@ func (pm *ConPtyProcessManager) GetProcessStatus() (entity.ProcessState, error)
 		return state, nil
 	}
 	state.Pid = fmt.Sprintf("%d", pm.proc.Pid)
-	cpuCores := runtime.NumCPU()
-	var taskManagerCpuPercent float64
-	if cpuCores > 0 {
-		percentOfTotal := pm.cpuPercentCache / float64(cpuCores)
-		taskManagerCpuPercent = percentOfTotal * 2
-	} else {
-		taskManagerCpuPercent = pm.cpuPercentCache
-	}
-	if taskManagerCpuPercent > 100.0 {
-		taskManagerCpuPercent = 100.0
-	}
-	state.Cpu = taskManagerCpuPercent
-	if memInfo, err := pm.proc.MemoryInfo(); err == nil {
-		memoryMB := float64(memInfo.RSS) / 1024 / 1024
-		state.Memory = memoryMB
-	} else {
-		state.Memory = 0
-	}
+	pm.monitor.fill(&state)
+
 	if createTimeMs, err := pm.proc.CreateTime(); err == nil {
 		createTime := time.Unix(0, createTimeMs*int64(time.Millisecond))
 		uptime := time.Since(createTime).Round(time.Second)
@@ -224,8 +269,13 @@ func (pm *ConPtyProcessManager) readPipe(ptyReader io.Reader, prefix string) {
 // resetState 清理进程状态
 func (pm *ConPtyProcessManager) resetState() {
 	pm.cpty = nil
+	pm.exited = nil
 	pm.proc = nil
-	pm.cpuPercentCache = 0
+}
+
+// GetLifecycle 返回进程的生命周期记录，包括退出码与启动、退出时间。
+func (pm *ConPtyProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	return pm.lifecycle.snapshot()
 }
 
 // IsRunning 检查被管理的进程当前是否正在运行
@@ -0,0 +1,62 @@
+//go:build !windows
+
+package BaseProcess
+
+import (
+	"fmt"
+	"time"
+	"voxesis/src/Common/Entity"
+)
+
+// ConPtyProcessManager 在非 Windows 平台上的占位实现。
+// ConPTY 是 Windows 10+ 独有的 API，类 Unix 平台请使用 UnixPtyProcessManager。
+type ConPtyProcessManager struct {
+	binary string
+}
+
+// NewConPtyProcessManager 为给定的可执行文件路径创建一个新的 ConPTY 进程管理器。
+func NewConPtyProcessManager(path string) (*ConPtyProcessManager, error) {
+	return &ConPtyProcessManager{binary: path}, nil
+}
+
+// SetOutputCallback 在非 Windows 平台上不做任何事。
+func (pm *ConPtyProcessManager) SetOutputCallback(callback func(log string)) {}
+
+// SetExitCallback 在非 Windows 平台上不做任何事。
+func (pm *ConPtyProcessManager) SetExitCallback(callback func(err error)) {}
+
+// SetEnv 在非 Windows 平台上不做任何事。
+func (pm *ConPtyProcessManager) SetEnv(env []string) {}
+
+// SetStopTimeout 在非 Windows 平台上不做任何事。
+func (pm *ConPtyProcessManager) SetStopTimeout(timeout time.Duration) {}
+
+// Start 在非 Windows 平台上总是返回错误。
+func (pm *ConPtyProcessManager) Start(workingDir string, options ...string) error {
+	return fmt.Errorf("ConPTY 方案仅支持 Windows 平台")
+}
+
+// Stop 在非 Windows 平台上不做任何事。
+func (pm *ConPtyProcessManager) Stop() error {
+	return nil
+}
+
+// GetProcessStatus 在非 Windows 平台上总是返回空状态。
+func (pm *ConPtyProcessManager) GetProcessStatus() (entity.ProcessState, error) {
+	return entity.ProcessState{}, nil
+}
+
+// GetLifecycle 在非 Windows 平台上总是返回从未启动的状态。
+func (pm *ConPtyProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+}
+
+// IsRunning 在非 Windows 平台上总是返回 false。
+func (pm *ConPtyProcessManager) IsRunning() bool {
+	return false
+}
+
+// SendCommand 在非 Windows 平台上总是返回错误。
+func (pm *ConPtyProcessManager) SendCommand(command string) error {
+	return fmt.Errorf("ConPTY 方案仅支持 Windows 平台")
+}
@@ -0,0 +1,37 @@
+//go:build !windows
+
+package BaseProcess
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// createStdinFifo 创建命名管道作为子进程的标准输入，返回以读写方式打开的管道。
+// 子进程继承的是读写描述符，管道始终有写端，Voxesis 退出后子进程也不会读到 EOF。
+func createStdinFifo(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("创建命名管道目录失败: %w", err)
+	}
+	_ = os.Remove(path)
+	if err := syscall.Mkfifo(path, 0600); err != nil {
+		return nil, fmt.Errorf("创建命名管道 %s 失败: %w", path, err)
+	}
+	fifo, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, fmt.Errorf("打开命名管道 %s 失败: %w", path, err)
+	}
+	return fifo, nil
+}
+
+// openStdinFifo 以只写方式打开已存在的命名管道，没有进程在读取时立即失败
+func openStdinFifo(path string) (*os.File, error) {
+	fifo, err := os.OpenFile(path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("打开命名管道 %s 失败: %w", path, err)
+	}
+	return fifo, nil
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package BaseProcess
+
+import (
+	"fmt"
+	"os"
+)
+
+// createStdinFifo Windows 下不支持命名管道标准输入
+func createStdinFifo(path string) (*os.File, error) {
+	return nil, fmt.Errorf("Windows 下不支持命名管道标准输入")
+}
+
+// openStdinFifo Windows 下不支持命名管道标准输入
+func openStdinFifo(path string) (*os.File, error) {
+	return nil, fmt.Errorf("Windows 下不支持命名管道标准输入")
+}
@@ -0,0 +1,145 @@
+package BaseProcess
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+	"voxesis/src/Common/Entity"
+)
+
+// ExitCodeError 表示进程以非 0 退出码退出，用于无法得到 exec.ExitError 的场景。
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("进程退出码: %d", e.Code)
+}
+
+// ExitStatus 从进程的退出错误中解析退出码与终止信号，err 为 nil 表示退出码为 0。
+// 被信号终止或无法解析时退出码为 -1。
+func ExitStatus(err error) (int, string) {
+	if err == nil {
+		return 0, ""
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return -1, ws.Signal().String()
+		}
+		return exitErr.ExitCode(), ""
+	}
+
+	var codeErr *ExitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.Code, ""
+	}
+	return -1, ""
+}
+
+// lifecycleRecorder 记录一个进程的生命周期，使用独立的锁，可在持有管理器锁时调用。
+type lifecycleRecorder struct {
+	mu   sync.Mutex
+	info entity.ProcessLifecycle
+}
+
+// starting 进入启动阶段，清除上一次的退出信息
+func (l *lifecycleRecorder) starting() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.info.State = entity.LifecycleStarting
+	l.info.ExitCode = nil
+	l.info.Signal = ""
+	l.info.StartedAt = nil
+	l.info.StoppedAt = nil
+}
+
+// running 进程已成功启动
+func (l *lifecycleRecorder) running() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.info.State = entity.LifecycleRunning
+	l.info.StartedAt = &now
+}
+
+// attached 接管一个已在运行的进程，启动时间为进程的创建时间
+func (l *lifecycleRecorder) attached(startedAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.info.State = entity.LifecycleRunning
+	l.info.ExitCode = nil
+	l.info.Signal = ""
+	l.info.StartedAt = &startedAt
+	l.info.StoppedAt = nil
+}
+
+// failed 进程启动失败
+func (l *lifecycleRecorder) failed(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.info.State = entity.LifecycleCrashed
+	l.info.StoppedAt = &now
+	l.info.LastError = err.Error()
+}
+
+// stopping 进程收到停止请求，之后的退出视为正常停止
+func (l *lifecycleRecorder) stopping() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.info.State == entity.LifecycleStarting || l.info.State == entity.LifecycleRunning {
+		l.info.State = entity.LifecycleStopping
+	}
+}
+
+// exited 记录进程的退出结果
+func (l *lifecycleRecorder) exited(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.info.StoppedAt = &now
+	code, signal := ExitStatus(err)
+	l.info.ExitCode = nil
+	if code >= 0 {
+		l.info.ExitCode = &code
+	}
+	l.info.Signal = signal
+
+	if err == nil || l.info.State == entity.LifecycleStopping {
+		l.info.State = entity.LifecycleStopped
+	} else {
+		l.info.State = entity.LifecycleCrashed
+	}
+	if err != nil {
+		l.info.LastError = err.Error()
+	}
+}
+
+// exitedUnknown 记录无法获取退出码的进程退出，视为正常停止
+func (l *lifecycleRecorder) exitedUnknown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.info.StoppedAt = &now
+	l.info.ExitCode = nil
+	l.info.Signal = ""
+	l.info.State = entity.LifecycleStopped
+}
+
+// snapshot 返回生命周期记录的副本
+func (l *lifecycleRecorder) snapshot() entity.ProcessLifecycle {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	info := l.info
+	if info.State == "" {
+		info.State = entity.LifecycleCreated
+	}
+	return info
+}
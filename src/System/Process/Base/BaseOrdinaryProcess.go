@@ -6,8 +6,8 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"runtime"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 	"voxesis/src/Common/Entity"
@@ -25,19 +25,32 @@ type ProcessManager struct {
 	stdin          io.WriteCloser
 	proc           *process.Process
 	outputCallback func(log string)
+	exitCallback   func(err error)
+	exited         chan struct{} // 进程退出后关闭
+	stopTimeout    time.Duration // 发送 SIGTERM 后等待退出的时长
+	env            []string      // 为 nil 时继承当前进程的环境变量
+	lifecycle      lifecycleRecorder
 
-	// 新增字段，用于准确监控 CPU
-	cpuPercentCache float64       // 用于缓存最新的 CPU 使用率
-	stopMonitorChan chan struct{} // 用于通知监控 goroutine 停止
+	stdinFifo string   // 不为空时使用该路径的命名管道作为标准输入，Voxesis 重启后仍可发送命令
+	fifo      *os.File // 当前进程使用的命名管道
+
+	limits        *entity.ResourceLimits // 为 nil 时不创建 cgroup
+	cgroup        *cgroup                // 当前进程所在的 cgroup，未启用资源限制时为 nil
+	cgroupWarning string                 // 未能启用资源限制的原因
+
+	monitor *treeMonitor // 进程树资源监控，进程运行时不为 nil
 }
 
+// cgroupSeq 用于生成唯一的 cgroup 名称
+var cgroupSeq atomic.Uint64
+
 // NewProcessManager 为给定的可执行文件路径创建一个新的进程管理器。
 func NewProcessManager(path string) (*ProcessManager, error) {
 	binaryPath, err := exec.LookPath(path)
 	if err != nil {
 		return nil, fmt.Errorf("未能找到可执行文件 '%s': %w", path, err)
 	}
-	return &ProcessManager{binary: binaryPath}, nil
+	return &ProcessManager{binary: binaryPath, stopTimeout: DefaultStopTimeout}, nil
 }
 
 // SetOutputCallback 设置一个回调函数来处理进程的标准输出和标准错误。
@@ -47,8 +60,46 @@ func (pm *ProcessManager) SetOutputCallback(callback func(log string)) {
 	pm.outputCallback = callback
 }
 
+// SetExitCallback 设置进程退出时的回调，参数为 cmd.Wait() 的返回值。
+func (pm *ProcessManager) SetExitCallback(callback func(err error)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.exitCallback = callback
+}
+
+// SetEnv 设置子进程的完整环境变量，格式为 "KEY=VALUE"。
+func (pm *ProcessManager) SetEnv(env []string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.env = env
+}
+
+// SetStdinFifo 设置下次启动时作为标准输入的命名管道路径，为空时使用普通管道，Windows 下不支持。
+func (pm *ProcessManager) SetStdinFifo(path string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.stdinFifo = path
+}
+
+// SetResourceLimits 设置下次启动时的资源限制，仅 Linux 且可用 cgroup v2 时生效，为 nil 表示不限制。
+func (pm *ProcessManager) SetResourceLimits(limits *entity.ResourceLimits) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.limits = limits
+}
+
+// SetStopTimeout 设置 Stop 发送 SIGTERM 后等待进程退出的时长。
+func (pm *ProcessManager) SetStopTimeout(timeout time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	pm.stopTimeout = timeout
+}
+
 // Start 使用给定的命令行参数和工作目录来执行进程，并启动后台监控。
-func (pm *ProcessManager) Start(workingDir string, options ...string) error {
+func (pm *ProcessManager) Start(workingDir string, options ...string) (err error) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
@@ -58,10 +109,76 @@ func (pm *ProcessManager) Start(workingDir string, options ...string) error {
 		}
 	}
 
+	pm.lifecycle.starting()
+	defer func() {
+		if err != nil {
+			pm.lifecycle.failed(err)
+		}
+	}()
+
+	err = pm.launch(workingDir, options, true)
+	if err != nil && pm.cgroup != nil {
+		// 内核不支持在 cgroup 中直接创建进程时 (Linux 5.7 以前) 不带资源限制重新启动
+		pm.releaseCgroup()
+		pm.cgroupWarning = fmt.Sprintf("在 cgroup 中启动进程失败 (需要 Linux 5.7 及以上): %v", err)
+		vlogger.AppLogger.Warnf("%s，进程将不受限制地运行", pm.cgroupWarning)
+		err = pm.launch(workingDir, options, false)
+	}
+	if err != nil {
+		return err
+	}
+	pm.lifecycle.running()
+
+	// 唯一的 Wait 调用点，负责回收进程并上报退出结果
+	cmd, exited := pm.cmd, make(chan struct{})
+	pm.exited = exited
+	go func() {
+		waitErr := cmd.Wait()
+		pm.lifecycle.exited(waitErr)
+		close(exited)
+
+		pm.mu.Lock()
+		tree := treeSnapshot{pgid: processGroup(cmd.Process.Pid)}
+		if pm.cmd == cmd {
+			if pm.monitor != nil {
+				tree = pm.monitor.snapshot(tree.pgid)
+			}
+			pm.stopMonitor()
+			pm.resetState()
+		}
+		callback, timeout := pm.exitCallback, pm.stopTimeout
+		pm.mu.Unlock()
+
+		// 主进程自行退出 (例如响应了控制台停止命令) 时同样清理残留的后代进程，完成后再上报退出
+		reapOrphans(tree, timeout)
+
+		if callback != nil {
+			callback(waitErr)
+		}
+	}()
+
+	pm.proc, err = process.NewProcess(int32(pm.cmd.Process.Pid))
+	if err != nil {
+		_ = pm.cmd.Process.Kill()
+		return fmt.Errorf("进程已启动但创建监控器失败: %w", err)
+	}
+
+	pm.monitor = newTreeMonitor(pm.proc.Pid)
+
+	vlogger.AppLogger.Infof("进程已启动, PID: %d，并已启动后台监控。", pm.cmd.Process.Pid)
+	return nil
+}
+
+// launch 创建并启动进程，连接输出与标准输入，limited 为 true 时在独占的 cgroup 中创建进程。调用方需持有写锁
+// 启动失败后 exec.Cmd 不能再次启动，重试时需重新调用
+func (pm *ProcessManager) launch(workingDir string, options []string, limited bool) (err error) {
 	pm.cmd = exec.Command(pm.binary, options...)
 	pm.cmd.Dir = workingDir
-	pm.cmd.Env = os.Environ()
-	pm.cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	pm.cmd.Env = pm.env
+	if pm.cmd.Env == nil {
+		pm.cmd.Env = os.Environ()
+	}
+	pm.cmd.SysProcAttr = newSysProcAttr()
 
 	if pm.outputCallback != nil {
 		stdoutPipe, err := pm.cmd.StdoutPipe()
@@ -76,75 +193,82 @@ func (pm *ProcessManager) Start(workingDir string, options ...string) error {
 		go pm.readPipe(stderrPipe, "[STDERR]")
 	}
 
-	var err error
-	pm.stdin, err = pm.cmd.StdinPipe()
-	if err != nil {
-		return fmt.Errorf("创建标准输入管道失败: %w", err)
+	pm.stdin = nil
+	if pm.stdinFifo != "" {
+		if pm.fifo, err = createStdinFifo(pm.stdinFifo); err != nil {
+			vlogger.AppLogger.Warnf("%v，将使用普通管道作为标准输入", err)
+		} else {
+			pm.cmd.Stdin = pm.fifo
+			pm.stdin = pm.fifo
+		}
 	}
-
-	if err := pm.cmd.Start(); err != nil {
-		return fmt.Errorf("启动进程失败: %w", err)
+	if pm.stdin == nil {
+		pm.stdin, err = pm.cmd.StdinPipe()
+		if err != nil {
+			return fmt.Errorf("创建标准输入管道失败: %w", err)
+		}
 	}
 
-	go func() { _ = pm.cmd.Wait() }()
-
-	pm.proc, err = process.NewProcess(int32(pm.cmd.Process.Pid))
+	closeCgroup := func() {}
+	if limited {
+		closeCgroup = pm.prepareCgroup()
+	}
+	err = pm.cmd.Start()
+	closeCgroup()
 	if err != nil {
-		_ = pm.cmd.Process.Kill()
-		return fmt.Errorf("进程已启动但创建监控器失败: %w", err)
+		pm.closeFifo()
+		pm.stdin = nil
+		return fmt.Errorf("启动进程失败: %w", err)
 	}
-
-	pm.stopMonitorChan = make(chan struct{})
-	go pm.monitorCPU()
-
-	vlogger.AppLogger.Info("进程已启动, PID: %d，并已启动后台监控。", pm.cmd.Process.Pid)
 	return nil
 }
 
-// monitorCPU 是一个后台 goroutine，定期、准确地更新 CPU 使用率。
-func (pm *ProcessManager) monitorCPU() {
-	// 第一次调用用于设置基准时间点，返回的值是0或无意义的值，应该被忽略。
-	// 这是 gopsutil 的标准用法。
-	_, _ = pm.proc.CPUPercent()
-
-	ticker := time.NewTicker(2 * time.Second) // 每 2 秒更新一次
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-pm.stopMonitorChan:
-			vlogger.AppLogger.Info("PID %d 的 CPU 监控已停止。", pm.proc.Pid)
-			return
-		case <-ticker.C:
-			// 【核心修正】
-			// CPUPercent 不需要参数。它会自动计算自上次调用以来的使用率。
-			// 因为我们的 ticker 是 2 秒一次，所以这里计算的就是这 2 秒内的平均值。
-			percent, err := pm.proc.CPUPercent()
-			if err != nil {
-				vlogger.AppLogger.Error("监控 PID %d 时出错: %v，监控将停止。", pm.proc.Pid, err)
-				return
-			}
+// prepareCgroup 为即将启动的进程创建独占的 cgroup 并写入资源限制，进程直接在 cgroup 中创建，
+// 启动后立即创建的子进程同样无法逃出限制。返回的函数需在启动后调用。调用方需持有写锁
+// 失败时进程照常运行，只记录警告；内核不支持在 cgroup 中创建进程时由 Start 不带限制重新启动
+func (pm *ProcessManager) prepareCgroup() (done func()) {
+	pm.cgroupWarning = ""
+	if pm.limits == nil {
+		return func() {}
+	}
 
-			pm.mu.Lock()
-			pm.cpuPercentCache = percent
-			pm.mu.Unlock()
+	cg, err := newCgroup(fmt.Sprintf("voxesis-%d-%d", os.Getpid(), cgroupSeq.Add(1)), *pm.limits)
+	if err == nil {
+		if done, err = cg.spawnInto(pm.cmd.SysProcAttr); err != nil {
+			_ = cg.remove()
 		}
 	}
+	if err != nil {
+		pm.cgroupWarning = err.Error()
+		vlogger.AppLogger.Warnf("资源限制未生效，进程将不受限制地运行: %v", err)
+		return func() {}
+	}
+	pm.cgroup = cg
+	return done
+}
+
+// releaseCgroup 删除进程退出后的 cgroup，调用方需持有写锁
+func (pm *ProcessManager) releaseCgroup() {
+	if pm.cgroup == nil {
+		return
+	}
+	if stats := pm.cgroup.stats(); stats.OomKillCount > 0 {
+		vlogger.AppLogger.Warnf("进程的 cgroup 中有 %d 个进程因超出内存限制被 OOM killer 杀死", stats.OomKillCount)
+	}
+	if err := pm.cgroup.remove(); err != nil {
+		vlogger.AppLogger.Warnf("%v", err)
+	}
+	pm.cgroup = nil
 }
 
-// Stop 优雅地终止进程，并停止后台监控。
+// Stop 优雅地终止整个进程树，并停止后台监控。
+// 先向进程组发送 SIGTERM，超过 stopTimeout 仍未退出则强制杀死并返回 ErrForceKilled。
+// 主进程退出后仍有后代进程存活时强制杀死它们，依然无法清理时返回 ErrTreeSurvived。
 func (pm *ProcessManager) Stop() error {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	if pm.stopMonitorChan != nil {
-		select {
-		case pm.stopMonitorChan <- struct{}{}:
-		default:
-		}
-		close(pm.stopMonitorChan)
-		pm.stopMonitorChan = nil
-	}
+	pm.stopMonitor()
 
 	if pm.proc == nil {
 		return nil
@@ -154,29 +278,43 @@ func (pm *ProcessManager) Stop() error {
 		return nil
 	}
 
-	p, err := os.FindProcess(int(pm.proc.Pid))
-	if err != nil {
-		pm.resetState()
-		return nil
-	}
+	tree := snapshotTree(pm.proc.Pid, processGroup(pm.cmd.Process.Pid))
+	deadline := time.Now().Add(pm.stopTimeout)
 
-	_ = p.Signal(syscall.SIGTERM)
-
-	done := make(chan error, 1)
-	go func() { done <- pm.cmd.Wait() }()
+	pm.lifecycle.stopping()
+	signalTree(tree, syscall.SIGTERM)
 
+	forced := false
 	select {
-	case <-time.After(5 * time.Second):
+	case <-time.After(pm.stopTimeout):
+		signalTree(tree, syscall.SIGKILL)
 		_ = pm.cmd.Process.Kill()
-		pm.resetState()
-		return fmt.Errorf("进程被强制杀死 (超时)")
-	case <-done:
-		pm.resetState()
-		return nil
+		<-pm.exited
+		forced = true
+	case <-pm.exited:
+	}
+
+	// 主进程退出后确认整个进程树都已退出，避免残留进程继续占用端口
+	err := reapTree(tree, time.Until(deadline))
+	pm.resetState()
+	if forced {
+		if err != nil {
+			vlogger.AppLogger.Warnf("%v", err)
+		}
+		return ErrForceKilled
+	}
+	return err
+}
+
+// stopMonitor 停止进程树资源监控，调用方需持有写锁
+func (pm *ProcessManager) stopMonitor() {
+	if pm.monitor != nil {
+		pm.monitor.close()
+		pm.monitor = nil
 	}
 }
 
-// GetProcessStatus 返回进程的当前资源使用情况，CPU部分从缓存读取。
+// GetProcessStatus 返回进程树的当前资源使用情况，从最近一次采样读取。
 func (pm *ProcessManager) GetProcessStatus() (entity.ProcessState, error) {
 	pm.mu.RLock()
 	defer pm.mu.RUnlock()
@@ -194,25 +332,13 @@ func (pm *ProcessManager) GetProcessStatus() (entity.ProcessState, error) {
 
 	state.Pid = fmt.Sprintf("%d", pm.proc.Pid)
 
-	cpuCores := runtime.NumCPU()
-	var taskManagerCpuPercent float64
-
-	if cpuCores > 0 {
-		percentOfTotal := pm.cpuPercentCache / float64(cpuCores)
-		taskManagerCpuPercent = percentOfTotal * 2
-	} else {
-		taskManagerCpuPercent = pm.cpuPercentCache
-	}
-	if taskManagerCpuPercent > 100.0 {
-		taskManagerCpuPercent = 100.0
-	}
-	state.Cpu = taskManagerCpuPercent
+	pm.monitor.fill(&state)
 
-	if memInfo, err := pm.proc.MemoryInfo(); err == nil {
-		memoryMB := float64(memInfo.RSS) / 1024 / 1024
-		state.Memory = memoryMB
-	} else {
-		state.Memory = 0
+	if pm.cgroup != nil {
+		stats := pm.cgroup.stats()
+		state.Cgroup = &stats
+	} else if pm.limits != nil {
+		state.Cgroup = &entity.CgroupState{Warning: pm.cgroupWarning}
 	}
 
 	if createTimeMs, err := pm.proc.CreateTime(); err == nil {
@@ -242,7 +368,24 @@ func (pm *ProcessManager) resetState() {
 	pm.cmd = nil
 	pm.proc = nil
 	pm.stdin = nil
-	pm.cpuPercentCache = 0
+	pm.exited = nil
+	pm.closeFifo()
+	pm.releaseCgroup()
+}
+
+// closeFifo 关闭并删除标准输入命名管道，调用方需持有写锁
+func (pm *ProcessManager) closeFifo() {
+	if pm.fifo == nil {
+		return
+	}
+	_ = pm.fifo.Close()
+	_ = os.Remove(pm.fifo.Name())
+	pm.fifo = nil
+}
+
+// GetLifecycle 返回进程的生命周期记录，包括退出码与启动、退出时间。
+func (pm *ProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	return pm.lifecycle.snapshot()
 }
 
 func (pm *ProcessManager) IsRunning() bool {
@@ -0,0 +1,12 @@
+package BaseProcess
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultStopTimeout 发送终止信号后等待进程退出的默认时长，超时后强制杀死。
+const DefaultStopTimeout = 5 * time.Second
+
+// ErrForceKilled 表示进程未能在超时时间内退出，已被强制杀死。
+var ErrForceKilled = errors.New("进程被强制杀死 (超时)")
@@ -0,0 +1,102 @@
+package BaseProcess
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"syscall"
+	"time"
+	vlogger "voxesis/src/Common/Logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// treeKillTimeout 强制杀死残留进程后等待其消失的时长
+const treeKillTimeout = 2 * time.Second
+
+// ErrTreeSurvived 表示停止后进程树中仍有进程存活
+var ErrTreeSurvived = errors.New("进程树中仍有进程存活")
+
+// treeSnapshot 停止前记录的进程树。
+// 主进程退出后其后代会被 init 收养，无法再从主进程遍历，只能据此找到残留进程。
+type treeSnapshot struct {
+	pgid  int             // 进程组ID，未使用独立进程组时为 0
+	procs map[int32]int64 // PID 到创建时间，用于识别 PID 复用
+}
+
+// snapshotTree 记录 root 及其所有后代进程
+func snapshotTree(root int32, pgid int) treeSnapshot {
+	tree := treeSnapshot{pgid: pgid, procs: make(map[int32]int64)}
+	for _, pid := range append([]int32{root}, descendants(root)...) {
+		if proc, err := process.NewProcess(pid); err == nil {
+			if created, err := proc.CreateTime(); err == nil {
+				tree.procs[pid] = created
+			}
+		}
+	}
+	return tree
+}
+
+// survivors 返回快照中仍然存活的进程，不包括已变为僵尸的进程
+func (t treeSnapshot) survivors() []int32 {
+	var alive []int32
+	for pid, created := range t.procs {
+		proc, err := process.NewProcess(pid)
+		if err != nil {
+			continue
+		}
+		if current, err := proc.CreateTime(); err != nil || current != created {
+			continue
+		}
+		if status, err := proc.Status(); err == nil && slices.Contains(status, process.Zombie) {
+			continue
+		}
+		alive = append(alive, pid)
+	}
+	return alive
+}
+
+// alive 判断进程树或进程组中是否仍有进程存活
+func (t treeSnapshot) alive() bool {
+	return len(t.survivors()) > 0 || groupAlive(t.pgid)
+}
+
+// wait 等待进程树全部退出，返回是否在超时前完成
+func (t treeSnapshot) wait(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for t.alive() {
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return true
+}
+
+// reapTree 在主进程退出后清理残留的后代进程。
+// 先等待它们在 grace 内自行退出，随后强制杀死整个进程组与剩余进程，仍有进程存活时返回 ErrTreeSurvived。
+func reapTree(tree treeSnapshot, grace time.Duration) error {
+	exited := tree.wait(grace)
+	// 快照之后才创建的进程只能通过进程组找到，无论是否超时都清理一次
+	signalTree(tree, syscall.SIGKILL)
+	if exited || tree.wait(treeKillTimeout) {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrTreeSurvived, tree.survivors())
+}
+
+// reapOrphans 主进程自行退出后，终止进程树中的残留进程
+func reapOrphans(tree treeSnapshot, grace time.Duration) {
+	if !tree.alive() {
+		return
+	}
+	if survivors := tree.survivors(); len(survivors) > 0 {
+		vlogger.AppLogger.Warnf("主进程已退出，但仍有残留的后代进程 %v，正在终止", survivors)
+	} else {
+		vlogger.AppLogger.Warnf("主进程已退出，但进程组 %d 中仍有残留进程，正在终止", tree.pgid)
+	}
+	signalTree(tree, syscall.SIGTERM)
+	if err := reapTree(tree, grace); err != nil {
+		vlogger.AppLogger.Warnf("%v", err)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !windows
+
+package BaseProcess
+
+import (
+	"errors"
+	"syscall"
+)
+
+// signalTree 向整个进程组以及快照中仍存活的进程发送信号。
+// 单独向快照中的进程发送是为了覆盖自行调用 setsid/setpgid 离开进程组的后代进程。
+func signalTree(tree treeSnapshot, sig syscall.Signal) {
+	if tree.pgid > 0 {
+		_ = syscall.Kill(-tree.pgid, sig)
+	}
+	for _, pid := range tree.survivors() {
+		_ = syscall.Kill(int(pid), sig)
+	}
+}
+
+// groupAlive 判断进程组中是否仍有进程
+func groupAlive(pgid int) bool {
+	if pgid <= 0 {
+		return false
+	}
+	return !errors.Is(syscall.Kill(-pgid, 0), syscall.ESRCH)
+}
+
+// leaderGroup 进程是进程组组长时返回其进程组ID，否则返回 0。
+// 不是组长的进程可能与 shell 等无关进程同组，不能向整个进程组发送信号。
+func leaderGroup(pid int) int {
+	pgid, err := syscall.Getpgid(pid)
+	if err != nil || pgid != pid {
+		return 0
+	}
+	return pgid
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package BaseProcess
+
+import (
+	"os"
+	"syscall"
+)
+
+// signalTree 终止快照中仍存活的进程。
+// Windows 没有进程组信号，也无法向其他控制台进程发送 SIGTERM，因此只处理 SIGKILL。
+func signalTree(tree treeSnapshot, sig syscall.Signal) {
+	if sig != syscall.SIGKILL {
+		return
+	}
+	for _, pid := range tree.survivors() {
+		if proc, err := os.FindProcess(int(pid)); err == nil {
+			_ = proc.Kill()
+		}
+	}
+}
+
+// groupAlive Windows 下不使用进程组
+func groupAlive(pgid int) bool {
+	return false
+}
+
+// leaderGroup Windows 下不使用进程组，总是返回 0
+func leaderGroup(pid int) int {
+	return 0
+}
@@ -0,0 +1,188 @@
+package BaseProcess
+
+import (
+	"runtime"
+	"sync"
+	"time"
+	"voxesis/src/Common/Entity"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// treeSampleInterval 进程树资源采样的周期
+const treeSampleInterval = 2 * time.Second
+
+// treeUsage 进程及其所有后代进程的资源使用汇总
+type treeUsage struct {
+	cpuPerCore float64 // 100 表示占满一个 CPU 核心
+	cpu        float64 // 占整机 CPU 的百分比
+	memoryMB   float64
+	threads    int32
+	openFiles  int32
+	readBytes  uint64
+	writeBytes uint64
+	processes  int
+}
+
+// cpuTime 一个进程的累计 CPU 时间，创建时间用于识别 PID 复用
+type cpuTime struct {
+	createTime int64
+	seconds    float64
+}
+
+// treeMonitor 在后台定期采样一个进程及其所有后代进程的资源使用。
+// 通过 shell 脚本或包装程序启动时，真正的服务器进程是其后代，同样会被统计。
+type treeMonitor struct {
+	root int32
+	stop chan struct{}
+
+	// 以下字段只由采样 goroutine 读写
+	cpuTimes   map[int32]cpuTime // 上次采样时各进程的累计 CPU 时间
+	lastSample time.Time
+
+	mu    sync.Mutex
+	usage treeUsage
+	procs map[int32]int64 // 最近一次采样时进程树中的 PID 与创建时间
+}
+
+// newTreeMonitor 立即采样一次作为基准，并启动后台采样
+func newTreeMonitor(root int32) *treeMonitor {
+	m := &treeMonitor{
+		root:     root,
+		stop:     make(chan struct{}),
+		cpuTimes: make(map[int32]cpuTime),
+	}
+	m.sample()
+	go m.run()
+	return m
+}
+
+// close 停止后台采样
+func (m *treeMonitor) close() {
+	close(m.stop)
+}
+
+// snapshot 返回最近一次采样时的进程树，用于主进程退出后查找残留的后代进程
+func (m *treeMonitor) snapshot(pgid int) treeSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tree := treeSnapshot{pgid: pgid, procs: make(map[int32]int64, len(m.procs))}
+	for pid, created := range m.procs {
+		tree.procs[pid] = created
+	}
+	return tree
+}
+
+// fill 将最近一次采样的汇总写入进程状态
+func (m *treeMonitor) fill(state *entity.ProcessState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state.Cpu = m.usage.cpu
+	state.CpuPerCore = m.usage.cpuPerCore
+	state.Memory = m.usage.memoryMB
+	state.Threads = m.usage.threads
+	state.OpenFiles = m.usage.openFiles
+	state.ReadBytes = m.usage.readBytes
+	state.WriteBytes = m.usage.writeBytes
+	state.Processes = m.usage.processes
+}
+
+func (m *treeMonitor) run() {
+	ticker := time.NewTicker(treeSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.sample()
+		}
+	}
+}
+
+// sample 遍历进程树并汇总资源使用，读取失败的进程或项会被跳过
+func (m *treeMonitor) sample() {
+	now := time.Now()
+	pids := append([]int32{m.root}, descendants(m.root)...)
+
+	var (
+		usage      treeUsage
+		cpuSeconds float64
+		times      = make(map[int32]cpuTime, len(pids))
+		procs      = make(map[int32]int64, len(pids))
+	)
+	for _, pid := range pids {
+		proc, err := process.NewProcess(pid)
+		if err != nil {
+			continue
+		}
+		usage.processes++
+
+		created, err := proc.CreateTime()
+		if err == nil {
+			procs[pid] = created
+		}
+		if t, err := proc.Times(); err == nil {
+			current := cpuTime{createTime: created, seconds: t.User + t.System}
+			times[pid] = current
+
+			if last, ok := m.cpuTimes[pid]; ok && last.createTime == created {
+				cpuSeconds += current.seconds - last.seconds
+			} else if !m.lastSample.IsZero() && created >= m.lastSample.UnixMilli() {
+				// 上次采样后才创建的进程，全部 CPU 时间都发生在本周期内
+				cpuSeconds += current.seconds
+			}
+		}
+		if memInfo, err := proc.MemoryInfo(); err == nil {
+			usage.memoryMB += float64(memInfo.RSS) / 1024 / 1024
+		}
+		if threads, err := proc.NumThreads(); err == nil {
+			usage.threads += threads
+		}
+		if fds, err := proc.NumFDs(); err == nil {
+			usage.openFiles += fds
+		}
+		if io, err := proc.IOCounters(); err == nil {
+			usage.readBytes += io.ReadBytes
+			usage.writeBytes += io.WriteBytes
+		}
+	}
+
+	if !m.lastSample.IsZero() {
+		if wall := now.Sub(m.lastSample).Seconds(); wall > 0 && cpuSeconds > 0 {
+			usage.cpuPerCore = cpuSeconds / wall * 100
+			usage.cpu = usage.cpuPerCore / float64(runtime.NumCPU())
+			if usage.cpu > 100 {
+				usage.cpu = 100
+			}
+		}
+	}
+	m.cpuTimes = times
+	m.lastSample = now
+
+	m.mu.Lock()
+	m.usage = usage
+	m.procs = procs
+	m.mu.Unlock()
+}
+
+// walkTree 按父子关系表广度优先列出 root 的所有后代进程
+func walkTree(root int32, children map[int32][]int32) []int32 {
+	var result []int32
+	visited := map[int32]bool{root: true}
+	queue := []int32{root}
+	for len(queue) > 0 {
+		for _, child := range children[queue[0]] {
+			if !visited[child] {
+				visited[child] = true
+				result = append(result, child)
+				queue = append(queue, child)
+			}
+		}
+		queue = queue[1:]
+	}
+	return result
+}
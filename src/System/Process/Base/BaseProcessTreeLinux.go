@@ -0,0 +1,40 @@
+//go:build linux
+
+package BaseProcess
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// descendants 返回 root 的所有后代进程，通过一次遍历 /proc 建立父子关系
+func descendants(root int32) []int32 {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+
+	children := make(map[int32][]int32)
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile("/proc/" + entry.Name() + "/stat")
+		if err != nil {
+			continue
+		}
+		// 进程名可能包含空格与括号，从最后一个右括号之后开始解析: state ppid ...
+		fields := strings.Fields(string(data[strings.LastIndexByte(string(data), ')')+1:]))
+		if len(fields) < 2 {
+			continue
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		children[int32(ppid)] = append(children[int32(ppid)], int32(pid))
+	}
+	return walkTree(root, children)
+}
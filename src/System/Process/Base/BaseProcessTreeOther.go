@@ -0,0 +1,27 @@
+//go:build !linux && !windows
+
+package BaseProcess
+
+import "github.com/shirou/gopsutil/v3/process"
+
+// descendants 返回 root 的所有后代进程，逐级查询子进程
+func descendants(root int32) []int32 {
+	var result []int32
+	queue := []int32{root}
+	for len(queue) > 0 {
+		proc, err := process.NewProcess(queue[0])
+		queue = queue[1:]
+		if err != nil {
+			continue
+		}
+		children, err := proc.Children()
+		if err != nil {
+			continue
+		}
+		for _, child := range children {
+			result = append(result, child.Pid)
+			queue = append(queue, child.Pid)
+		}
+	}
+	return result
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package BaseProcess
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// descendants 返回 root 的所有后代进程，通过一次进程快照建立父子关系
+func descendants(root int32) []int32 {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil
+	}
+	defer windows.CloseHandle(snap)
+
+	children := make(map[int32][]int32)
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		parent, pid := int32(entry.ParentProcessID), int32(entry.ProcessID)
+		if pid != 0 && pid != parent {
+			children[parent] = append(children[parent], pid)
+		}
+	}
+	return walkTree(root, children)
+}
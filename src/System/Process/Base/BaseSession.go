@@ -0,0 +1,103 @@
+package BaseProcess
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+	"voxesis/src/Common/Entity"
+)
+
+// SessionHostFlag 以会话托管进程身份运行 Voxesis 可执行文件时使用的命令行参数
+const SessionHostFlag = "--voxesis-session-host"
+
+// 会话相关的超时
+const (
+	sessionLaunchTimeout  = 15 * time.Second // 等待托管进程启动服务器的时长
+	sessionRequestTimeout = 10 * time.Second // 等待托管进程响应请求的时长
+	sessionLinger         = 10 * time.Minute // 服务器退出后等待 Voxesis 连接以取走退出结果的时长
+)
+
+// 会话消息类型
+const (
+	sessionAttach  = "attach"  // 客户端: 开始接收序号大于 Since 的输出
+	sessionCommand = "command" // 客户端: 向服务器发送命令
+	sessionResize  = "resize"  // 客户端: 调整终端尺寸
+	sessionStop    = "stop"    // 客户端: 停止服务器
+	sessionStatus  = "status"  // 客户端: 查询服务器状态
+	sessionOutput  = "output"  // 托管进程: 一行输出
+	sessionResult  = "result"  // 托管进程: 请求的结果
+	sessionExited  = "exited"  // 托管进程: 服务器已退出
+)
+
+// SessionConfig 会话托管进程启动服务器所需的配置，由 Voxesis 通过标准输入传递给托管进程
+type SessionConfig struct {
+	Binary          string                 `json:"binary"`
+	Args            []string               `json:"args"`
+	WorkingDir      string                 `json:"workingDir"`
+	Env             []string               `json:"env"`      // 为 nil 时继承托管进程的环境变量
+	Terminal        bool                   `json:"terminal"` // 是否在伪终端中运行服务器
+	Socket          string                 `json:"socket"`   // 托管进程监听的 Unix 套接字路径
+	StopTimeoutMs   int                    `json:"stopTimeoutMs"`
+	ScrollbackLines int                    `json:"scrollbackLines"`
+	ScrollbackBytes int                    `json:"scrollbackBytes"`
+	Limits          *entity.ResourceLimits `json:"limits,omitempty"` // 仅普通进程支持
+}
+
+// sessionHandshake 托管进程启动服务器后通过标准输出返回的结果
+type sessionHandshake struct {
+	Pid   int    `json:"pid"`
+	Error string `json:"error,omitempty"`
+}
+
+// sessionMessage 托管进程与 Voxesis 之间以换行分隔的 JSON 消息
+type sessionMessage struct {
+	Type string `json:"type"`
+	Id   int    `json:"id,omitempty"` // 请求编号，结果使用相同的编号
+
+	Since     uint64 `json:"since,omitempty"`
+	Data      string `json:"data,omitempty"`
+	Cols      uint16 `json:"cols,omitempty"`
+	Rows      uint16 `json:"rows,omitempty"`
+	TimeoutMs int    `json:"timeoutMs,omitempty"`
+
+	Seq       uint64                   `json:"seq,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+	State     *entity.ProcessState     `json:"state,omitempty"`
+	Lifecycle *entity.ProcessLifecycle `json:"lifecycle,omitempty"`
+}
+
+// sessionConn 带写锁的会话连接，消息按行编码
+type sessionConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+}
+
+func newSessionConn(conn net.Conn) *sessionConn {
+	return &sessionConn{conn: conn, reader: bufio.NewReaderSize(conn, 64*1024)}
+}
+
+// read 读取一条消息
+func (c *sessionConn) read() (sessionMessage, error) {
+	var msg sessionMessage
+	line, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		return msg, err
+	}
+	err = json.Unmarshal(line, &msg)
+	return msg, err
+}
+
+// write 写入一条消息
+func (c *sessionConn) write(msg sessionMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err = c.conn.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,366 @@
+package BaseProcess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+)
+
+// ErrSessionLost 表示与会话托管进程的连接意外断开，服务器的状态未知
+var ErrSessionLost = errors.New("与会话托管进程的连接已断开")
+
+// SessionProcessManager 通过 Unix 套接字管理运行在会话托管进程中的服务器。
+// 托管进程与 Voxesis 相互独立，Voxesis 重启后可以重新连接并补齐期间的输出。
+// 此结构体的所有方法都是并发安全的。
+type SessionProcessManager struct {
+	mu             sync.RWMutex
+	socket         string
+	conn           *sessionConn
+	outputCallback func(log string)
+	exitCallback   func(err error)
+	stopTimeout    time.Duration
+	lifecycle      entity.ProcessLifecycle
+
+	lastSeq uint64 // 已收到的最新输出序号，重新连接时从这里继续
+	nextId  int
+	pending map[int]chan sessionMessage
+	exited  chan struct{} // 服务器退出或连接断开后关闭，未连接时为 nil
+}
+
+// NewSessionProcessManager 为监听在 socket 上的会话创建管理器。
+func NewSessionProcessManager(socket string) *SessionProcessManager {
+	return &SessionProcessManager{
+		socket:      socket,
+		stopTimeout: DefaultStopTimeout,
+		lifecycle:   entity.ProcessLifecycle{State: entity.LifecycleCreated},
+	}
+}
+
+// SetOutputCallback 设置处理服务器输出的回调，重新连接时会先回放托管进程缓冲的输出。
+func (pm *SessionProcessManager) SetOutputCallback(callback func(log string)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.outputCallback = callback
+}
+
+// SetExitCallback 设置服务器退出或连接断开时的回调。
+func (pm *SessionProcessManager) SetExitCallback(callback func(err error)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.exitCallback = callback
+}
+
+// SetStopTimeout 设置 Stop 时托管进程发送终止信号后等待服务器退出的时长。
+func (pm *SessionProcessManager) SetStopTimeout(timeout time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	pm.stopTimeout = timeout
+}
+
+// Launch 启动会话托管进程并由它启动服务器，随后连接到会话。
+func (pm *SessionProcessManager) Launch(config SessionConfig) error {
+	pm.setLifecycle(func(l *entity.ProcessLifecycle) {
+		*l = entity.ProcessLifecycle{State: entity.LifecycleStarting, LastError: l.LastError}
+	})
+
+	err := launchSessionHost(config)
+	if err == nil {
+		pm.mu.Lock()
+		pm.lastSeq = 0
+		pm.mu.Unlock()
+		err = pm.Connect()
+	}
+	if err != nil {
+		now := time.Now()
+		pm.setLifecycle(func(l *entity.ProcessLifecycle) {
+			l.State = entity.LifecycleCrashed
+			l.StoppedAt = &now
+			l.LastError = err.Error()
+		})
+	}
+	return err
+}
+
+// launchSessionHost 以 SessionHostFlag 运行当前可执行文件作为托管进程，并等待它启动服务器
+func launchSessionHost(config SessionConfig) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("获取 Voxesis 可执行文件路径失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(config.Socket), 0755); err != nil {
+		return fmt.Errorf("创建会话目录失败: %w", err)
+	}
+
+	cmd := exec.Command(exe, SessionHostFlag)
+	cmd.Dir = filepath.Dir(config.Socket)
+	cmd.SysProcAttr = newDetachedSysProcAttr()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("启动会话托管进程失败: %w", err)
+	}
+	// 托管进程在 Voxesis 运行期间退出时回收，Voxesis 退出后由系统收养
+	go func() { _ = cmd.Wait() }()
+
+	if err := json.NewEncoder(stdin).Encode(config); err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("向会话托管进程传递配置失败: %w", err)
+	}
+	_ = stdin.Close()
+
+	replies := make(chan sessionHandshake, 1)
+	go func() {
+		var handshake sessionHandshake
+		if err := json.NewDecoder(stdout).Decode(&handshake); err != nil {
+			handshake.Error = fmt.Sprintf("会话托管进程意外退出: %v", err)
+		}
+		replies <- handshake
+	}()
+
+	select {
+	case handshake := <-replies:
+		if handshake.Error != "" {
+			return errors.New(handshake.Error)
+		}
+		vlogger.AppLogger.Infof("会话托管进程已启动服务器, PID: %d, 套接字: %s", handshake.Pid, config.Socket)
+		return nil
+	case <-time.After(sessionLaunchTimeout):
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("会话托管进程未在 %s 内启动服务器", sessionLaunchTimeout)
+	}
+}
+
+// Connect 连接到会话并回放上次收到之后的输出，服务器已退出时同样会上报退出结果。
+func (pm *SessionProcessManager) Connect() error {
+	conn, err := net.DialTimeout("unix", pm.socket, sessionRequestTimeout)
+	if err != nil {
+		return fmt.Errorf("连接会话 %s 失败: %w", pm.socket, err)
+	}
+
+	pm.mu.Lock()
+	if pm.running() {
+		pm.mu.Unlock()
+		_ = conn.Close()
+		return fmt.Errorf("已连接到会话 %s", pm.socket)
+	}
+	session := newSessionConn(conn)
+	exited := make(chan struct{})
+	pm.conn = session
+	pm.exited = exited
+	pm.pending = make(map[int]chan sessionMessage)
+	since := pm.lastSeq
+	pm.mu.Unlock()
+
+	go pm.readLoop(session, exited)
+
+	result, err := pm.request(sessionMessage{Type: sessionAttach, Since: since})
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if result.Lifecycle != nil {
+		pm.setLifecycle(func(l *entity.ProcessLifecycle) { *l = *result.Lifecycle })
+	}
+	return nil
+}
+
+// readLoop 接收托管进程的消息，连接断开时视为服务器状态未知
+func (pm *SessionProcessManager) readLoop(session *sessionConn, exited chan struct{}) {
+	var exitErr error
+	for {
+		msg, err := session.read()
+		if err != nil {
+			exitErr = ErrSessionLost
+			now := time.Now()
+			pm.setLifecycle(func(l *entity.ProcessLifecycle) {
+				l.State = entity.LifecycleCrashed
+				l.StoppedAt = &now
+				l.LastError = ErrSessionLost.Error()
+			})
+			break
+		}
+
+		switch msg.Type {
+		case sessionOutput:
+			pm.mu.Lock()
+			callback, duplicate := pm.outputCallback, msg.Seq <= pm.lastSeq
+			if !duplicate {
+				pm.lastSeq = msg.Seq
+			}
+			pm.mu.Unlock()
+			if !duplicate && callback != nil {
+				callback(msg.Data)
+			}
+		case sessionResult:
+			pm.mu.Lock()
+			reply, ok := pm.pending[msg.Id]
+			delete(pm.pending, msg.Id)
+			pm.mu.Unlock()
+			if ok {
+				reply <- msg
+			}
+		case sessionExited:
+			if msg.Lifecycle != nil {
+				pm.setLifecycle(func(l *entity.ProcessLifecycle) { *l = *msg.Lifecycle })
+			}
+			if msg.Error != "" {
+				exitErr = errors.New(msg.Error)
+			}
+		}
+		if msg.Type == sessionExited {
+			break
+		}
+	}
+
+	_ = session.conn.Close()
+	pm.mu.Lock()
+	close(exited)
+	pm.conn = nil
+	callback := pm.exitCallback
+	pm.mu.Unlock()
+
+	if callback != nil {
+		callback(exitErr)
+	}
+}
+
+// request 发送请求并等待结果，结果中的错误同样作为 error 返回
+func (pm *SessionProcessManager) request(msg sessionMessage) (sessionMessage, error) {
+	return pm.requestTimeout(msg, sessionRequestTimeout)
+}
+
+// requestTimeout 发送请求并最多等待 timeout
+func (pm *SessionProcessManager) requestTimeout(msg sessionMessage, timeout time.Duration) (sessionMessage, error) {
+	pm.mu.Lock()
+	if !pm.running() {
+		pm.mu.Unlock()
+		return sessionMessage{}, fmt.Errorf("未连接到会话")
+	}
+	pm.nextId++
+	msg.Id = pm.nextId
+	reply := make(chan sessionMessage, 1)
+	pm.pending[msg.Id] = reply
+	session, exited := pm.conn, pm.exited
+	pm.mu.Unlock()
+
+	if err := session.write(msg); err != nil {
+		return sessionMessage{}, fmt.Errorf("向会话托管进程发送请求失败: %w", err)
+	}
+
+	select {
+	case result := <-reply:
+		if result.Error != "" {
+			return result, errors.New(result.Error)
+		}
+		return result, nil
+	case <-exited:
+		return sessionMessage{}, fmt.Errorf("服务器已退出")
+	case <-time.After(timeout):
+		pm.mu.Lock()
+		delete(pm.pending, msg.Id)
+		pm.mu.Unlock()
+		return sessionMessage{}, fmt.Errorf("会话托管进程未在 %s 内响应", timeout)
+	}
+}
+
+// Stop 请求托管进程停止服务器并等待其退出。
+// 托管进程先发送终止信号，超过 stopTimeout 仍未退出则强制杀死并返回 ErrForceKilled。
+func (pm *SessionProcessManager) Stop() error {
+	pm.mu.Lock()
+	if !pm.running() {
+		pm.mu.Unlock()
+		return nil
+	}
+	timeout, exited := pm.stopTimeout, pm.exited
+	pm.lifecycle.State = entity.LifecycleStopping
+	pm.mu.Unlock()
+
+	// 托管进程强制杀死后还会清理进程树，额外留出等待时间
+	_, err := pm.requestTimeout(sessionMessage{Type: sessionStop, TimeoutMs: int(timeout.Milliseconds())},
+		timeout+2*treeKillTimeout+sessionRequestTimeout)
+	select {
+	case <-exited:
+	case <-time.After(sessionRequestTimeout):
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case err.Error() == ErrForceKilled.Error():
+		return ErrForceKilled
+	default:
+		return err
+	}
+}
+
+// SendCommand 向服务器发送一个命令。
+func (pm *SessionProcessManager) SendCommand(command string) error {
+	_, err := pm.request(sessionMessage{Type: sessionCommand, Data: command})
+	return err
+}
+
+// Resize 调整服务器伪终端的尺寸，会话未使用伪终端时返回错误。
+func (pm *SessionProcessManager) Resize(cols, rows uint16) error {
+	_, err := pm.request(sessionMessage{Type: sessionResize, Cols: cols, Rows: rows})
+	return err
+}
+
+// GetProcessStatus 获取服务器进程树的资源使用情况。
+func (pm *SessionProcessManager) GetProcessStatus() (entity.ProcessState, error) {
+	result, err := pm.request(sessionMessage{Type: sessionStatus})
+	if err != nil || result.State == nil {
+		return entity.ProcessState{}, err
+	}
+	return *result.State, nil
+}
+
+// GetLifecycle 返回托管进程记录的服务器生命周期。
+func (pm *SessionProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.lifecycle
+}
+
+// IsRunning 检查是否已连接到会话且服务器仍在运行。
+func (pm *SessionProcessManager) IsRunning() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.running()
+}
+
+// running 调用方需持有锁
+func (pm *SessionProcessManager) running() bool {
+	if pm.exited == nil {
+		return false
+	}
+	select {
+	case <-pm.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// setLifecycle 修改生命周期记录
+func (pm *SessionProcessManager) setLifecycle(update func(l *entity.ProcessLifecycle)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	update(&pm.lifecycle)
+}
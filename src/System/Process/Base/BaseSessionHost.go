@@ -0,0 +1,424 @@
+package BaseProcess
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+	vutils "voxesis/src/Common/Utils"
+)
+
+// sessionClientBuffer 每个连接等待发送的消息上限，客户端读取过慢时断开，由其重新连接补齐输出
+const sessionClientBuffer = 4096
+
+// sessionBackend 托管进程内实际运行服务器的进程管理器
+type sessionBackend interface {
+	SetOutputCallback(callback func(log string))
+	SetExitCallback(callback func(err error))
+	SetEnv(env []string)
+	SetStopTimeout(timeout time.Duration)
+	Start(workingDir string, options ...string) error
+	Stop() error
+	GetProcessStatus() (entity.ProcessState, error)
+	GetLifecycle() entity.ProcessLifecycle
+	SendCommand(command string) error
+}
+
+// sessionClient 托管进程一侧的客户端连接
+type sessionClient struct {
+	conn     *sessionConn
+	outbox   chan sessionMessage
+	closed   chan struct{}
+	closeOne sync.Once
+}
+
+// close 断开连接，可重复调用
+func (c *sessionClient) close() {
+	c.closeOne.Do(func() {
+		close(c.closed)
+		_ = c.conn.conn.Close()
+	})
+}
+
+// sessionHost 在 Voxesis 之外运行服务器的会话托管进程，Voxesis 退出或重启不影响服务器
+type sessionHost struct {
+	backend    sessionBackend
+	listener   net.Listener
+	scrollback *vutils.ScrollbackBuffer
+
+	mu        sync.Mutex // 保证输出分配序号、回放与分发的顺序一致
+	clients   map[*sessionClient]struct{}
+	stopping  int // 正在处理的停止请求数，退出结果在停止请求的结果之后发送
+	exited    chan struct{}
+	exitMsg   sessionMessage
+	delivered chan struct{} // 退出结果已发送给至少一个客户端后关闭
+	deliverer sync.Once
+}
+
+// RunSessionHost 以会话托管进程身份运行，返回进程退出码。
+// 从标准输入读取 SessionConfig，启动服务器后通过标准输出返回握手结果，之后只通过 Unix 套接字通信。
+// 服务器退出并将结果交给 Voxesis 后托管进程随之退出。
+func RunSessionHost() int {
+	// 日志同时输出到标准输出，握手结果只写入原来的标准输出，其余输出丢弃
+	handshake := os.Stdout
+	if devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+		os.Stdout = devNull
+	}
+
+	var config SessionConfig
+	if err := json.NewDecoder(os.Stdin).Decode(&config); err != nil {
+		writeHandshake(handshake, sessionHandshake{Error: fmt.Sprintf("读取会话配置失败: %v", err)})
+		return 1
+	}
+
+	logName := strings.TrimSuffix(filepath.Base(config.Socket), filepath.Ext(config.Socket)) + ".host.log"
+	if err := vlogger.InitLogger(filepath.Dir(config.Socket), logName); err != nil {
+		writeHandshake(handshake, sessionHandshake{Error: fmt.Sprintf("初始化托管进程日志失败: %v", err)})
+		return 1
+	}
+
+	host, pid, err := startSessionHost(config)
+	if err != nil {
+		writeHandshake(handshake, sessionHandshake{Error: err.Error()})
+		return 1
+	}
+	writeHandshake(handshake, sessionHandshake{Pid: pid})
+
+	// 与 Voxesis 之间的管道只用于握手，之后关闭以免 Voxesis 退出时影响托管进程
+	_ = os.Stdin.Close()
+	_ = handshake.Close()
+	return host.serve()
+}
+
+// writeHandshake 向 Voxesis 返回握手结果
+func writeHandshake(w io.Writer, handshake sessionHandshake) {
+	_ = json.NewEncoder(w).Encode(handshake)
+}
+
+// startSessionHost 监听套接字并启动服务器，返回服务器的 PID
+func startSessionHost(config SessionConfig) (*sessionHost, int, error) {
+	// 套接字仍可连接说明已有托管进程在运行同一个会话
+	if conn, err := net.DialTimeout("unix", config.Socket, time.Second); err == nil {
+		_ = conn.Close()
+		return nil, 0, fmt.Errorf("会话 %s 已在运行", config.Socket)
+	}
+	_ = os.Remove(config.Socket)
+	listener, err := net.Listen("unix", config.Socket)
+	if err != nil {
+		return nil, 0, fmt.Errorf("监听会话套接字 %s 失败: %w", config.Socket, err)
+	}
+
+	backend, err := newSessionBackend(config)
+	if err != nil {
+		_ = listener.Close()
+		return nil, 0, err
+	}
+
+	lines, bytes := config.ScrollbackLines, config.ScrollbackBytes
+	if lines <= 0 {
+		lines = 5000
+	}
+	if bytes <= 0 {
+		bytes = 4 << 20
+	}
+	host := &sessionHost{
+		backend:    backend,
+		listener:   listener,
+		scrollback: vutils.NewScrollbackBuffer(lines, bytes),
+		clients:    make(map[*sessionClient]struct{}),
+		exited:     make(chan struct{}),
+		delivered:  make(chan struct{}),
+	}
+	backend.SetOutputCallback(host.output)
+	backend.SetExitCallback(host.exit)
+
+	if err := backend.Start(config.WorkingDir, config.Args...); err != nil {
+		_ = listener.Close()
+		return nil, 0, err
+	}
+
+	state, _ := backend.GetProcessStatus()
+	var pid int
+	_, _ = fmt.Sscan(state.Pid, &pid)
+	return host, pid, nil
+}
+
+// newSessionBackend 按配置创建运行服务器的进程管理器
+func newSessionBackend(config SessionConfig) (sessionBackend, error) {
+	var backend sessionBackend
+	switch {
+	case !config.Terminal:
+		manager, err := NewProcessManager(config.Binary)
+		if err != nil {
+			return nil, err
+		}
+		manager.SetResourceLimits(config.Limits)
+		backend = manager
+	case runtime.GOOS == "windows":
+		manager, err := NewConPtyProcessManager(config.Binary)
+		if err != nil {
+			return nil, err
+		}
+		backend = manager
+	default:
+		manager, err := NewUnixPtyProcessManager(config.Binary)
+		if err != nil {
+			return nil, err
+		}
+		backend = manager
+	}
+
+	backend.SetEnv(config.Env)
+	if config.StopTimeoutMs > 0 {
+		backend.SetStopTimeout(time.Duration(config.StopTimeoutMs) * time.Millisecond)
+	}
+	return backend, nil
+}
+
+// serve 接受 Voxesis 的连接，服务器退出且结果已取走 (或等待超时) 后返回
+func (h *sessionHost) serve() int {
+	// 托管进程被终止时先停止服务器
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		vlogger.AppLogger.Info("托管进程收到终止信号，正在停止服务器")
+		if err := h.backend.Stop(); err != nil {
+			vlogger.AppLogger.Warnf("停止服务器失败: %v", err)
+		}
+	}()
+
+	go func() {
+		for {
+			conn, err := h.listener.Accept()
+			if err != nil {
+				return
+			}
+			go h.handle(conn)
+		}
+	}()
+
+	<-h.exited
+	select {
+	case <-h.delivered:
+	case <-time.After(sessionLinger):
+		vlogger.AppLogger.Warn("服务器已退出，但 Voxesis 未在等待时间内取走退出结果")
+	}
+
+	_ = h.listener.Close()
+	h.drain(time.Second)
+	h.mu.Lock()
+	for client := range h.clients {
+		client.close()
+	}
+	h.mu.Unlock()
+	return 0
+}
+
+// drain 等待仍连接的客户端收到退出结果后自行断开，最多等待 timeout
+func (h *sessionHost) drain(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		h.mu.Lock()
+		remaining := len(h.clients)
+		h.mu.Unlock()
+		if remaining == 0 {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// output 为一行输出分配序号并分发给所有客户端
+func (h *sessionHost) output(data string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	line := h.scrollback.Append(data)
+	for client := range h.clients {
+		h.send(client, sessionMessage{Type: sessionOutput, Seq: line.Seq, Data: line.Data})
+	}
+}
+
+// exit 记录服务器的退出结果并通知所有客户端
+func (h *sessionHost) exit(err error) {
+	lifecycle := h.backend.GetLifecycle()
+	msg := sessionMessage{Type: sessionExited, Lifecycle: &lifecycle}
+	if err != nil {
+		msg.Error = err.Error()
+	}
+	vlogger.AppLogger.Infof("服务器已退出: %v", err)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.exitMsg = msg
+	close(h.exited)
+	if h.stopping == 0 {
+		h.broadcastExit()
+	}
+}
+
+// broadcastExit 将退出结果发送给所有客户端，调用方需持有 h.mu
+func (h *sessionHost) broadcastExit() {
+	for client := range h.clients {
+		h.send(client, h.exitMsg)
+	}
+}
+
+// send 将消息放入客户端的发送队列，队列已满时断开该客户端，调用方需持有 h.mu
+func (h *sessionHost) send(client *sessionClient, msg sessionMessage) {
+	select {
+	case client.outbox <- msg:
+	default:
+		vlogger.AppLogger.Warn("客户端读取输出过慢，已断开连接")
+		delete(h.clients, client)
+		client.close()
+	}
+}
+
+// handle 处理一个客户端连接上的请求
+func (h *sessionHost) handle(conn net.Conn) {
+	client := &sessionClient{
+		conn:   newSessionConn(conn),
+		outbox: make(chan sessionMessage, sessionClientBuffer),
+		closed: make(chan struct{}),
+	}
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, client)
+		h.mu.Unlock()
+		client.close()
+	}()
+	go h.writeLoop(client)
+
+	for {
+		msg, err := client.conn.read()
+		if err != nil {
+			return
+		}
+		switch msg.Type {
+		case sessionAttach:
+			h.attach(client, msg)
+		case sessionStop:
+			// 停止可能持续较久，不阻塞同一连接上的其他请求
+			h.mu.Lock()
+			h.stopping++
+			h.mu.Unlock()
+			go h.stop(client, msg)
+		default:
+			h.reply(client, msg, h.request(msg))
+		}
+	}
+}
+
+// writeLoop 依次发送客户端队列中的消息
+func (h *sessionHost) writeLoop(client *sessionClient) {
+	for {
+		select {
+		case <-client.closed:
+			return
+		case msg := <-client.outbox:
+			if err := client.conn.write(msg); err != nil {
+				client.close()
+				return
+			}
+			// 退出结果是最后一条消息，发送后断开连接
+			if msg.Type == sessionExited {
+				h.deliverer.Do(func() { close(h.delivered) })
+				client.close()
+				return
+			}
+		}
+	}
+}
+
+// attach 回放序号大于 Since 的输出，之后开始向客户端分发新的输出
+func (h *sessionHost) attach(client *sessionClient, msg sessionMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lifecycle := h.backend.GetLifecycle()
+	h.send(client, sessionMessage{Type: sessionResult, Id: msg.Id, Lifecycle: &lifecycle})
+	lines, _, _ := h.scrollback.Since(msg.Since)
+	for _, line := range lines {
+		h.send(client, sessionMessage{Type: sessionOutput, Seq: line.Seq, Data: line.Data})
+	}
+
+	select {
+	case <-h.exited:
+		h.send(client, h.exitMsg)
+	default:
+		h.clients[client] = struct{}{}
+	}
+}
+
+// stop 停止服务器并回复结果，超时被强制杀死时结果为 ErrForceKilled，之后再发送退出结果
+func (h *sessionHost) stop(client *sessionClient, msg sessionMessage) {
+	if msg.TimeoutMs > 0 {
+		h.backend.SetStopTimeout(time.Duration(msg.TimeoutMs) * time.Millisecond)
+	}
+	result := sessionMessage{Type: sessionResult, Id: msg.Id}
+	if err := h.backend.Stop(); err != nil {
+		result.Error = err.Error()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.send(client, result)
+	h.stopping--
+	select {
+	case <-h.exited:
+		if h.stopping == 0 {
+			h.broadcastExit()
+		}
+	default:
+	}
+}
+
+// request 处理命令、调整尺寸与状态查询请求
+func (h *sessionHost) request(msg sessionMessage) sessionMessage {
+	var (
+		result sessionMessage
+		err    error
+	)
+	switch msg.Type {
+	case sessionCommand:
+		err = h.backend.SendCommand(msg.Data)
+	case sessionResize:
+		resizable, ok := h.backend.(interface{ Resize(cols, rows uint16) error })
+		if !ok {
+			err = errors.New("会话未使用伪终端，无法调整终端尺寸")
+			break
+		}
+		err = resizable.Resize(msg.Cols, msg.Rows)
+	case sessionStatus:
+		var state entity.ProcessState
+		state, err = h.backend.GetProcessStatus()
+		result.State = &state
+	default:
+		err = fmt.Errorf("未知的会话请求: %s", msg.Type)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// reply 将请求的结果发送给客户端
+func (h *sessionHost) reply(client *sessionClient, request sessionMessage, result sessionMessage) {
+	result.Type = sessionResult
+	result.Id = request.Id
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.send(client, result)
+}
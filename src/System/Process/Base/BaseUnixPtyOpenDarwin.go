@@ -0,0 +1,50 @@
+//go:build darwin
+
+package BaseProcess
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPty 打开一对伪终端，返回主设备与从设备。
+func openPty() (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("打开 /dev/ptmx 失败: %w", err)
+	}
+	fd := master.Fd()
+
+	// 相当于 grantpt(3) 与 unlockpt(3)
+	if err := unix.IoctlSetInt(int(fd), unix.TIOCPTYGRANT, 0); err != nil {
+		_ = master.Close()
+		return nil, nil, fmt.Errorf("授权伪终端失败: %w", err)
+	}
+	if err := unix.IoctlSetInt(int(fd), unix.TIOCPTYUNLK, 0); err != nil {
+		_ = master.Close()
+		return nil, nil, fmt.Errorf("解锁伪终端失败: %w", err)
+	}
+
+	// 相当于 ptsname(3)，TIOCPTYGNAME 要求 128 字节的缓冲区
+	name := make([]byte, 128)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(unix.TIOCPTYGNAME), uintptr(unsafe.Pointer(&name[0]))); errno != 0 {
+		_ = master.Close()
+		return nil, nil, fmt.Errorf("获取伪终端名称失败: %w", errno)
+	}
+	if i := bytes.IndexByte(name, 0); i >= 0 {
+		name = name[:i]
+	}
+
+	slave, err := os.OpenFile(string(name), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		_ = master.Close()
+		return nil, nil, fmt.Errorf("打开伪终端从设备失败: %w", err)
+	}
+
+	return master, slave, nil
+}
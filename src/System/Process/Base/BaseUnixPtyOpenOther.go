@@ -0,0 +1,14 @@
+//go:build !windows && !linux && !darwin
+
+package BaseProcess
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// openPty 在未适配的平台上总是返回错误。
+func openPty() (*os.File, *os.File, error) {
+	return nil, nil, fmt.Errorf("伪终端方案暂不支持 %s 平台", runtime.GOOS)
+}
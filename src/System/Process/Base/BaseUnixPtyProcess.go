@@ -0,0 +1,354 @@
+//go:build !windows
+
+package BaseProcess
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"voxesis/src/Common/Entity"
+	vlogger "voxesis/src/Common/Logger"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"golang.org/x/sys/unix"
+)
+
+// 默认的终端尺寸，与 ConPTY 方案保持一致，使用超宽终端避免日志被折行。
+const (
+	defaultPtyCols = 8192
+	defaultPtyRows = 100
+)
+
+// UnixPtyProcessManager 负责管理一个运行在 POSIX 伪终端中的外部进程。
+// 这是 ConPtyProcessManager 在 Linux / macOS 上的对应实现。
+// 此结构体的所有方法都是并发安全的。
+type UnixPtyProcessManager struct {
+	mu             sync.RWMutex
+	binary         string
+	cmd            *exec.Cmd
+	pty            *os.File // 伪终端主设备，同时用于读取输出和写入命令
+	proc           *process.Process
+	outputCallback func(log string)
+	exitCallback   func(err error)
+	cols, rows     uint16
+	stopTimeout    time.Duration // 发送 SIGTERM 后等待退出的时长
+	env            []string      // 为 nil 时继承当前进程的环境变量
+	lifecycle      lifecycleRecorder
+	stopping       bool // Stop 正在终止当前进程树
+
+	monitor *treeMonitor // 进程树资源监控，进程运行时不为 nil
+}
+
+// NewUnixPtyProcessManager 为给定的可执行文件路径创建一个新的伪终端进程管理器。
+func NewUnixPtyProcessManager(path string) (*UnixPtyProcessManager, error) {
+	binaryPath, err := exec.LookPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("未能找到可执行文件 '%s': %w", path, err)
+	}
+	return &UnixPtyProcessManager{
+		binary:      binaryPath,
+		cols:        defaultPtyCols,
+		rows:        defaultPtyRows,
+		stopTimeout: DefaultStopTimeout,
+	}, nil
+}
+
+// SetOutputCallback 设置一个回调函数来处理进程的合并输出。
+func (pm *UnixPtyProcessManager) SetOutputCallback(callback func(log string)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.outputCallback = callback
+}
+
+// SetExitCallback 设置进程退出时的回调，参数为 cmd.Wait() 的返回值。
+func (pm *UnixPtyProcessManager) SetExitCallback(callback func(err error)) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.exitCallback = callback
+}
+
+// SetEnv 设置子进程的完整环境变量，格式为 "KEY=VALUE"。
+func (pm *UnixPtyProcessManager) SetEnv(env []string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.env = env
+}
+
+// SetStopTimeout 设置 Stop 发送 SIGTERM 后等待进程退出的时长。
+func (pm *UnixPtyProcessManager) SetStopTimeout(timeout time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	pm.stopTimeout = timeout
+}
+
+// Start 使用给定的命令行参数和工作目录来执行进程，并启动后台监控。
+func (pm *UnixPtyProcessManager) Start(workingDir string, options ...string) (err error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.pty != nil {
+		return fmt.Errorf("此管理器已在运行一个进程")
+	}
+
+	pm.lifecycle.starting()
+	defer func() {
+		if err != nil {
+			pm.lifecycle.failed(err)
+		}
+	}()
+
+	master, slave, err := openPty()
+	if err != nil {
+		return fmt.Errorf("创建伪终端失败: %w", err)
+	}
+	// 子进程持有从设备的副本，父进程这一端在启动后即可关闭
+	defer func() { _ = slave.Close() }()
+
+	if err := setWinsize(master, pm.cols, pm.rows); err != nil {
+		_ = master.Close()
+		return err
+	}
+
+	cmd := exec.Command(pm.binary, options...)
+	cmd.Dir = workingDir
+	env := pm.env
+	if env == nil {
+		env = os.Environ()
+	}
+	cmd.Env = append(env[:len(env):len(env)], "TERM=xterm")
+	cmd.Stdin = slave
+	cmd.Stdout = slave
+	cmd.Stderr = slave
+	// 新建会话并将从设备设为控制终端，Ctty 指的是子进程中的 fd 0
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+
+	if err := cmd.Start(); err != nil {
+		_ = master.Close()
+		return fmt.Errorf("启动伪终端进程失败: %w", err)
+	}
+	pm.cmd = cmd
+	pm.pty = master
+	pm.stopping = false
+	pm.lifecycle.running()
+
+	// 异步等待进程结束，并在结束后自动清理状态
+	go func() {
+		waitErr := cmd.Wait() // 阻塞直到进程退出
+		pm.lifecycle.exited(waitErr)
+		vlogger.AppLogger.Info("伪终端进程 (PID: ", cmd.Process.Pid, " ) 已退出。")
+
+		pm.mu.Lock()
+		tree := treeSnapshot{pgid: processGroup(cmd.Process.Pid)}
+		if pm.cmd == cmd {
+			if pm.monitor != nil && !pm.stopping {
+				tree = pm.monitor.snapshot(tree.pgid)
+			}
+			pm.release()
+		}
+		callback, timeout, stopping := pm.exitCallback, pm.stopTimeout, pm.stopping
+		pm.mu.Unlock()
+
+		// 主进程自行退出 (例如响应了控制台停止命令) 时同样清理残留的后代进程，完成后再上报退出
+		// 由 Stop 触发的退出由 Stop 负责清理
+		if !stopping {
+			reapOrphans(tree, timeout)
+		}
+
+		if callback != nil {
+			callback(waitErr)
+		}
+	}()
+
+	// 创建 gopsutil 进程对象用于监控
+	pm.proc, err = process.NewProcess(int32(cmd.Process.Pid))
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("进程已启动但创建监控器失败: %w", err)
+	}
+
+	// 启动日志读取和 CPU 监控
+	if pm.outputCallback != nil {
+		go pm.readPipe(master, "")
+	}
+
+	pm.monitor = newTreeMonitor(pm.proc.Pid)
+
+	vlogger.AppLogger.Info("进程已通过伪终端启动, PID: ", cmd.Process.Pid, ".")
+	return nil
+}
+
+// Stop 终止整个进程树，并停止后台监控。
+// 先向进程组发送 SIGTERM，超过 stopTimeout 仍未退出则强制杀死并返回 ErrForceKilled。
+// 主进程退出后仍有后代进程存活时强制杀死它们，依然无法清理时返回 ErrTreeSurvived。
+func (pm *UnixPtyProcessManager) Stop() error {
+	pm.mu.Lock()
+	cmd, timeout := pm.cmd, pm.stopTimeout
+	var tree treeSnapshot
+	if cmd != nil {
+		tree = snapshotTree(int32(cmd.Process.Pid), processGroup(cmd.Process.Pid))
+		pm.stopping = true
+	}
+	pm.mu.Unlock()
+
+	if cmd == nil {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	pm.lifecycle.stopping()
+	signalTree(tree, syscall.SIGTERM)
+
+	forced := !pm.waitExit(cmd, timeout)
+	if forced {
+		signalTree(tree, syscall.SIGKILL)
+		_ = cmd.Process.Kill()
+		pm.waitExit(cmd, DefaultStopTimeout)
+	}
+
+	// 主进程退出后确认整个进程树都已退出，避免残留进程继续占用端口
+	err := reapTree(tree, time.Until(deadline))
+	if forced {
+		if err != nil {
+			vlogger.AppLogger.Warnf("%v", err)
+		}
+		return ErrForceKilled
+	}
+	return err
+}
+
+// waitExit 等待进程退出并被清理，返回是否在超时前完成。
+func (pm *UnixPtyProcessManager) waitExit(cmd *exec.Cmd, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		pm.mu.RLock()
+		exited := pm.cmd != cmd
+		pm.mu.RUnlock()
+		if exited {
+			return true
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return false
+}
+
+// Resize 修改伪终端的窗口尺寸，进程会收到 SIGWINCH。
+func (pm *UnixPtyProcessManager) Resize(cols, rows uint16) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if cols == 0 || rows == 0 {
+		return fmt.Errorf("无效的终端尺寸: %dx%d", cols, rows)
+	}
+
+	pm.cols, pm.rows = cols, rows
+	if pm.pty == nil {
+		return nil // 下次启动时生效
+	}
+	return setWinsize(pm.pty, cols, rows)
+}
+
+// GetProcessStatus 返回进程树的当前资源使用情况，从最近一次采样读取。
+func (pm *UnixPtyProcessManager) GetProcessStatus() (entity.ProcessState, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	state := entity.ProcessState{}
+	if pm.proc == nil {
+		return state, nil
+	}
+	isRunning, err := pm.proc.IsRunning()
+	if err != nil || !isRunning {
+		return state, nil
+	}
+	state.Pid = fmt.Sprintf("%d", pm.proc.Pid)
+	pm.monitor.fill(&state)
+
+	if createTimeMs, err := pm.proc.CreateTime(); err == nil {
+		createTime := time.Unix(0, createTimeMs*int64(time.Millisecond))
+		uptime := time.Since(createTime).Round(time.Second)
+		state.RunTime = uptime.String()
+	} else {
+		state.RunTime = "unknow"
+	}
+	return state, nil
+}
+
+// readPipe 从伪终端读取所有输出
+func (pm *UnixPtyProcessManager) readPipe(ptyReader io.Reader, prefix string) {
+	scanner := bufio.NewScanner(ptyReader)
+	for scanner.Scan() {
+		// 终端输出以 \r\n 换行
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		pm.mu.RLock()
+		if pm.outputCallback != nil {
+			pm.outputCallback(fmt.Sprintf("%s %s", prefix, line))
+		}
+		pm.mu.RUnlock()
+	}
+}
+
+// release 停止监控、关闭伪终端并清理进程状态，调用方需持有写锁
+func (pm *UnixPtyProcessManager) release() {
+	if pm.monitor != nil {
+		pm.monitor.close()
+		pm.monitor = nil
+	}
+	if pm.pty != nil {
+		_ = pm.pty.Close()
+	}
+	pm.cmd = nil
+	pm.pty = nil
+	pm.proc = nil
+}
+
+// GetLifecycle 返回进程的生命周期记录，包括退出码、终止信号与启动、退出时间。
+func (pm *UnixPtyProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	return pm.lifecycle.snapshot()
+}
+
+// IsRunning 检查被管理的进程当前是否正在运行
+func (pm *UnixPtyProcessManager) IsRunning() bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if pm.proc == nil {
+		return false
+	}
+	running, err := pm.proc.IsRunning()
+	return err == nil && running
+}
+
+// SendCommand 向伪终端发送命令
+func (pm *UnixPtyProcessManager) SendCommand(command string) error {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if pm.pty == nil {
+		return fmt.Errorf("进程未启动或伪终端不可用")
+	}
+
+	// 终端的行规程会把 \r 转换为换行
+	command = strings.TrimRight(command, "\r\n") + "\r"
+
+	if _, err := pm.pty.Write([]byte(command)); err != nil {
+		return fmt.Errorf("向伪终端写入命令失败: %w", err)
+	}
+	return nil
+}
+
+// setWinsize 设置伪终端的窗口尺寸
+func setWinsize(pty *os.File, cols, rows uint16) error {
+	ws := &unix.Winsize{Col: cols, Row: rows}
+	if err := unix.IoctlSetWinsize(int(pty.Fd()), unix.TIOCSWINSZ, ws); err != nil {
+		return fmt.Errorf("设置终端尺寸失败: %w", err)
+	}
+	return nil
+}
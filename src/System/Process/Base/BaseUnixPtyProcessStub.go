@@ -0,0 +1,67 @@
+//go:build windows
+
+package BaseProcess
+
+import (
+	"fmt"
+	"time"
+	"voxesis/src/Common/Entity"
+)
+
+// UnixPtyProcessManager 在 Windows 平台上的占位实现。
+// Windows 请使用基于 ConPTY 的 ConPtyProcessManager。
+type UnixPtyProcessManager struct {
+	binary string
+}
+
+// NewUnixPtyProcessManager 为给定的可执行文件路径创建一个新的伪终端进程管理器。
+func NewUnixPtyProcessManager(path string) (*UnixPtyProcessManager, error) {
+	return &UnixPtyProcessManager{binary: path}, nil
+}
+
+// SetOutputCallback 在 Windows 平台上不做任何事。
+func (pm *UnixPtyProcessManager) SetOutputCallback(callback func(log string)) {}
+
+// SetExitCallback 在 Windows 平台上不做任何事。
+func (pm *UnixPtyProcessManager) SetExitCallback(callback func(err error)) {}
+
+// SetEnv 在 Windows 平台上不做任何事。
+func (pm *UnixPtyProcessManager) SetEnv(env []string) {}
+
+// SetStopTimeout 在 Windows 平台上不做任何事。
+func (pm *UnixPtyProcessManager) SetStopTimeout(timeout time.Duration) {}
+
+// Start 在 Windows 平台上总是返回错误。
+func (pm *UnixPtyProcessManager) Start(workingDir string, options ...string) error {
+	return fmt.Errorf("伪终端方案仅支持类 Unix 平台")
+}
+
+// Stop 在 Windows 平台上不做任何事。
+func (pm *UnixPtyProcessManager) Stop() error {
+	return nil
+}
+
+// Resize 在 Windows 平台上总是返回错误。
+func (pm *UnixPtyProcessManager) Resize(cols, rows uint16) error {
+	return fmt.Errorf("伪终端方案仅支持类 Unix 平台")
+}
+
+// GetProcessStatus 在 Windows 平台上总是返回空状态。
+func (pm *UnixPtyProcessManager) GetProcessStatus() (entity.ProcessState, error) {
+	return entity.ProcessState{}, nil
+}
+
+// GetLifecycle 在 Windows 平台上总是返回从未启动的状态。
+func (pm *UnixPtyProcessManager) GetLifecycle() entity.ProcessLifecycle {
+	return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+}
+
+// IsRunning 在 Windows 平台上总是返回 false。
+func (pm *UnixPtyProcessManager) IsRunning() bool {
+	return false
+}
+
+// SendCommand 在 Windows 平台上总是返回错误。
+func (pm *UnixPtyProcessManager) SendCommand(command string) error {
+	return fmt.Errorf("伪终端方案仅支持类 Unix 平台")
+}
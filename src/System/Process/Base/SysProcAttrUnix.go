@@ -0,0 +1,22 @@
+//go:build !windows
+
+package BaseProcess
+
+import "syscall"
+
+// newSysProcAttr 返回启动子进程时使用的系统属性。
+// 子进程作为新进程组的组长启动，停止时可以向整个进程组发送信号。
+func newSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// processGroup 返回由 newSysProcAttr 启动的进程所在的进程组ID
+func processGroup(pid int) int {
+	return pid
+}
+
+// newDetachedSysProcAttr 返回启动会话托管进程时使用的系统属性。
+// 托管进程在新的会话中运行，不受 Voxesis 所在终端与进程组的信号影响。
+func newDetachedSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
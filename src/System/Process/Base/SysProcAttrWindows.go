@@ -0,0 +1,27 @@
+//go:build windows
+
+package BaseProcess
+
+import "syscall"
+
+// newSysProcAttr 返回启动子进程时使用的系统属性，Windows 下隐藏控制台窗口。
+func newSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{HideWindow: true}
+}
+
+// processGroup Windows 下不使用进程组，总是返回 0
+func processGroup(pid int) int {
+	return 0
+}
+
+// detachedProcess 创建不附加到任何控制台的进程
+const detachedProcess = 0x00000008
+
+// newDetachedSysProcAttr 返回启动会话托管进程时使用的系统属性。
+// 托管进程不附加到 Voxesis 的控制台，并位于独立的进程组中。
+func newDetachedSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess,
+	}
+}
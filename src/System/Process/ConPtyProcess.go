@@ -3,6 +3,7 @@ package process
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 	"voxesis/src/Common/Entity"
 	BaseProcess "voxesis/src/System/Process/Base"
 )
@@ -10,6 +11,11 @@ import (
 type ConPtyProcess struct {
 	manager *BaseProcess.ConPtyProcessManager
 	path    string
+
+	exitCallback func(err error)
+	stopTimeout  time.Duration
+	workingDir   string
+	env          []string
 }
 
 func NewConPtyProcess(path string) *ConPtyProcess {
@@ -18,6 +24,29 @@ func NewConPtyProcess(path string) *ConPtyProcess {
 	}
 }
 
+// SetExitCallback 设置服务器退出时的回调，每次 Start 时传递给底层管理器。
+func (m *ConPtyProcess) SetExitCallback(callback func(err error)) {
+	m.exitCallback = callback
+}
+
+// SetWorkingDir 设置服务器的工作目录，为空时使用可执行文件所在目录。
+func (m *ConPtyProcess) SetWorkingDir(dir string) {
+	m.workingDir = dir
+}
+
+// SetEnv 设置服务器的完整环境变量，为 nil 时继承当前进程的环境变量。
+func (m *ConPtyProcess) SetEnv(env []string) {
+	m.env = env
+}
+
+// SetStopTimeout 设置停止时等待服务器自行退出的时长，超时后强制终止。
+func (m *ConPtyProcess) SetStopTimeout(timeout time.Duration) {
+	m.stopTimeout = timeout
+	if m.manager != nil {
+		m.manager.SetStopTimeout(timeout)
+	}
+}
+
 func (m *ConPtyProcess) Start(logCallback func(log string), args []string) error {
 	// 如果已有实例在运行，先停止
 	if m.manager != nil && m.manager.IsRunning() {
@@ -37,8 +66,19 @@ func (m *ConPtyProcess) Start(logCallback func(log string), args []string) error
 		logCallback(log)
 	})
 
+	if m.exitCallback != nil {
+		m.manager.SetExitCallback(m.exitCallback)
+	}
+	if m.stopTimeout > 0 {
+		m.manager.SetStopTimeout(m.stopTimeout)
+	}
+	m.manager.SetEnv(m.env)
+
 	// 启动进程
-	workingDir := filepath.Dir(m.path)
+	workingDir := m.workingDir
+	if workingDir == "" {
+		workingDir = filepath.Dir(m.path)
+	}
 	return m.manager.Start(workingDir, args...)
 }
 
@@ -56,6 +96,14 @@ func (m *ConPtyProcess) SendCommand(command string) error {
 	return m.manager.SendCommand(command)
 }
 
+// GetLifecycle 获取服务器的生命周期记录，从未启动时为 created 状态。
+func (m *ConPtyProcess) GetLifecycle() entity.ProcessLifecycle {
+	if m.manager == nil {
+		return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+	}
+	return m.manager.GetLifecycle()
+}
+
 // IsRunning 检查服务器是否在运行。
 func (m *ConPtyProcess) IsRunning() bool {
 	if m.manager == nil {
@@ -3,6 +3,7 @@ package process
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 	"voxesis/src/Common/Entity"
 	BaseProcess "voxesis/src/System/Process/Base"
 )
@@ -10,6 +11,13 @@ import (
 type OrdinaryProcess struct {
 	manager *BaseProcess.ProcessManager
 	path    string
+
+	exitCallback func(err error)
+	stopTimeout  time.Duration
+	workingDir   string
+	env          []string
+	limits       *entity.ResourceLimits
+	stdinFifo    string
 }
 
 func NewOrdinaryProcess(path string) *OrdinaryProcess {
@@ -18,6 +26,39 @@ func NewOrdinaryProcess(path string) *OrdinaryProcess {
 	}
 }
 
+// SetExitCallback 设置服务器退出时的回调，每次 Start 时传递给底层管理器。
+func (m *OrdinaryProcess) SetExitCallback(callback func(err error)) {
+	m.exitCallback = callback
+}
+
+// SetWorkingDir 设置服务器的工作目录，为空时使用可执行文件所在目录。
+func (m *OrdinaryProcess) SetWorkingDir(dir string) {
+	m.workingDir = dir
+}
+
+// SetEnv 设置服务器的完整环境变量，为 nil 时继承当前进程的环境变量。
+func (m *OrdinaryProcess) SetEnv(env []string) {
+	m.env = env
+}
+
+// SetResourceLimits 设置服务器的资源限制，下次启动时生效，仅 Linux 上支持。
+func (m *OrdinaryProcess) SetResourceLimits(limits *entity.ResourceLimits) {
+	m.limits = limits
+}
+
+// SetStdinFifo 设置作为标准输入的命名管道路径，下次启动时生效，Windows 下不支持。
+func (m *OrdinaryProcess) SetStdinFifo(path string) {
+	m.stdinFifo = path
+}
+
+// SetStopTimeout 设置停止时等待服务器自行退出的时长，超时后强制终止。
+func (m *OrdinaryProcess) SetStopTimeout(timeout time.Duration) {
+	m.stopTimeout = timeout
+	if m.manager != nil {
+		m.manager.SetStopTimeout(timeout)
+	}
+}
+
 func (m *OrdinaryProcess) Start(logCallback func(log string), args []string) error {
 	// 如果已有实例在运行，先停止
 	if m.manager != nil && m.manager.IsRunning() {
@@ -34,11 +75,24 @@ func (m *OrdinaryProcess) Start(logCallback func(log string), args []string) err
 
 	// 输出回调
 	m.manager.SetOutputCallback(func(log string) {
-		go logCallback(log)
+		logCallback(log)
 	})
 
+	if m.exitCallback != nil {
+		m.manager.SetExitCallback(m.exitCallback)
+	}
+	if m.stopTimeout > 0 {
+		m.manager.SetStopTimeout(m.stopTimeout)
+	}
+	m.manager.SetEnv(m.env)
+	m.manager.SetResourceLimits(m.limits)
+	m.manager.SetStdinFifo(m.stdinFifo)
+
 	// 启动进程
-	workingDir := filepath.Dir(m.path)
+	workingDir := m.workingDir
+	if workingDir == "" {
+		workingDir = filepath.Dir(m.path)
+	}
 	return m.manager.Start(workingDir, args...)
 }
 
@@ -56,6 +110,14 @@ func (m *OrdinaryProcess) SendCommand(command string) error {
 	return m.manager.SendCommand(command)
 }
 
+// GetLifecycle 获取服务器的生命周期记录，从未启动时为 created 状态。
+func (m *OrdinaryProcess) GetLifecycle() entity.ProcessLifecycle {
+	if m.manager == nil {
+		return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+	}
+	return m.manager.GetLifecycle()
+}
+
 // IsRunning 检查服务器是否在运行。
 func (m *OrdinaryProcess) IsRunning() bool {
 	if m.manager == nil {
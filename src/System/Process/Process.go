@@ -0,0 +1,27 @@
+package process
+
+import BaseProcess "voxesis/src/System/Process/Base"
+
+// ErrForceKilled 表示服务器未能在超时时间内退出，已被强制终止。
+var ErrForceKilled = BaseProcess.ErrForceKilled
+
+// DefaultStopTimeout 发送终止信号后等待服务器退出的默认时长。
+const DefaultStopTimeout = BaseProcess.DefaultStopTimeout
+
+// FindProcessByPath 查找可执行文件为 path、工作目录为 workingDir 的服务器进程，未找到时返回 0。
+func FindProcessByPath(path, workingDir string) (int32, error) {
+	return BaseProcess.FindProcessByPath(path, workingDir)
+}
+
+// ProcessCreateTime 返回进程的创建时间 (Unix 毫秒)，进程不存在时返回错误。
+func ProcessCreateTime(pid int32) (int64, error) {
+	return BaseProcess.ProcessCreateTime(pid)
+}
+
+// SessionHostFlag 以会话托管进程身份运行 Voxesis 时的命令行参数，main 检测到后应调用 RunSessionHost。
+const SessionHostFlag = BaseProcess.SessionHostFlag
+
+// RunSessionHost 以会话托管进程身份运行，返回进程退出码。
+func RunSessionHost() int {
+	return BaseProcess.RunSessionHost()
+}
@@ -0,0 +1,177 @@
+package process
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"time"
+	"voxesis/src/Common/Entity"
+	BaseProcess "voxesis/src/System/Process/Base"
+)
+
+// SessionProcess 在独立的会话托管进程中运行服务器，Voxesis 退出或重启后服务器继续运行，
+// 之后可以通过 Reconnect 重新连接并补齐期间的输出。
+type SessionProcess struct {
+	manager  *BaseProcess.SessionProcessManager
+	path     string
+	socket   string
+	terminal bool
+
+	exitCallback    func(err error)
+	stopTimeout     time.Duration
+	workingDir      string
+	env             []string
+	limits          *entity.ResourceLimits
+	scrollbackLines int
+	scrollbackBytes int
+}
+
+// NewSessionProcess 创建监听在 socket 上的会话进程实例，terminal 为 true 时服务器运行在伪终端中。
+func NewSessionProcess(path, socket string, terminal bool) *SessionProcess {
+	return &SessionProcess{
+		path:     path,
+		socket:   socket,
+		terminal: terminal,
+	}
+}
+
+// SetExitCallback 设置服务器退出或与托管进程的连接断开时的回调。
+func (m *SessionProcess) SetExitCallback(callback func(err error)) {
+	m.exitCallback = callback
+	if m.manager != nil {
+		m.manager.SetExitCallback(callback)
+	}
+}
+
+// SetWorkingDir 设置服务器的工作目录，为空时使用可执行文件所在目录。
+func (m *SessionProcess) SetWorkingDir(dir string) {
+	m.workingDir = dir
+}
+
+// SetEnv 设置服务器的完整环境变量，为 nil 时继承托管进程的环境变量。
+func (m *SessionProcess) SetEnv(env []string) {
+	m.env = env
+}
+
+// SetResourceLimits 设置服务器的资源限制，下次启动时生效，仅 Linux 上的非终端会话支持。
+func (m *SessionProcess) SetResourceLimits(limits *entity.ResourceLimits) {
+	m.limits = limits
+}
+
+// SetScrollbackLimits 设置托管进程缓冲的输出上限，Voxesis 重新连接时从中回放。
+func (m *SessionProcess) SetScrollbackLimits(lines, bytes int) {
+	m.scrollbackLines = lines
+	m.scrollbackBytes = bytes
+}
+
+// SetStopTimeout 设置停止时等待服务器自行退出的时长，超时后强制终止。
+func (m *SessionProcess) SetStopTimeout(timeout time.Duration) {
+	m.stopTimeout = timeout
+	if m.manager != nil {
+		m.manager.SetStopTimeout(timeout)
+	}
+}
+
+// Start 启动会话托管进程并由它启动服务器。
+func (m *SessionProcess) Start(logCallback func(log string), args []string) error {
+	if m.manager != nil && m.manager.IsRunning() {
+		return fmt.Errorf("会话已在运行")
+	}
+
+	// 托管进程的工作目录与 Voxesis 不同，需要使用可执行文件的绝对路径
+	binary, err := exec.LookPath(m.path)
+	if err != nil {
+		return fmt.Errorf("找不到可执行文件 %s: %w", m.path, err)
+	}
+	if binary, err = filepath.Abs(binary); err != nil {
+		return err
+	}
+	workingDir := m.workingDir
+	if workingDir == "" {
+		workingDir = filepath.Dir(binary)
+	}
+
+	m.newManager(logCallback)
+	config := BaseProcess.SessionConfig{
+		Binary:          binary,
+		Args:            args,
+		WorkingDir:      workingDir,
+		Env:             m.env,
+		Terminal:        m.terminal,
+		Socket:          m.socket,
+		StopTimeoutMs:   int(m.stopTimeout.Milliseconds()),
+		ScrollbackLines: m.scrollbackLines,
+		ScrollbackBytes: m.scrollbackBytes,
+	}
+	if !m.terminal {
+		config.Limits = m.limits
+	}
+	return m.manager.Launch(config)
+}
+
+// Reconnect 重新连接到仍在运行的会话，先回放托管进程缓冲的输出。
+func (m *SessionProcess) Reconnect(logCallback func(log string)) error {
+	if m.manager != nil && m.manager.IsRunning() {
+		return fmt.Errorf("已连接到会话")
+	}
+	m.newManager(logCallback)
+	return m.manager.Connect()
+}
+
+// newManager 创建新的底层管理器
+func (m *SessionProcess) newManager(logCallback func(log string)) {
+	m.manager = BaseProcess.NewSessionProcessManager(m.socket)
+	m.manager.SetOutputCallback(logCallback)
+	if m.exitCallback != nil {
+		m.manager.SetExitCallback(m.exitCallback)
+	}
+	if m.stopTimeout > 0 {
+		m.manager.SetStopTimeout(m.stopTimeout)
+	}
+}
+
+func (m *SessionProcess) Stop() error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return nil // 未运行，视为成功停止
+	}
+	return m.manager.Stop()
+}
+
+func (m *SessionProcess) SendCommand(command string) error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.SendCommand(command)
+}
+
+// Resize 修改服务器终端的窗口尺寸，仅终端会话支持。
+func (m *SessionProcess) Resize(cols, rows uint16) error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.Resize(cols, rows)
+}
+
+// GetLifecycle 获取服务器的生命周期记录，从未启动时为 created 状态。
+func (m *SessionProcess) GetLifecycle() entity.ProcessLifecycle {
+	if m.manager == nil {
+		return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+	}
+	return m.manager.GetLifecycle()
+}
+
+// IsRunning 检查服务器是否在运行。
+func (m *SessionProcess) IsRunning() bool {
+	if m.manager == nil {
+		return false
+	}
+	return m.manager.IsRunning()
+}
+
+// GetStatus 获取服务器进程的状态。
+func (m *SessionProcess) GetStatus() (entity.ProcessState, error) {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return entity.ProcessState{}, fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.GetProcessStatus()
+}
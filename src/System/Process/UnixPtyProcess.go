@@ -0,0 +1,129 @@
+package process
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+	"voxesis/src/Common/Entity"
+	BaseProcess "voxesis/src/System/Process/Base"
+)
+
+type UnixPtyProcess struct {
+	manager *BaseProcess.UnixPtyProcessManager
+	path    string
+
+	exitCallback func(err error)
+	stopTimeout  time.Duration
+	workingDir   string
+	env          []string
+}
+
+func NewUnixPtyProcess(path string) *UnixPtyProcess {
+	return &UnixPtyProcess{
+		path: path,
+	}
+}
+
+// SetExitCallback 设置服务器退出时的回调，每次 Start 时传递给底层管理器。
+func (m *UnixPtyProcess) SetExitCallback(callback func(err error)) {
+	m.exitCallback = callback
+}
+
+// SetWorkingDir 设置服务器的工作目录，为空时使用可执行文件所在目录。
+func (m *UnixPtyProcess) SetWorkingDir(dir string) {
+	m.workingDir = dir
+}
+
+// SetEnv 设置服务器的完整环境变量，为 nil 时继承当前进程的环境变量。
+func (m *UnixPtyProcess) SetEnv(env []string) {
+	m.env = env
+}
+
+// SetStopTimeout 设置停止时等待服务器自行退出的时长，超时后强制终止。
+func (m *UnixPtyProcess) SetStopTimeout(timeout time.Duration) {
+	m.stopTimeout = timeout
+	if m.manager != nil {
+		m.manager.SetStopTimeout(timeout)
+	}
+}
+
+func (m *UnixPtyProcess) Start(logCallback func(log string), args []string) error {
+	// 如果已有实例在运行，先停止
+	if m.manager != nil && m.manager.IsRunning() {
+		if err := m.manager.Stop(); err != nil {
+			return fmt.Errorf("无法停止正在运行的旧服务器: %w", err)
+		}
+	}
+
+	// 创建一个新的底层管理器
+	var err error
+	if m.manager, err = BaseProcess.NewUnixPtyProcessManager(m.path); err != nil {
+		return err
+	}
+
+	// 设置回调
+	m.manager.SetOutputCallback(func(log string) {
+		logCallback(log)
+	})
+
+	if m.exitCallback != nil {
+		m.manager.SetExitCallback(m.exitCallback)
+	}
+	if m.stopTimeout > 0 {
+		m.manager.SetStopTimeout(m.stopTimeout)
+	}
+	m.manager.SetEnv(m.env)
+
+	// 启动进程
+	workingDir := m.workingDir
+	if workingDir == "" {
+		workingDir = filepath.Dir(m.path)
+	}
+	return m.manager.Start(workingDir, args...)
+}
+
+func (m *UnixPtyProcess) Stop() error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return nil // 未运行，视为成功停止
+	}
+	return m.manager.Stop()
+}
+
+func (m *UnixPtyProcess) SendCommand(command string) error {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.SendCommand(command)
+}
+
+// Resize 修改服务器终端的窗口尺寸。
+func (m *UnixPtyProcess) Resize(cols, rows uint16) error {
+	if m.manager == nil {
+		return fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.Resize(cols, rows)
+}
+
+// GetLifecycle 获取服务器的生命周期记录，从未启动时为 created 状态。
+func (m *UnixPtyProcess) GetLifecycle() entity.ProcessLifecycle {
+	if m.manager == nil {
+		return entity.ProcessLifecycle{State: entity.LifecycleCreated}
+	}
+	return m.manager.GetLifecycle()
+}
+
+// IsRunning 检查服务器是否在运行。
+func (m *UnixPtyProcess) IsRunning() bool {
+	if m.manager == nil {
+		return false
+	}
+	return m.manager.IsRunning()
+}
+
+// GetStatus 获取服务器进程的状态。
+func (m *UnixPtyProcess) GetStatus() (entity.ProcessState, error) {
+	if m.manager == nil || !m.manager.IsRunning() {
+		return entity.ProcessState{}, fmt.Errorf("服务器未在运行")
+	}
+	return m.manager.GetProcessStatus()
+}
@@ -0,0 +1,18 @@
+package v_web_api
+
+import (
+	vwebcontroller "voxesis/src/Communication/InterHttp"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Backups(group *gin.RouterGroup) {
+	ctrl := &vwebcontroller.Backups{}
+
+	group.GET("/ListBackups", ctrl.ListBackups)
+	group.GET("/Download", ctrl.DownloadBackup)
+	group.GET("/GetPolicy", ctrl.GetPolicy)
+	group.POST("/CreateBackup", ctrl.CreateBackup)
+	group.POST("/DeleteBackup", ctrl.DeleteBackup)
+	group.POST("/SetPolicy", ctrl.SetPolicy)
+}
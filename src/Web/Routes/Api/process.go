@@ -11,9 +11,38 @@ func Process(group *gin.RouterGroup) {
 	vcommon.ProcessCtrl = &vwebcontroller.Process{}
 
 	group.POST("/NewProcess", vcommon.ProcessCtrl.NewProcess)
+	group.GET("/ListProcesses", vcommon.ProcessCtrl.ListProcesses)
+	group.POST("/GetProcess", vcommon.ProcessCtrl.GetProcess)
+	group.POST("/UpdateProcess", vcommon.ProcessCtrl.UpdateProcess)
+	group.POST("/DeleteProcess", vcommon.ProcessCtrl.DeleteProcess)
 	group.POST("/Start", vcommon.ProcessCtrl.Start)
+	group.POST("/Attach", vcommon.ProcessCtrl.Attach)
+	group.POST("/StartWithProfile", vcommon.ProcessCtrl.StartWithProfile)
+	group.POST("/ListProfiles", vcommon.ProcessCtrl.ListProfiles)
+	group.POST("/SetProfiles", vcommon.ProcessCtrl.SetProfiles)
 	group.POST("/Stop", vcommon.ProcessCtrl.Stop)
+	group.POST("/SetStopSequence", vcommon.ProcessCtrl.SetStopSequence)
 	group.POST("/SendCommand", vcommon.ProcessCtrl.SendCommand)
+	group.POST("/QueueCommand", vcommon.ProcessCtrl.QueueCommand)
+	group.POST("/ExecuteCommand", vcommon.ProcessCtrl.ExecuteCommand)
+	group.POST("/ListQueuedCommands", vcommon.ProcessCtrl.ListQueuedCommands)
+	group.POST("/CancelQueuedCommand", vcommon.ProcessCtrl.CancelQueuedCommand)
+	group.POST("/Resize", vcommon.ProcessCtrl.Resize)
 	group.POST("/GetProcessStatus", vcommon.ProcessCtrl.GetProcessStatus)
+	group.GET("/metrics", vcommon.ProcessCtrl.GetMetrics)
+	group.GET("/players", vcommon.ProcessCtrl.GetOnlinePlayers)
+	group.GET("/players/playtime", vcommon.ProcessCtrl.GetPlaytime)
+	group.GET("/players/sessions", vcommon.ProcessCtrl.GetPlayerSessions)
+	group.POST("/GetLifecycle", vcommon.ProcessCtrl.GetLifecycle)
+	group.POST("/SetRestartPolicy", vcommon.ProcessCtrl.SetRestartPolicy)
+	group.POST("/GetRestartStatus", vcommon.ProcessCtrl.GetRestartStatus)
+	group.POST("/PlanRestart", vcommon.ProcessCtrl.PlanRestart)
+	group.POST("/AbortRestart", vcommon.ProcessCtrl.AbortRestart)
+	group.POST("/GetPlannedRestart", vcommon.ProcessCtrl.GetPlannedRestart)
+	group.POST("/SetRestartNotice", vcommon.ProcessCtrl.SetRestartNotice)
+	group.POST("/SetReadinessProbe", vcommon.ProcessCtrl.SetReadinessProbe)
+	group.POST("/SetResourceLimits", vcommon.ProcessCtrl.SetResourceLimits)
+	group.POST("/SetScrollbackConfig", vcommon.ProcessCtrl.SetScrollbackConfig)
+	group.POST("/GetOutputSince", vcommon.ProcessCtrl.GetOutputSince)
 	group.GET("/GetProcessOutput", vcommon.ProcessCtrl.GetProcessOutput)
 }
@@ -0,0 +1,17 @@
+package v_web_api
+
+import (
+	vwebcontroller "voxesis/src/Communication/InterHttp"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Rules(group *gin.RouterGroup) {
+	ctrl := &vwebcontroller.Rules{}
+
+	group.GET("/ListRules", ctrl.ListRules)
+	group.GET("/GetRule", ctrl.GetRule)
+	group.POST("/CreateRule", ctrl.CreateRule)
+	group.POST("/UpdateRule", ctrl.UpdateRule)
+	group.POST("/DeleteRule", ctrl.DeleteRule)
+}
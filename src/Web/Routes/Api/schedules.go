@@ -0,0 +1,19 @@
+package v_web_api
+
+import (
+	vwebcontroller "voxesis/src/Communication/InterHttp"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Schedules(group *gin.RouterGroup) {
+	ctrl := &vwebcontroller.Scheduler{}
+
+	group.GET("/ListJobs", ctrl.ListJobs)
+	group.GET("/GetJob", ctrl.GetJob)
+	group.GET("/ListRuns", ctrl.ListRuns)
+	group.POST("/CreateJob", ctrl.CreateJob)
+	group.POST("/UpdateJob", ctrl.UpdateJob)
+	group.POST("/DeleteJob", ctrl.DeleteJob)
+	group.POST("/RunJob", ctrl.RunJob)
+}
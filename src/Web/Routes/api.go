@@ -14,6 +14,9 @@ func ApiRoutes(r *gin.RouterGroup) {
 
 	vwebapi.Process(group.Group("/process"))
 	vwebapi.Plugins(group.Group("/plugins"))
+	vwebapi.Rules(group.Group("/rules"))
+	vwebapi.Schedules(group.Group("/schedules"))
+	vwebapi.Backups(group.Group("/backups"))
 
 	vwebapi.Utils(group.Group("/utils"))
 }
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"voxesis/src/Common"
+	vdataimpl "voxesis/src/Common/Data/impl"
 	vlogger "voxesis/src/Common/Logger"
 	communication "voxesis/src/Communication"
 
@@ -28,6 +29,13 @@ func InitAPP(assets embed.FS) *application.App {
 	// 初始化日志管理器
 	initLoggerManager(appDir)
 
+	// 初始化数据库
+	initDataBase(appDir)
+
+	// 进程定义在初始化时恢复，需要提前确定应用目录
+	v_common.AppDir = appDir
+	v_common.PluginDir = pluginsDir
+
 	communication.Init()
 
 	app := application.New(application.Options{
@@ -39,6 +47,9 @@ func InitAPP(assets embed.FS) *application.App {
 			application.NewService(communication.PluginIpc),
 			application.NewService(communication.SystemDialogIpc),
 			application.NewService(communication.ProcessIpc),
+			application.NewService(communication.RulesIpc),
+			application.NewService(communication.SchedulerIpc),
+			application.NewService(communication.BackupIpc),
 			application.NewService(communication.UtilsIpc),
 		},
 		Assets: application.AssetOptions{
@@ -85,8 +96,6 @@ func InitAPP(assets embed.FS) *application.App {
 	})
 
 	v_common.App = app
-	v_common.AppDir = appDir
-	v_common.PluginDir = pluginsDir
 
 	return app
 }
@@ -98,3 +107,11 @@ func initLoggerManager(appDir string) {
 		return
 	}
 }
+
+func initDataBase(appDir string) {
+	// 初始化数据库
+	if err := vdataimpl.InitDataBase(filepath.Join(appDir, "data"), "voxesis.db"); err != nil {
+		log.Fatalf("数据库初始化失败: %v\n", err)
+		return
+	}
+}